@@ -188,10 +188,16 @@ func runWeb(c *cli.Context) error {
 			m.Get("/repos", route.ExploreRepos)
 			m.Get("/users", route.ExploreUsers)
 			m.Get("/organizations", route.ExploreOrganizations)
+			m.Get("/code", route.ExploreCode)
 		}, ignSignIn)
 		m.Combo("/install", route.InstallInit).Get(route.Install).
 			Post(bindIgnErr(form.Install{}), route.InstallPost)
 		m.Get("/^:type(issues|pulls)$", reqSignIn, user.Issues)
+		m.Group("/timesheet", func() {
+			m.Get("", user.Timesheet)
+			m.Get("/export", user.ExportTimesheet)
+			m.Post("/delete", user.DeleteTrackedTime)
+		}, reqSignIn)
 
 		// ***** START: User *****
 		m.Group("/user", func() {
@@ -239,6 +245,10 @@ func runWeb(c *cli.Context) error {
 				m.Post("/leave", user.SettingsLeaveOrganization)
 			})
 
+			m.Combo("/searches").Get(user.SettingsSearches).
+				Post(bindIgnErr(form.SavedSearch{}), user.SettingsSearchesPost)
+			m.Post("/searches/delete", user.DeleteSavedSearch)
+
 			settingsHandler := user.NewSettingsHandler(user.NewSettingsStore())
 			m.Combo("/applications").Get(settingsHandler.Applications()).
 				Post(bindIgnErr(form.NewAccessToken{}), settingsHandler.ApplicationsPost())
@@ -365,6 +375,27 @@ func runWeb(c *cli.Context) error {
 			}, repo.InjectOrgRepoContext())
 		}
 
+		// Project routes are shared by repositories and organizations.
+		projectCardRoutes := func() {
+			m.Post("/cards", repo.AddProjectCard)
+			m.Post("/cards/:card/move", repo.MoveProjectCard)
+			m.Post("/cards/:card/delete", repo.DeleteProjectCard)
+		}
+		projectManageRoutes := func() {
+			m.Combo("/new").Get(repo.NewProject).
+				Post(bindIgnErr(form.CreateProject{}), repo.NewProjectPost)
+			m.Group("/:id", func() {
+				m.Combo("/edit").Get(repo.EditProject).
+					Post(bindIgnErr(form.CreateProject{}), repo.EditProjectPost)
+				m.Post("/:action(open|close)", repo.ChangeProjectStatus)
+				m.Post("/delete", repo.DeleteProject)
+				m.Post("/columns", repo.NewProjectColumn)
+				m.Post("/columns/:column/edit", repo.EditProjectColumn)
+				m.Post("/columns/:column/move", repo.MoveProjectColumn)
+				m.Post("/columns/:column/delete", repo.DeleteProjectColumn)
+			})
+		}
+
 		// ***** START: Organization *****
 		m.Group("/org", func() {
 			m.Group("", func() {
@@ -383,6 +414,12 @@ func runWeb(c *cli.Context) error {
 				m.Get("/members/action/:action", org.MembersAction)
 
 				m.Get("/teams", org.Teams)
+
+				m.Group("/projects", func() {
+					m.Get("", repo.Projects)
+					m.Get("/:id", repo.ViewProject)
+					m.Group("/:id", projectCardRoutes)
+				})
 			}, context.OrgAssignment(true))
 
 			m.Group("/:org", func() {
@@ -405,10 +442,18 @@ func runWeb(c *cli.Context) error {
 					m.Post("/avatar", binding.MultipartForm(form.Avatar{}), org.SettingsAvatar)
 					m.Post("/avatar/delete", org.SettingsDeleteAvatar)
 					m.Group("/hooks", webhookRoutes)
+					m.Group("/labels", func() {
+						m.Get("", org.SettingsLabels)
+						m.Post("/new", bindIgnErr(form.CreateLabel{}), org.NewLabel)
+						m.Post("/edit", bindIgnErr(form.CreateLabel{}), org.UpdateLabel)
+						m.Post("/delete", org.DeleteLabel)
+						m.Post("/merge", org.MergeLabels)
+					})
 					m.Route("/delete", "GET,POST", org.SettingsDelete)
 				})
 
 				m.Route("/invitations/new", "GET,POST", org.Invitation)
+				m.Group("/projects", projectManageRoutes)
 			}, context.OrgAssignment(true, true))
 		}, reqSignIn)
 		// ***** END: Organization *****
@@ -478,6 +523,8 @@ func runWeb(c *cli.Context) error {
 			m.Get("/issues/:index", repo.ViewIssue)
 			m.Get("/labels/", repo.RetrieveLabels, repo.Labels)
 			m.Get("/milestones", repo.Milestones)
+			m.Get("/projects", repo.MustEnableIssues, repo.Projects)
+			m.Get("/projects/:id", repo.MustEnableIssues, repo.ViewProject)
 		}, ignSignIn, context.RepoAssignment(true))
 		m.Group("/:username/:reponame", func() {
 			// FIXME: should use different URLs but mostly same logic for comments of issue and pull reuqest.
@@ -489,12 +536,14 @@ func runWeb(c *cli.Context) error {
 				m.Group("/:index", func() {
 					m.Post("/title", repo.UpdateIssueTitle)
 					m.Post("/content", repo.UpdateIssueContent)
+					m.Post("/reactions", repo.ToggleIssueReaction)
 					m.Combo("/comments").Post(bindIgnErr(form.CreateComment{}), repo.NewComment)
 				})
 			})
 			m.Group("/comments/:id", func() {
 				m.Post("", repo.UpdateCommentContent)
 				m.Post("/delete", repo.DeleteComment)
+				m.Post("/reactions", repo.ToggleCommentReaction)
 			})
 		}, reqSignIn, context.RepoAssignment(true))
 		m.Group("/:username/:reponame", func() {
@@ -512,6 +561,18 @@ func runWeb(c *cli.Context) error {
 					m.Post("/label", repo.UpdateIssueLabel)
 					m.Post("/milestone", repo.UpdateIssueMilestone)
 					m.Post("/assignee", repo.UpdateIssueAssignee)
+					m.Post("/reviewer", repo.UpdateIssueReviewer)
+					m.Post("/deadline", repo.UpdateIssueDeadline)
+					m.Post("/dependency", repo.AddIssueDependency)
+					m.Post("/dependency/delete", repo.RemoveIssueDependency)
+					m.Post("/transfer", repo.TransferIssue)
+					m.Post("/:action(lock|unlock)", repo.LockIssue)
+					m.Group("/times", func() {
+						m.Post("/add", repo.AddIssueTrackedTime)
+						m.Post("/stopwatch/start", repo.StartIssueStopwatch)
+						m.Post("/stopwatch/stop", repo.StopIssueStopwatch)
+						m.Post("/stopwatch/cancel", repo.CancelIssueStopwatch)
+					})
 				}, reqRepoWriter)
 			})
 			m.Group("/labels", func() {
@@ -528,6 +589,10 @@ func runWeb(c *cli.Context) error {
 				m.Get("/:id/:action", repo.ChangeMilestonStatus)
 				m.Post("/delete", repo.DeleteMilestone)
 			}, reqRepoWriter, context.RepoRef())
+			m.Group("/projects", func() {
+				projectManageRoutes()
+				m.Group("/:id", projectCardRoutes)
+			}, repo.MustEnableIssues, reqRepoWriter)
 
 			m.Group("/releases", func() {
 				m.Get("/new", repo.NewRelease)
@@ -543,8 +608,7 @@ func runWeb(c *cli.Context) error {
 			// for PR in same repository. After select branch on the page, the URL contains redundant head user name.
 			// e.g. /org1/test-repo/compare/master...org1:develop
 			// which should be /org1/test-repo/compare/master...develop
-			m.Combo("/compare/*", repo.MustAllowPulls).Get(repo.CompareAndPullRequest).
-				Post(bindIgnErr(form.NewIssue{}), repo.CompareAndPullRequestPost)
+			m.Post("/compare/*", repo.MustAllowPulls, bindIgnErr(form.NewIssue{}), repo.CompareAndPullRequestPost)
 
 			m.Group("", func() {
 				m.Combo("/_edit/*").Get(repo.EditFile).
@@ -580,6 +644,7 @@ func runWeb(c *cli.Context) error {
 			m.Group("", func() {
 				m.Get("/releases", repo.MustBeNotBare, repo.Releases)
 				m.Get("/pulls", repo.RetrieveLabels, repo.Pulls)
+				m.Get("/pulls/:index([0-9]+)\\.:ext(patch|diff)", repo.MustAllowPulls, repo.RawPullDiff)
 				m.Get("/pulls/:index", repo.ViewPull)
 			}, context.RepoRef())
 
@@ -607,18 +672,26 @@ func runWeb(c *cli.Context) error {
 				m.Get("/commits", context.RepoRef(), repo.ViewPullCommits)
 				m.Get("/files", context.RepoRef(), repo.ViewPullFiles)
 				m.Post("/merge", reqRepoWriter, repo.MergePullRequest)
+				m.Post("/auto_merge", reqRepoWriter, repo.ScheduleAutoMerge)
+				m.Post("/auto_merge/cancel", reqRepoWriter, repo.CancelAutoMerge)
+				m.Post("/approve", reqRepoWriter, repo.ApprovePullRequest)
+				m.Post("/ready", reqSignIn, repo.MarkPullRequestReady)
+				m.Post("/draft", reqSignIn, repo.MarkPullRequestDraft)
 			}, repo.MustAllowPulls)
 
 			m.Group("", func() {
 				m.Get("/src/*", repo.Home)
 				m.Get("/raw/*", repo.SingleDownload)
+				m.Get("/blame/*", repo.Blame)
 				m.Get("/commits/*", repo.RefCommits)
+				m.Get("/graph", repo.Graph)
 				m.Get("/commit/:sha([a-f0-9]{7,40})$", repo.Diff)
 				m.Get("/forks", repo.Forks)
+				m.Get("/search", repo.SearchCode)
 			}, repo.MustBeNotBare, context.RepoRef())
 			m.Get("/commit/:sha([a-f0-9]{7,40})\\.:ext(patch|diff)", repo.MustBeNotBare, repo.RawDiff)
 
-			m.Get("/compare/:before([a-z0-9]{40})\\.\\.\\.:after([a-z0-9]{40})", repo.MustBeNotBare, context.RepoRef(), repo.CompareDiff)
+			m.Get("/compare/*", repo.MustBeNotBare, repo.Compare)
 		}, ignSignIn, context.RepoAssignment())
 		m.Group("/:username/:reponame", func() {
 			m.Get("", repo.Home)
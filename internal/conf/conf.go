@@ -330,6 +330,20 @@ func Init(customConf string) error {
 		Mirror.DefaultInterval = 8
 	}
 
+	// ****************************
+	// ----- Indexer settings -----
+	// ****************************
+
+	if err = File.Section("indexer").MapTo(&Indexer); err != nil {
+		return errors.Wrap(err, "mapping [indexer] section")
+	}
+	if !filepath.IsAbs(Indexer.RepoIndexerPath) {
+		Indexer.RepoIndexerPath = filepath.Join(Server.AppDataPath, Indexer.RepoIndexerPath)
+	}
+	if !filepath.IsAbs(Indexer.IssueIndexerPath) {
+		Indexer.IssueIndexerPath = filepath.Join(Server.AppDataPath, Indexer.IssueIndexerPath)
+	}
+
 	// *************************
 	// ----- I18n settings -----
 	// *************************
@@ -363,6 +377,8 @@ func Init(customConf string) error {
 		return errors.Wrap(err, "mapping [markdown] section")
 	} else if err = File.Section("smartypants").MapTo(&Smartypants); err != nil {
 		return errors.Wrap(err, "mapping [smartypants] section")
+	} else if err = File.Section("markup").MapTo(&Markup); err != nil {
+		return errors.Wrap(err, "mapping [markup] section")
 	} else if err = File.Section("admin").MapTo(&Admin); err != nil {
 		return errors.Wrap(err, "mapping [admin] section")
 	} else if err = File.Section("cron").MapTo(&Cron); err != nil {
@@ -45,6 +45,7 @@ func TestInit(t *testing.T) {
 		{"time", &Time},
 		{"picture", &Picture},
 		{"mirror", &Mirror},
+		{"indexer", &Indexer},
 		{"i18n", &I18n},
 	} {
 		err := cfg.Section(v.section).ReflectFrom(v.config)
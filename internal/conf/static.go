@@ -131,6 +131,15 @@ var (
 		DefaultInterval int
 	}
 
+	// Indexer settings
+	Indexer struct {
+		RepoIndexerEnabled  bool
+		RepoIndexerPath     string
+		MaxFileSize         int64
+		IssueIndexerEnabled bool
+		IssueIndexerPath    string
+	}
+
 	// Webhook settings
 	Webhook struct {
 		Types          []string
@@ -155,6 +164,25 @@ var (
 		AngledQuotes bool
 	}
 
+	// Markup settings
+	Markup struct {
+		CSV struct {
+			MaxFileSize int64
+			MaxRows     int
+		} `ini:"markup.csv"`
+		AsciiDoc struct {
+			FileExtensions []string
+			RenderCommand  string
+			RenderTimeout  time.Duration
+		} `ini:"markup.asciidoc"`
+		ReStructuredText struct {
+			FileExtensions        []string
+			RenderCommand         string
+			EnableBuiltinRenderer bool
+			RenderTimeout         time.Duration
+		} `ini:"markup.rst"`
+	}
+
 	// Admin settings
 	Admin struct {
 		DisableRegularOrgCreation bool
@@ -185,6 +213,11 @@ var (
 			Schedule   string
 			OlderThan  time.Duration
 		} `ini:"cron.repo_archive_cleanup"`
+		IssueOverdueDigest struct {
+			Enabled    bool
+			RunAtStart bool
+			Schedule   string
+		} `ini:"cron.issue_overdue_digest"`
 	}
 
 	// Git settings
@@ -203,6 +236,10 @@ var (
 			Clone   int
 			Pull    int
 			Diff    int
+			Blame   int
+			Graph   int
+			Log     int
+			Index   int
 			GC      int `ini:"GC"`
 		} `ini:"git.timeout"`
 	}
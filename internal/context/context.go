@@ -96,6 +96,35 @@ func (c *Context) UserID() int64 {
 	return c.User.ID
 }
 
+// CanReadIssue returns true if current user is allowed to read the issue, which
+// may belong to a repository other than the current one. This method assumes
+// issue.Repo has been loaded.
+func (c *Context) CanReadIssue(issue *database.Issue) bool {
+	if c.Repo != nil && c.Repo.Repository != nil && issue.RepoID == c.Repo.Repository.ID {
+		return true
+	}
+
+	return database.Handle.Permissions().Authorize(c.Req.Context(), c.UserID(), issue.RepoID, database.AccessModeRead,
+		database.AccessModeOptions{
+			OwnerID: issue.Repo.OwnerID,
+			Private: issue.Repo.IsPrivate,
+		},
+	)
+}
+
+// GetTransferredIssue returns the issue that was transferred out of the
+// current repository with the index. It returns database.ErrIssueNotExist if
+// there is no such issue that current user is allowed to read.
+func (c *Context) GetTransferredIssue(index int64) (*database.Issue, error) {
+	issue, err := database.GetIssueByRedirect(c.Repo.Repository.ID, index)
+	if err != nil {
+		return nil, err
+	} else if !c.CanReadIssue(issue) {
+		return nil, database.ErrIssueNotExist{}
+	}
+	return issue, nil
+}
+
 func (c *Context) GetErrMsg() string {
 	return c.Data["ErrorMsg"].(string)
 }
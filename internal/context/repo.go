@@ -71,6 +71,13 @@ func (r *Repository) HasAccess() bool {
 	return r.AccessMode >= database.AccessModeRead
 }
 
+// CanComment returns true if current user can comment on the issue, react to
+// it and edit comments, which writers can do even when the conversation is
+// locked.
+func (r *Repository) CanComment(issue *database.Issue) bool {
+	return !issue.IsLocked || r.IsWriter()
+}
+
 // CanEnableEditor returns true if repository is editable and user has proper access level.
 func (r *Repository) CanEnableEditor() bool {
 	return r.Repository.CanEnableEditor() && r.IsViewBranch && r.IsWriter() && !r.Repository.IsBranchRequirePullRequest(r.BranchName)
@@ -0,0 +1,33 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package context
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"gogs.io/gogs/internal/database"
+)
+
+func TestRepository_CanComment(t *testing.T) {
+	tests := []struct {
+		name       string
+		accessMode database.AccessMode
+		isLocked   bool
+		want       bool
+	}{
+		{name: "reader on unlocked issue", accessMode: database.AccessModeRead, want: true},
+		{name: "reader on locked issue", accessMode: database.AccessModeRead, isLocked: true, want: false},
+		{name: "writer on locked issue", accessMode: database.AccessModeWrite, isLocked: true, want: true},
+		{name: "admin on locked issue", accessMode: database.AccessModeAdmin, isLocked: true, want: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &Repository{AccessMode: test.accessMode}
+			assert.Equal(t, test.want, r.CanComment(&database.Issue{IsLocked: test.isLocked}))
+		})
+	}
+}
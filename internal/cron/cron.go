@@ -66,6 +66,17 @@ func NewContext() {
 			go database.DeleteOldRepositoryArchives()
 		}
 	}
+	if conf.Cron.IssueOverdueDigest.Enabled {
+		entry, err = c.AddFunc("Issue overdue digest", conf.Cron.IssueOverdueDigest.Schedule, database.SendIssueOverdueDigests)
+		if err != nil {
+			log.Fatal("Cron.(issue overdue digest): %v", err)
+		}
+		if conf.Cron.IssueOverdueDigest.RunAtStart {
+			entry.Prev = time.Now()
+			entry.ExecTimes++
+			go database.SendIssueOverdueDigests()
+		}
+	}
 	c.Start()
 }
 
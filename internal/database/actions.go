@@ -402,6 +402,10 @@ func updateCommitReferencesToIssues(doer *User, repo *Repository, commits []*Pus
 			}
 
 			if err = issue.ChangeStatus(doer, repo, true); err != nil {
+				if IsErrIssueBlocked(err) {
+					log.Trace("Issue [%d] is blocked by open dependencies, skip closing", issue.ID)
+					continue
+				}
 				return err
 			}
 		}
@@ -710,6 +714,7 @@ const (
 	ActionMirrorSyncPush                          // 20
 	ActionMirrorSyncCreate                        // 21
 	ActionMirrorSyncDelete                        // 22
+	ActionTransferIssue                           // 23
 )
 
 // Action is a user operation to a repository. It implements template.Actioner
@@ -805,6 +810,12 @@ func (a *Action) GetIssueInfos() []string {
 	return strings.SplitN(a.Content, "|", 2)
 }
 
+// GetTransferIssueInfos returns the source repository, the old index, the
+// target repository and the new index of a transferred issue.
+func (a *Action) GetTransferIssueInfos() []string {
+	return strings.SplitN(a.Content, "|", 4)
+}
+
 func (a *Action) GetIssueTitle() string {
 	index, _ := strconv.ParseInt(a.GetIssueInfos()[0], 10, 64)
 	issue, err := GetIssueByIndex(a.RepoID, index)
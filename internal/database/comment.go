@@ -37,8 +37,49 @@ const (
 	CommentTypeCommentRef
 	// Reference from a pull request
 	CommentTypePullRef
+
+	// Events.
+	// Label added (NewValue) or removed (OldValue)
+	CommentTypeLabel
+	// Milestone changed from OldValue to NewValue
+	CommentTypeMilestone
+	// Assignee added (NewValue) or removed (OldValue)
+	CommentTypeAssignees
+	// Title changed from OldValue to NewValue
+	CommentTypeChangeTitle
+	// Due date set (NewValue), changed or removed (OldValue)
+	CommentTypeDeadline
+	// Issue transferred from OldValue to NewValue
+	CommentTypeTransfer
+	// Conversation locked with the reason of NewValue
+	CommentTypeLock
+	// Conversation unlocked
+	CommentTypeUnlock
 )
 
+var commentTypeNames = map[CommentType]string{
+	CommentTypeComment:     "comment",
+	CommentTypeReopen:      "reopen",
+	CommentTypeClose:       "close",
+	CommentTypeIssueRef:    "issue_ref",
+	CommentTypeCommitRef:   "commit_ref",
+	CommentTypeCommentRef:  "comment_ref",
+	CommentTypePullRef:     "pull_ref",
+	CommentTypeLabel:       "label",
+	CommentTypeMilestone:   "milestone",
+	CommentTypeAssignees:   "assignees",
+	CommentTypeChangeTitle: "change_title",
+	CommentTypeDeadline:    "deadline",
+	CommentTypeTransfer:    "transfer",
+	CommentTypeLock:        "lock",
+	CommentTypeUnlock:      "unlock",
+}
+
+// String returns the name of the comment type used by the API.
+func (t CommentType) String() string {
+	return commentTypeNames[t]
+}
+
 type CommentTag int
 
 const (
@@ -69,7 +110,15 @@ type Comment struct {
 	// Reference issue in commit message
 	CommitSHA string `xorm:"VARCHAR(40)"`
 
+	// For event comments, the values before and after the change.
+	LabelID    int64
+	Label      *Label `xorm:"-" json:"-" gorm:"-"`
+	AssigneeID int64
+	OldValue   string `xorm:"TEXT"`
+	NewValue   string `xorm:"TEXT"`
+
 	Attachments []*Attachment `xorm:"-" json:"-" gorm:"-"`
+	Reactions   ReactionList  `xorm:"-" json:"-" gorm:"-"`
 
 	// For view issue page.
 	ShowTag CommentTag `xorm:"-" json:"-" gorm:"-"`
@@ -111,11 +160,19 @@ func (c *Comment) loadAttributes(e Engine) (err error) {
 		if err != nil {
 			return fmt.Errorf("getIssueByID [%d]: %v", c.IssueID, err)
 		}
-		if c.Issue.Repo == nil {
-			c.Issue.Repo, err = getRepositoryByID(e, c.Issue.RepoID)
-			if err != nil {
-				return fmt.Errorf("getRepositoryByID [%d]: %v", c.Issue.RepoID, err)
-			}
+	}
+	if c.Issue.Repo == nil {
+		c.Issue.Repo, err = getRepositoryByID(e, c.Issue.RepoID)
+		if err != nil {
+			return fmt.Errorf("getRepositoryByID [%d]: %v", c.Issue.RepoID, err)
+		}
+	}
+
+	if c.Type == CommentTypeLabel && c.LabelID > 0 && c.Label == nil {
+		// The label could have been deleted, the event falls back to the recorded name.
+		c.Label, err = getLabelAvailableToRepoByID(e, c.Issue.Repo, c.LabelID)
+		if err != nil && !IsErrLabelNotExist(err) {
+			return fmt.Errorf("getLabelAvailableToRepoByID [%d]: %v", c.LabelID, err)
 		}
 	}
 
@@ -197,6 +254,11 @@ func createComment(e *xorm.Session, opts *CreateCommentOptions) (_ *Comment, err
 		CommitSHA: opts.CommitSHA,
 		Line:      opts.LineNum,
 		Content:   opts.Content,
+
+		LabelID:    opts.LabelID,
+		AssigneeID: opts.AssigneeID,
+		OldValue:   opts.OldValue,
+		NewValue:   opts.NewValue,
 	}
 	if _, err = e.Insert(comment); err != nil {
 		return nil, err
@@ -317,6 +379,11 @@ type CreateCommentOptions struct {
 	LineNum     int64
 	Content     string
 	Attachments []string // UUIDs of attachments
+
+	LabelID    int64
+	AssigneeID int64
+	OldValue   string
+	NewValue   string
 }
 
 // CreateComment creates comment of issue or commit.
@@ -332,7 +399,13 @@ func CreateComment(opts *CreateCommentOptions) (comment *Comment, err error) {
 		return nil, err
 	}
 
-	return comment, sess.Commit()
+	if err = sess.Commit(); err != nil {
+		return nil, err
+	}
+	if comment.Type == CommentTypeComment {
+		UpdateIssueIndexer(comment.IssueID)
+	}
+	return comment, nil
 }
 
 // CreateIssueComment creates a plain issue comment.
@@ -483,6 +556,7 @@ func UpdateComment(doer *User, c *Comment, oldContent string) (err error) {
 	if _, err = x.Id(c.ID).AllCols().Update(c); err != nil {
 		return err
 	}
+	UpdateIssueIndexer(c.IssueID)
 
 	if err = c.Issue.LoadAttributes(); err != nil {
 		log.Error("Issue.LoadAttributes [issue_id: %d]: %v", c.IssueID, err)
@@ -523,6 +597,9 @@ func DeleteCommentByID(doer *User, id int64) error {
 	if _, err = sess.ID(comment.ID).Delete(new(Comment)); err != nil {
 		return err
 	}
+	if err = deleteCommentReactions(sess, comment.ID); err != nil {
+		return fmt.Errorf("delete comment reactions: %v", err)
+	}
 
 	if comment.Type == CommentTypeComment {
 		if _, err = sess.Exec("UPDATE `issue` SET num_comments = num_comments - 1 WHERE id = ?", comment.IssueID); err != nil {
@@ -533,6 +610,9 @@ func DeleteCommentByID(doer *User, id int64) error {
 	if err = sess.Commit(); err != nil {
 		return fmt.Errorf("commit: %v", err)
 	}
+	if comment.Type == CommentTypeComment {
+		UpdateIssueIndexer(comment.IssueID)
+	}
 
 	_, err = DeleteAttachmentsByComment(comment.ID, true)
 	if err != nil {
@@ -0,0 +1,228 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"xorm.io/xorm"
+)
+
+// CommitStatusState is the state of a commit status reported by an external service.
+type CommitStatusState string
+
+const (
+	CommitStatusPending CommitStatusState = "pending"
+	CommitStatusSuccess CommitStatusState = "success"
+	CommitStatusFailure CommitStatusState = "failure"
+	CommitStatusError   CommitStatusState = "error"
+)
+
+// IsValid returns true if the state is one of the known states.
+func (s CommitStatusState) IsValid() bool {
+	switch s {
+	case CommitStatusPending, CommitStatusSuccess, CommitStatusFailure, CommitStatusError:
+		return true
+	}
+	return false
+}
+
+// priority returns the weight of the state when combining multiple statuses,
+// the state with the higher priority wins.
+func (s CommitStatusState) priority() int {
+	switch s {
+	case CommitStatusSuccess:
+		return 1
+	case CommitStatusPending:
+		return 2
+	case CommitStatusFailure:
+		return 3
+	case CommitStatusError:
+		return 4
+	}
+	return 0
+}
+
+// CommitStatus represents a status of a commit reported by an external service
+// (e.g. a CI system) under a given context.
+type CommitStatus struct {
+	ID          int64
+	RepoID      int64  `xorm:"INDEX"`
+	SHA         string `xorm:"VARCHAR(40) INDEX"`
+	State       CommitStatusState
+	TargetURL   string `xorm:"TEXT"`
+	Description string `xorm:"TEXT"`
+	Context     string
+	CreatorID   int64
+	Creator     *User `xorm:"-" json:"-" gorm:"-"`
+
+	Created     time.Time `xorm:"-" json:"-" gorm:"-"`
+	CreatedUnix int64
+	Updated     time.Time `xorm:"-" json:"-" gorm:"-"`
+	UpdatedUnix int64
+}
+
+func (s *CommitStatus) BeforeInsert() {
+	s.CreatedUnix = time.Now().Unix()
+	s.UpdatedUnix = s.CreatedUnix
+}
+
+func (s *CommitStatus) BeforeUpdate() {
+	s.UpdatedUnix = time.Now().Unix()
+}
+
+func (s *CommitStatus) AfterSet(colName string, _ xorm.Cell) {
+	switch colName {
+	case "created_unix":
+		s.Created = time.Unix(s.CreatedUnix, 0).Local()
+	case "updated_unix":
+		s.Updated = time.Unix(s.UpdatedUnix, 0).Local()
+	}
+}
+
+// LoadAttributes loads the creator of the status.
+func (s *CommitStatus) LoadAttributes() (err error) {
+	if s.Creator == nil && s.CreatorID > 0 {
+		s.Creator, err = Handle.Users().GetByID(context.TODO(), s.CreatorID)
+		if err != nil {
+			return fmt.Errorf("get creator [%d]: %v", s.CreatorID, err)
+		}
+	}
+	return nil
+}
+
+// NewCommitStatusOptions contains options for creating a commit status.
+type NewCommitStatusOptions struct {
+	Repo        *Repository
+	Creator     *User
+	SHA         string
+	State       CommitStatusState
+	TargetURL   string
+	Description string
+	Context     string
+}
+
+// NewCommitStatus creates a new status for the commit of given SHA. An empty
+// context is normalized to "default". Pull requests with auto-merge scheduled
+// on the commit are re-evaluated.
+func NewCommitStatus(opts NewCommitStatusOptions) (*CommitStatus, error) {
+	if !opts.State.IsValid() {
+		return nil, fmt.Errorf("invalid state %q", opts.State)
+	}
+
+	status := &CommitStatus{
+		RepoID:      opts.Repo.ID,
+		SHA:         strings.ToLower(opts.SHA),
+		State:       opts.State,
+		TargetURL:   opts.TargetURL,
+		Description: opts.Description,
+		Context:     strings.TrimSpace(opts.Context),
+		CreatorID:   opts.Creator.ID,
+		Creator:     opts.Creator,
+	}
+	if status.Context == "" {
+		status.Context = "default"
+	}
+	if _, err := x.Insert(status); err != nil {
+		return nil, err
+	}
+
+	go addAutoMergeTasksBySHA(status.RepoID, status.SHA)
+	return status, nil
+}
+
+// GetCommitStatuses returns all statuses of the commit of given SHA in the
+// repository, ordered from newest to oldest.
+func GetCommitStatuses(repoID int64, sha string) ([]*CommitStatus, error) {
+	statuses := make([]*CommitStatus, 0, 5)
+	return statuses, x.Where("repo_id = ? AND sha = ?", repoID, strings.ToLower(sha)).Desc("id").Find(&statuses)
+}
+
+// latestCommitStatuses filters the statuses (ordered from newest to oldest)
+// and keeps only the most recent one of each context.
+func latestCommitStatuses(statuses []*CommitStatus) []*CommitStatus {
+	seen := make(map[string]bool, len(statuses))
+	latest := make([]*CommitStatus, 0, len(statuses))
+	for _, s := range statuses {
+		if seen[s.Context] {
+			continue
+		}
+		seen[s.Context] = true
+		latest = append(latest, s)
+	}
+	return latest
+}
+
+// GetLatestCommitStatuses returns the most recent status of each context for
+// the commit of given SHA in the repository.
+func GetLatestCommitStatuses(repoID int64, sha string) ([]*CommitStatus, error) {
+	statuses, err := GetCommitStatuses(repoID, sha)
+	if err != nil {
+		return nil, err
+	}
+	return latestCommitStatuses(statuses), nil
+}
+
+// GetLatestCommitStatusesBySHAs returns the most recent status of each context
+// for every given commit SHA in the repository, keyed by SHA.
+func GetLatestCommitStatusesBySHAs(repoID int64, shas []string) (map[string][]*CommitStatus, error) {
+	result := make(map[string][]*CommitStatus, len(shas))
+	if len(shas) == 0 {
+		return result, nil
+	}
+
+	statuses := make([]*CommitStatus, 0, len(shas))
+	if err := x.Where("repo_id = ?", repoID).In("sha", shas).Desc("id").Find(&statuses); err != nil {
+		return nil, err
+	}
+
+	bySHA := make(map[string][]*CommitStatus, len(shas))
+	for _, s := range statuses {
+		bySHA[s.SHA] = append(bySHA[s.SHA], s)
+	}
+	for sha, list := range bySHA {
+		result[sha] = latestCommitStatuses(list)
+	}
+	return result, nil
+}
+
+// CombinedCommitStatusState returns the combined state of given statuses, the
+// worst state wins. It returns pending when there is no status at all.
+func CombinedCommitStatusState(statuses []*CommitStatus) CommitStatusState {
+	if len(statuses) == 0 {
+		return CommitStatusPending
+	}
+
+	state := CommitStatusSuccess
+	for _, s := range statuses {
+		if s.State.priority() > state.priority() {
+			state = s.State
+		}
+	}
+	return state
+}
+
+// StatusChecksPassed returns true if all required contexts have a successful
+// status. When no context is required, the combined state of all statuses must
+// be success.
+func StatusChecksPassed(statuses []*CommitStatus, requiredContexts []string) bool {
+	if len(requiredContexts) == 0 {
+		return len(statuses) > 0 && CombinedCommitStatusState(statuses) == CommitStatusSuccess
+	}
+
+	states := make(map[string]CommitStatusState, len(statuses))
+	for _, s := range statuses {
+		states[s.Context] = s.State
+	}
+	for _, ctx := range requiredContexts {
+		if states[ctx] != CommitStatusSuccess {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCombinedCommitStatusState(t *testing.T) {
+	tests := []struct {
+		name   string
+		states []CommitStatusState
+		want   CommitStatusState
+	}{
+		{
+			name: "no status",
+			want: CommitStatusPending,
+		},
+		{
+			name:   "all success",
+			states: []CommitStatusState{CommitStatusSuccess, CommitStatusSuccess},
+			want:   CommitStatusSuccess,
+		},
+		{
+			name:   "pending wins over success",
+			states: []CommitStatusState{CommitStatusSuccess, CommitStatusPending},
+			want:   CommitStatusPending,
+		},
+		{
+			name:   "error wins over failure",
+			states: []CommitStatusState{CommitStatusFailure, CommitStatusError, CommitStatusSuccess},
+			want:   CommitStatusError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			statuses := make([]*CommitStatus, len(test.states))
+			for i := range test.states {
+				statuses[i] = &CommitStatus{State: test.states[i]}
+			}
+			assert.Equal(t, test.want, CombinedCommitStatusState(statuses))
+		})
+	}
+}
+
+func TestStatusChecksPassed(t *testing.T) {
+	statuses := []*CommitStatus{
+		{Context: "ci/build", State: CommitStatusSuccess},
+		{Context: "ci/lint", State: CommitStatusFailure},
+	}
+
+	assert.False(t, StatusChecksPassed(nil, nil))
+	assert.False(t, StatusChecksPassed(statuses, nil))
+	assert.True(t, StatusChecksPassed(statuses, []string{"ci/build"}))
+	assert.False(t, StatusChecksPassed(statuses, []string{"ci/build", "ci/lint"}))
+	assert.False(t, StatusChecksPassed(statuses, []string{"ci/test"}))
+}
+
+func TestGetLatestCommitStatuses(t *testing.T) {
+	setupLegacyTestDB(t)
+
+	const sha = "2c0ab8f1e0b25f0b6d2c1b8a5f2ab3e3cbd4e1f6"
+	for _, status := range []*CommitStatus{
+		{RepoID: 1, SHA: sha, Context: "ci/build", State: CommitStatusPending},
+		{RepoID: 1, SHA: sha, Context: "ci/build", State: CommitStatusFailure},
+		// Statuses reported to a fork must not be mixed in.
+		{RepoID: 2, SHA: sha, Context: "ci/build", State: CommitStatusSuccess},
+		{RepoID: 2, SHA: sha, Context: "ci/lint", State: CommitStatusSuccess},
+	} {
+		_, err := x.Insert(status)
+		require.NoError(t, err)
+	}
+
+	statuses, err := GetLatestCommitStatuses(1, strings.ToUpper(sha))
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "ci/build", statuses[0].Context)
+	assert.Equal(t, CommitStatusFailure, statuses[0].State)
+}
@@ -40,13 +40,20 @@ type Issue struct {
 	MilestoneID     int64       `gorm:"index"`
 	Milestone       *Milestone  `xorm:"-" json:"-" gorm:"-"`
 	Priority        int
-	AssigneeID      int64 `gorm:"index"`
+	AssigneeID      int64 `gorm:"index"` // The primary assignee.
 	Assignee        *User `xorm:"-" json:"-" gorm:"-"`
-	IsClosed        bool
-	IsRead          bool         `xorm:"-" json:"-" gorm:"-"`
-	IsPull          bool         // Indicates whether is a pull request or not.
-	PullRequest     *PullRequest `xorm:"-" json:"-" gorm:"-"`
-	NumComments     int
+	// Assignees contains all users assigned to the issue, including the primary assignee.
+	Assignees []*User `xorm:"-" json:"-" gorm:"-"`
+	// RequestedReviewers contains users requested to review the pull request.
+	RequestedReviewers []*User `xorm:"-" json:"-" gorm:"-"`
+	IsClosed           bool
+	IsRead             bool         `xorm:"-" json:"-" gorm:"-"`
+	IsPull             bool         // Indicates whether is a pull request or not.
+	PullRequest        *PullRequest `xorm:"-" json:"-" gorm:"-"`
+	NumComments        int
+	// IsLocked indicates whether only writers are allowed to comment.
+	IsLocked   bool
+	LockReason string
 
 	Deadline     time.Time `xorm:"-" json:"-" gorm:"-"`
 	DeadlineUnix int64
@@ -57,22 +64,38 @@ type Issue struct {
 
 	Attachments []*Attachment `xorm:"-" json:"-" gorm:"-"`
 	Comments    []*Comment    `xorm:"-" json:"-" gorm:"-"`
+	Reactions   ReactionList  `xorm:"-" json:"-" gorm:"-"`
 }
 
 func (issue *Issue) BeforeInsert() {
 	issue.CreatedUnix = time.Now().Unix()
 	issue.UpdatedUnix = issue.CreatedUnix
+	issue.setDeadlineUnix()
 }
 
 func (issue *Issue) BeforeUpdate() {
 	issue.UpdatedUnix = time.Now().Unix()
-	issue.DeadlineUnix = issue.Deadline.Unix()
+	issue.setDeadlineUnix()
+}
+
+// setDeadlineUnix sets DeadlineUnix from Deadline, zero value of both means
+// the issue has no due date.
+func (issue *Issue) setDeadlineUnix() {
+	if issue.Deadline.IsZero() {
+		issue.DeadlineUnix = 0
+	} else {
+		issue.DeadlineUnix = issue.Deadline.Unix()
+	}
 }
 
 func (issue *Issue) AfterSet(colName string, _ xorm.Cell) {
 	switch colName {
 	case "deadline_unix":
-		issue.Deadline = time.Unix(issue.DeadlineUnix, 0).Local()
+		if issue.DeadlineUnix > 0 {
+			issue.Deadline = time.Unix(issue.DeadlineUnix, 0).Local()
+		} else {
+			issue.Deadline = time.Time{}
+		}
 	case "created_unix":
 		issue.Created = time.Unix(issue.CreatedUnix, 0).Local()
 	case "updated_unix":
@@ -136,6 +159,14 @@ func (issue *Issue) loadAttributes(e Engine) (err error) {
 		}
 	}
 
+	if err = issue.loadAssignees(e); err != nil {
+		return fmt.Errorf("loadAssignees: %v", err)
+	}
+
+	if err = issue.loadRequestedReviewers(e); err != nil {
+		return fmt.Errorf("loadRequestedReviewers: %v", err)
+	}
+
 	if issue.IsPull && issue.PullRequest == nil {
 		// It is possible pull request is not yet created.
 		issue.PullRequest, err = getPullRequestByIssueID(e, issue.ID)
@@ -158,6 +189,13 @@ func (issue *Issue) loadAttributes(e Engine) (err error) {
 		}
 	}
 
+	if issue.Reactions == nil {
+		issue.Reactions, err = getReactionsByIssueID(e, issue.ID)
+		if err != nil {
+			return fmt.Errorf("getReactionsByIssueID [%d]: %v", issue.ID, err)
+		}
+	}
+
 	return nil
 }
 
@@ -271,30 +309,64 @@ func (issue *Issue) sendLabelUpdatedWebhook(doer *User) {
 	}
 }
 
-func (issue *Issue) addLabel(e *xorm.Session, label *Label) error {
-	return newIssueLabel(e, issue, label)
+func (issue *Issue) addLabel(e *xorm.Session, doer *User, label *Label) error {
+	if err := newIssueLabel(e, issue, label); err != nil {
+		return err
+	}
+	return createLabelEvent(e, doer, issue, label, true)
 }
 
 // AddLabel adds a new label to the issue.
-func (issue *Issue) AddLabel(doer *User, label *Label) error {
-	if err := NewIssueLabel(issue, label); err != nil {
+func (issue *Issue) AddLabel(doer *User, label *Label) (err error) {
+	sess := x.NewSession()
+	defer sess.Close()
+	if err = sess.Begin(); err != nil {
 		return err
 	}
 
+	if !hasIssueLabel(sess, issue.ID, label.ID) {
+		if err = issue.addLabel(sess, doer, label); err != nil {
+			return err
+		}
+	}
+
+	if err = sess.Commit(); err != nil {
+		return fmt.Errorf("commit: %v", err)
+	}
+
 	issue.sendLabelUpdatedWebhook(doer)
 	return nil
 }
 
-func (issue *Issue) addLabels(e *xorm.Session, labels []*Label) error {
-	return newIssueLabels(e, issue, labels)
+func (issue *Issue) addLabels(e *xorm.Session, doer *User, labels []*Label) error {
+	for i := range labels {
+		if hasIssueLabel(e, issue.ID, labels[i].ID) {
+			continue
+		}
+
+		if err := issue.addLabel(e, doer, labels[i]); err != nil {
+			return fmt.Errorf("addLabel: %v", err)
+		}
+	}
+	return nil
 }
 
 // AddLabels adds a list of new labels to the issue.
-func (issue *Issue) AddLabels(doer *User, labels []*Label) error {
-	if err := NewIssueLabels(issue, labels); err != nil {
+func (issue *Issue) AddLabels(doer *User, labels []*Label) (err error) {
+	sess := x.NewSession()
+	defer sess.Close()
+	if err = sess.Begin(); err != nil {
 		return err
 	}
 
+	if err = issue.addLabels(sess, doer, labels); err != nil {
+		return err
+	}
+
+	if err = sess.Commit(); err != nil {
+		return fmt.Errorf("commit: %v", err)
+	}
+
 	issue.sendLabelUpdatedWebhook(doer)
 	return nil
 }
@@ -311,21 +383,36 @@ func (issue *Issue) getLabels(e Engine) (err error) {
 	return nil
 }
 
-func (issue *Issue) removeLabel(e *xorm.Session, label *Label) error {
-	return deleteIssueLabel(e, issue, label)
+func (issue *Issue) removeLabel(e *xorm.Session, doer *User, label *Label) error {
+	if err := deleteIssueLabel(e, issue, label); err != nil {
+		return err
+	}
+	return createLabelEvent(e, doer, issue, label, false)
 }
 
 // RemoveLabel removes a label from issue by given ID.
-func (issue *Issue) RemoveLabel(doer *User, label *Label) error {
-	if err := DeleteIssueLabel(issue, label); err != nil {
+func (issue *Issue) RemoveLabel(doer *User, label *Label) (err error) {
+	sess := x.NewSession()
+	defer sess.Close()
+	if err = sess.Begin(); err != nil {
 		return err
 	}
 
+	if hasIssueLabel(sess, issue.ID, label.ID) {
+		if err = issue.removeLabel(sess, doer, label); err != nil {
+			return err
+		}
+	}
+
+	if err = sess.Commit(); err != nil {
+		return fmt.Errorf("commit: %v", err)
+	}
+
 	issue.sendLabelUpdatedWebhook(doer)
 	return nil
 }
 
-func (issue *Issue) clearLabels(e *xorm.Session) (err error) {
+func (issue *Issue) clearLabels(e *xorm.Session, doer *User) (err error) {
 	if err = issue.getLabels(e); err != nil {
 		return fmt.Errorf("getLabels: %v", err)
 	}
@@ -334,7 +421,7 @@ func (issue *Issue) clearLabels(e *xorm.Session) (err error) {
 	labels := make([]*Label, len(issue.Labels))
 	copy(labels, issue.Labels)
 	for i := range labels {
-		if err = issue.removeLabel(e, labels[i]); err != nil {
+		if err = issue.removeLabel(e, doer, labels[i]); err != nil {
 			return fmt.Errorf("removeLabel: %v", err)
 		}
 	}
@@ -349,7 +436,7 @@ func (issue *Issue) ClearLabels(doer *User) (err error) {
 		return err
 	}
 
-	if err = issue.clearLabels(sess); err != nil {
+	if err = issue.clearLabels(sess, doer); err != nil {
 		return err
 	}
 
@@ -386,17 +473,34 @@ func (issue *Issue) ClearLabels(doer *User) (err error) {
 	return nil
 }
 
-// ReplaceLabels removes all current labels and add new labels to the issue.
-func (issue *Issue) ReplaceLabels(labels []*Label) (err error) {
+// ReplaceLabels removes labels that are not in the given list and adds new
+// labels to the issue.
+func (issue *Issue) ReplaceLabels(doer *User, labels []*Label) (err error) {
 	sess := x.NewSession()
 	defer sess.Close()
 	if err = sess.Begin(); err != nil {
 		return err
 	}
 
-	if err = issue.clearLabels(sess); err != nil {
-		return fmt.Errorf("clearLabels: %v", err)
-	} else if err = issue.addLabels(sess, labels); err != nil {
+	if err = issue.getLabels(sess); err != nil {
+		return fmt.Errorf("getLabels: %v", err)
+	}
+	keep := make(map[int64]bool, len(labels))
+	for i := range labels {
+		keep[labels[i].ID] = true
+	}
+	oldLabels := make([]*Label, len(issue.Labels))
+	copy(oldLabels, issue.Labels)
+	for i := range oldLabels {
+		if keep[oldLabels[i].ID] {
+			continue
+		}
+		if err = issue.removeLabel(sess, doer, oldLabels[i]); err != nil {
+			return fmt.Errorf("removeLabel: %v", err)
+		}
+	}
+
+	if err = issue.addLabels(sess, doer, labels); err != nil {
 		return fmt.Errorf("addLabels: %v", err)
 	}
 
@@ -473,7 +577,18 @@ func (issue *Issue) changeStatus(e *xorm.Session, doer *User, repo *Repository,
 }
 
 // ChangeStatus changes issue status to open or closed.
+// It returns ErrIssueBlocked when closing an issue that is blocked by open
+// dependencies and the repository does not allow it.
 func (issue *Issue) ChangeStatus(doer *User, repo *Repository, isClosed bool) (err error) {
+	if isClosed && !issue.IsClosed && repo.BlockCloseByDependencies {
+		blocked, err := issue.HasOpenBlockers()
+		if err != nil {
+			return fmt.Errorf("check open blockers: %v", err)
+		} else if blocked {
+			return ErrIssueBlocked{args: map[string]any{"issueID": issue.ID}}
+		}
+	}
+
 	sess := x.NewSession()
 	defer sess.Close()
 	if err = sess.Begin(); err != nil {
@@ -526,10 +641,27 @@ func (issue *Issue) ChangeStatus(doer *User, repo *Repository, isClosed bool) (e
 
 func (issue *Issue) ChangeTitle(doer *User, title string) (err error) {
 	oldTitle := issue.Title
+	if oldTitle == title {
+		return nil
+	}
+
+	sess := x.NewSession()
+	defer sess.Close()
+	if err = sess.Begin(); err != nil {
+		return err
+	}
+
 	issue.Title = title
-	if err = UpdateIssueCols(issue, "name"); err != nil {
-		return fmt.Errorf("UpdateIssueCols: %v", err)
+	if err = updateIssueCols(sess, issue, "name"); err != nil {
+		return fmt.Errorf("updateIssueCols: %v", err)
+	} else if err = createTitleEvent(sess, doer, issue, oldTitle, title); err != nil {
+		return fmt.Errorf("createTitleEvent: %v", err)
+	}
+
+	if err = sess.Commit(); err != nil {
+		return fmt.Errorf("commit: %v", err)
 	}
+	UpdateIssueIndexer(issue.ID)
 
 	if issue.IsPull {
 		issue.PullRequest.Issue = issue
@@ -572,6 +704,7 @@ func (issue *Issue) ChangeContent(doer *User, content string) (err error) {
 	if err = UpdateIssueCols(issue, "content"); err != nil {
 		return fmt.Errorf("UpdateIssueCols: %v", err)
 	}
+	UpdateIssueIndexer(issue.ID)
 
 	if issue.IsPull {
 		issue.PullRequest.Issue = issue
@@ -608,66 +741,22 @@ func (issue *Issue) ChangeContent(doer *User, content string) (err error) {
 	return nil
 }
 
-func (issue *Issue) ChangeAssignee(doer *User, assigneeID int64) (err error) {
-	issue.AssigneeID = assigneeID
-	if err = UpdateIssueUserByAssignee(issue); err != nil {
-		return fmt.Errorf("UpdateIssueUserByAssignee: %v", err)
-	}
-
-	issue.Assignee, err = Handle.Users().GetByID(context.TODO(), issue.AssigneeID)
-	if err != nil && !IsErrUserNotExist(err) {
-		log.Error("Failed to get user by ID: %v", err)
-		return nil
-	}
-
-	// Error not nil here means user does not exist, which is remove assignee.
-	isRemoveAssignee := err != nil
-	if issue.IsPull {
-		issue.PullRequest.Issue = issue
-		apiPullRequest := &api.PullRequestPayload{
-			Index:       issue.Index,
-			PullRequest: issue.PullRequest.APIFormat(),
-			Repository:  issue.Repo.APIFormatLegacy(nil),
-			Sender:      doer.APIFormat(),
-		}
-		if isRemoveAssignee {
-			apiPullRequest.Action = api.HOOK_ISSUE_UNASSIGNED
-		} else {
-			apiPullRequest.Action = api.HOOK_ISSUE_ASSIGNED
-		}
-		err = PrepareWebhooks(issue.Repo, HookEventTypePullRequest, apiPullRequest)
-	} else {
-		apiIssues := &api.IssuesPayload{
-			Index:      issue.Index,
-			Issue:      issue.APIFormat(),
-			Repository: issue.Repo.APIFormatLegacy(nil),
-			Sender:     doer.APIFormat(),
-		}
-		if isRemoveAssignee {
-			apiIssues.Action = api.HOOK_ISSUE_UNASSIGNED
-		} else {
-			apiIssues.Action = api.HOOK_ISSUE_ASSIGNED
-		}
-		err = PrepareWebhooks(issue.Repo, HookEventTypeIssues, apiIssues)
-	}
-	if err != nil {
-		log.Error("PrepareWebhooks [is_pull: %v, remove_assignee: %v]: %v", issue.IsPull, isRemoveAssignee, err)
-	}
-
-	return nil
-}
-
 type NewIssueOptions struct {
 	Repo        *Repository
 	Issue       *Issue
 	LableIDs    []int64
+	AssigneeIDs []int64
+	ReviewerIDs []int64  // Only applies to pull requests.
 	Attachments []string // In UUID format.
 	IsPull      bool
 }
 
 func newIssue(e *xorm.Session, opts NewIssueOptions) (err error) {
 	opts.Issue.Title = strings.TrimSpace(opts.Issue.Title)
-	opts.Issue.Index = opts.Repo.NextIssueIndex()
+	opts.Issue.Index, err = nextIssueIndex(e, opts.Repo)
+	if err != nil {
+		return err
+	}
 
 	if opts.Issue.MilestoneID > 0 {
 		milestone, err := getMilestoneByRepoID(e, opts.Issue.RepoID, opts.Issue.MilestoneID)
@@ -725,11 +814,11 @@ func newIssue(e *xorm.Session, opts NewIssueOptions) (err error) {
 
 		for _, label := range labels {
 			// Silently drop invalid labels.
-			if label.RepoID != opts.Repo.ID {
+			if !label.IsAvailableTo(opts.Repo) {
 				continue
 			}
 
-			if err = opts.Issue.addLabel(e, label); err != nil {
+			if err = newIssueLabel(e, opts.Issue, label); err != nil {
 				return fmt.Errorf("addLabel [id: %d]: %v", label.ID, err)
 			}
 		}
@@ -739,6 +828,18 @@ func newIssue(e *xorm.Session, opts NewIssueOptions) (err error) {
 		return err
 	}
 
+	opts.Issue.Repo = opts.Repo
+	if len(opts.AssigneeIDs) > 0 {
+		if _, _, err = setIssueAssignees(e, opts.Issue, opts.AssigneeIDs); err != nil {
+			return fmt.Errorf("set assignees: %v", err)
+		}
+	}
+	if opts.IsPull && len(opts.ReviewerIDs) > 0 {
+		if _, _, err = setIssueRequestedReviewers(e, opts.Issue, opts.ReviewerIDs); err != nil {
+			return fmt.Errorf("set requested reviewers: %v", err)
+		}
+	}
+
 	if len(opts.Attachments) > 0 {
 		attachments, err := getAttachmentsByUUIDs(e, opts.Attachments)
 		if err != nil {
@@ -757,25 +858,33 @@ func newIssue(e *xorm.Session, opts NewIssueOptions) (err error) {
 }
 
 // NewIssue creates new issue with labels and attachments for repository.
-func NewIssue(repo *Repository, issue *Issue, labelIDs []int64, uuids []string) (err error) {
+func NewIssue(repo *Repository, issue *Issue, labelIDs []int64, uuids []string) error {
+	return NewIssueWithOptions(NewIssueOptions{
+		Repo:        repo,
+		Issue:       issue,
+		LableIDs:    labelIDs,
+		Attachments: uuids,
+	})
+}
+
+// NewIssueWithOptions creates new issue with given options.
+func NewIssueWithOptions(opts NewIssueOptions) (err error) {
+	repo, issue := opts.Repo, opts.Issue
 	sess := x.NewSession()
 	defer sess.Close()
 	if err = sess.Begin(); err != nil {
 		return err
 	}
 
-	if err = newIssue(sess, NewIssueOptions{
-		Repo:        repo,
-		Issue:       issue,
-		LableIDs:    labelIDs,
-		Attachments: uuids,
-	}); err != nil {
+	opts.IsPull = false
+	if err = newIssue(sess, opts); err != nil {
 		return fmt.Errorf("new issue: %v", err)
 	}
 
 	if err = sess.Commit(); err != nil {
 		return fmt.Errorf("commit: %v", err)
 	}
+	UpdateIssueIndexer(issue.ID)
 
 	if err = NotifyWatchers(&Action{
 		ActUserID:    issue.Poster.ID,
@@ -901,6 +1010,7 @@ func GetIssueByID(id int64) (*Issue, error) {
 type IssuesOptions struct {
 	UserID      int64
 	AssigneeID  int64
+	ReviewerID  int64
 	RepoID      int64
 	PosterID    int64
 	MilestoneID int64
@@ -909,8 +1019,48 @@ type IssuesOptions struct {
 	IsClosed    bool
 	IsMention   bool
 	IsPull      bool
+	Draft       DraftFilter
+	Due         DueFilter
 	Labels      string
 	SortType    string
+	Filter      *IssueFilter
+}
+
+// DraftFilter filters pull requests by their draft state.
+type DraftFilter string
+
+const (
+	DraftFilterNone  DraftFilter = ""
+	DraftFilterDraft DraftFilter = "draft"
+	DraftFilterReady DraftFilter = "ready"
+)
+
+// joinDraftFilter narrows down pull requests of the session by given filter.
+func joinDraftFilter(sess *xorm.Session, filter DraftFilter) {
+	if filter != DraftFilterDraft && filter != DraftFilterReady {
+		return
+	}
+	sess.Join("INNER", "pull_request", "issue.id = pull_request.issue_id").
+		And("pull_request.is_draft = ?", filter == DraftFilterDraft)
+}
+
+// issueUserFlagCond returns the condition for issues that have given boolean
+// column of issue-user relation set for a user, the user ID and the column value
+// are the arguments.
+func issueUserFlagCond(col string) string {
+	return "issue.id IN (SELECT issue_id FROM `issue_user` WHERE uid = ? AND " + col + " = ?)"
+}
+
+// issueRankOrder returns the ORDER BY clause that sorts issues in the order of
+// given IDs.
+func issueRankOrder(issueIDs []int64) string {
+	var buf strings.Builder
+	buf.WriteString("CASE issue.id")
+	for i, id := range issueIDs {
+		fmt.Fprintf(&buf, " WHEN %d THEN %d", id, i)
+	}
+	buf.WriteString(" END")
+	return buf.String()
 }
 
 // buildIssuesQuery returns nil if it foresees there won't be any value returned.
@@ -934,18 +1084,31 @@ func buildIssuesQuery(opts *IssuesOptions) *xorm.Session {
 	}
 
 	if opts.AssigneeID > 0 {
-		sess.And("issue.assignee_id=?", opts.AssigneeID)
+		sess.And(issueUserFlagCond("is_assigned"), opts.AssigneeID, true)
 	} else if opts.PosterID > 0 {
 		sess.And("issue.poster_id=?", opts.PosterID)
 	}
 
+	if opts.ReviewerID > 0 {
+		sess.And(issueUserFlagCond("is_review_requested"), opts.ReviewerID, true)
+	}
+
 	if opts.MilestoneID > 0 {
 		sess.And("issue.milestone_id=?", opts.MilestoneID)
 	}
 
 	sess.And("issue.is_pull=?", opts.IsPull)
+	if opts.IsPull {
+		joinDraftFilter(sess, opts.Draft)
+	}
+	applyDueFilter(sess, opts.Due)
+	rankedIDs := applyIssueFilter(sess, opts.Filter, issueFilterRepoIDs(opts.RepoID, opts.RepoIDs))
 
-	switch opts.SortType {
+	sortType := opts.SortType
+	if sortType == "" && len(rankedIDs) > 0 {
+		sortType = "relevance"
+	}
+	switch sortType {
 	case "oldest":
 		sess.Asc("issue.created_unix")
 	case "recentupdate":
@@ -958,6 +1121,17 @@ func buildIssuesQuery(opts *IssuesOptions) *xorm.Session {
 		sess.Asc("issue.num_comments")
 	case "priority":
 		sess.Desc("issue.priority")
+	case "nearduedate":
+		// Issues without due date go last.
+		sess.OrderBy("CASE WHEN issue.deadline_unix > 0 THEN 0 ELSE 1 END, issue.deadline_unix ASC")
+	case "farduedate":
+		sess.Desc("issue.deadline_unix")
+	case "relevance":
+		if len(rankedIDs) > 0 {
+			sess.OrderBy(issueRankOrder(rankedIDs))
+		} else {
+			sess.Desc("issue.created_unix")
+		}
 	default:
 		sess.Desc("issue.created_unix")
 	}
@@ -1004,6 +1178,10 @@ func Issues(opts *IssuesOptions) ([]*Issue, error) {
 		return nil, fmt.Errorf("find: %v", err)
 	}
 
+	if err := loadIssuesUsers(x, issues); err != nil {
+		return nil, fmt.Errorf("load assignees and reviewers: %v", err)
+	}
+
 	// FIXME: use IssueList to improve performance.
 	for i := range issues {
 		if err := issues[i].LoadAttributes(); err != nil {
@@ -1050,6 +1228,9 @@ type IssueUser struct {
 	IsMentioned bool
 	IsPoster    bool
 	IsClosed    bool
+
+	IsReviewRequested bool
+	IsApproved        bool
 }
 
 func newIssueUsers(e *xorm.Session, repo *Repository, issue *Issue) error {
@@ -1150,6 +1331,8 @@ func GetIssueUserPairsByMode(userID, repoID int64, filterMode FilterMode, isClos
 		sess.And("is_assigned=?", true)
 	case FilterModeCreate:
 		sess.And("is_poster=?", true)
+	case FilterModeReviewRequested:
+		sess.And("is_review_requested=?", true)
 	default:
 		return ius, nil
 	}
@@ -1207,6 +1390,7 @@ type IssueStats struct {
 	AssignCount            int64
 	CreateCount            int64
 	MentionCount           int64
+	ReviewRequestedCount   int64
 }
 
 type FilterMode string
@@ -1216,6 +1400,8 @@ const (
 	FilterModeAssign    FilterMode = "assigned"
 	FilterModeCreate    FilterMode = "created_by"
 	FilterModeMention   FilterMode = "mentioned"
+
+	FilterModeReviewRequested FilterMode = "review_requested"
 )
 
 func parseCountResult(results []map[string][]byte) int64 {
@@ -1234,8 +1420,12 @@ type IssueStatsOptions struct {
 	Labels      string
 	MilestoneID int64
 	AssigneeID  int64
+	ReviewerID  int64
 	FilterMode  FilterMode
 	IsPull      bool
+	Draft       DraftFilter
+	Due         DueFilter
+	Filter      *IssueFilter
 }
 
 // GetIssueStats returns issue statistic information by given conditions.
@@ -1244,11 +1434,16 @@ func GetIssueStats(opts *IssueStatsOptions) *IssueStats {
 
 	countSession := func(opts *IssueStatsOptions) *xorm.Session {
 		sess := x.Where("issue.repo_id = ?", opts.RepoID).And("is_pull = ?", opts.IsPull)
+		if opts.IsPull {
+			joinDraftFilter(sess, opts.Draft)
+		}
+		applyDueFilter(sess, opts.Due)
+		applyIssueFilter(sess, opts.Filter, []int64{opts.RepoID})
 
 		if len(opts.Labels) > 0 && opts.Labels != "0" {
 			labelIDs := tool.StringsToInt64s(strings.Split(opts.Labels, ","))
 			if len(labelIDs) > 0 {
-				sess.Join("INNER", "issue_label", "issue.id = issue_id").In("label_id", labelIDs)
+				sess.Join("INNER", "issue_label", "issue.id = issue_label.issue_id").In("label_id", labelIDs)
 			}
 		}
 
@@ -1257,14 +1452,18 @@ func GetIssueStats(opts *IssueStatsOptions) *IssueStats {
 		}
 
 		if opts.AssigneeID > 0 {
-			sess.And("assignee_id = ?", opts.AssigneeID)
+			sess.And(issueUserFlagCond("is_assigned"), opts.AssigneeID, true)
+		}
+
+		if opts.ReviewerID > 0 {
+			sess.And(issueUserFlagCond("is_review_requested"), opts.ReviewerID, true)
 		}
 
 		return sess
 	}
 
 	switch opts.FilterMode {
-	case FilterModeYourRepos, FilterModeAssign:
+	case FilterModeYourRepos, FilterModeAssign, FilterModeReviewRequested:
 		stats.OpenCount, _ = countSession(opts).
 			And("is_closed = ?", false).
 			Count(new(Issue))
@@ -1301,11 +1500,13 @@ func GetIssueStats(opts *IssueStatsOptions) *IssueStats {
 }
 
 // GetUserIssueStats returns issue statistic information for dashboard by given conditions.
-func GetUserIssueStats(repoID, userID int64, repoIDs []int64, filterMode FilterMode, isPull bool) *IssueStats {
+func GetUserIssueStats(repoID, userID int64, repoIDs []int64, filterMode FilterMode, isPull bool, due DueFilter, filter *IssueFilter) *IssueStats {
 	stats := &IssueStats{}
 	hasAnyRepo := repoID > 0 || len(repoIDs) > 0
 	countSession := func(isClosed, isPull bool, repoID int64, repoIDs []int64) *xorm.Session {
 		sess := x.Where("issue.is_closed = ?", isClosed).And("issue.is_pull = ?", isPull)
+		applyDueFilter(sess, due)
+		applyIssueFilter(sess, filter, issueFilterRepoIDs(repoID, repoIDs))
 
 		if repoID > 0 {
 			sess.And("repo_id = ?", repoID)
@@ -1317,9 +1518,15 @@ func GetUserIssueStats(repoID, userID int64, repoIDs []int64, filterMode FilterM
 	}
 
 	stats.AssignCount, _ = countSession(false, isPull, repoID, nil).
-		And("assignee_id = ?", userID).
+		And(issueUserFlagCond("is_assigned"), userID, true).
 		Count(new(Issue))
 
+	if isPull {
+		stats.ReviewRequestedCount, _ = countSession(false, isPull, repoID, nil).
+			And(issueUserFlagCond("is_review_requested"), userID, true).
+			Count(new(Issue))
+	}
+
 	stats.CreateCount, _ = countSession(false, isPull, repoID, nil).
 		And("poster_id = ?", userID).
 		Count(new(Issue))
@@ -1341,10 +1548,17 @@ func GetUserIssueStats(repoID, userID int64, repoIDs []int64, filterMode FilterM
 			Count(new(Issue))
 	case FilterModeAssign:
 		stats.OpenCount, _ = countSession(false, isPull, repoID, nil).
-			And("assignee_id = ?", userID).
+			And(issueUserFlagCond("is_assigned"), userID, true).
+			Count(new(Issue))
+		stats.ClosedCount, _ = countSession(true, isPull, repoID, nil).
+			And(issueUserFlagCond("is_assigned"), userID, true).
+			Count(new(Issue))
+	case FilterModeReviewRequested:
+		stats.OpenCount, _ = countSession(false, isPull, repoID, nil).
+			And(issueUserFlagCond("is_review_requested"), userID, true).
 			Count(new(Issue))
 		stats.ClosedCount, _ = countSession(true, isPull, repoID, nil).
-			And("assignee_id = ?", userID).
+			And(issueUserFlagCond("is_review_requested"), userID, true).
 			Count(new(Issue))
 	case FilterModeCreate:
 		stats.OpenCount, _ = countSession(false, isPull, repoID, nil).
@@ -1373,8 +1587,11 @@ func GetRepoIssueStats(repoID, userID int64, filterMode FilterMode, isPull bool)
 
 	switch filterMode {
 	case FilterModeAssign:
-		openCountSession.And("assignee_id = ?", userID)
-		closedCountSession.And("assignee_id = ?", userID)
+		openCountSession.And(issueUserFlagCond("is_assigned"), userID, true)
+		closedCountSession.And(issueUserFlagCond("is_assigned"), userID, true)
+	case FilterModeReviewRequested:
+		openCountSession.And(issueUserFlagCond("is_review_requested"), userID, true)
+		closedCountSession.And(issueUserFlagCond("is_review_requested"), userID, true)
 	case FilterModeCreate:
 		openCountSession.And("poster_id = ?", userID)
 		closedCountSession.And("poster_id = ?", userID)
@@ -1393,7 +1610,11 @@ func updateIssue(e Engine, issue *Issue) error {
 
 // UpdateIssue updates all fields of given issue.
 func UpdateIssue(issue *Issue) error {
-	return updateIssue(x, issue)
+	if err := updateIssue(x, issue); err != nil {
+		return err
+	}
+	UpdateIssueIndexer(issue.ID)
+	return nil
 }
 
 func updateIssueUsersByStatus(e Engine, issueID int64, isClosed bool) error {
@@ -1406,36 +1627,6 @@ func UpdateIssueUsersByStatus(issueID int64, isClosed bool) error {
 	return updateIssueUsersByStatus(x, issueID, isClosed)
 }
 
-func updateIssueUserByAssignee(e *xorm.Session, issue *Issue) (err error) {
-	if _, err = e.Exec("UPDATE `issue_user` SET is_assigned = ? WHERE issue_id = ?", false, issue.ID); err != nil {
-		return err
-	}
-
-	// Assignee ID equals to 0 means clear assignee.
-	if issue.AssigneeID > 0 {
-		if _, err = e.Exec("UPDATE `issue_user` SET is_assigned = ? WHERE uid = ? AND issue_id = ?", true, issue.AssigneeID, issue.ID); err != nil {
-			return err
-		}
-	}
-
-	return updateIssue(e, issue)
-}
-
-// UpdateIssueUserByAssignee updates issue-user relation for assignee.
-func UpdateIssueUserByAssignee(issue *Issue) (err error) {
-	sess := x.NewSession()
-	defer sess.Close()
-	if err = sess.Begin(); err != nil {
-		return err
-	}
-
-	if err = updateIssueUserByAssignee(sess, issue); err != nil {
-		return err
-	}
-
-	return sess.Commit()
-}
-
 // UpdateIssueUserByRead updates issue-user relation for reading.
 func UpdateIssueUserByRead(uid, issueID int64) error {
 	_, err := x.Exec("UPDATE `issue_user` SET is_read=? WHERE uid=? AND issue_id=?", true, uid, issueID)
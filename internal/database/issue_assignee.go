@@ -0,0 +1,465 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"fmt"
+	"sort"
+
+	api "github.com/gogs/go-gogs-client"
+	log "unknwon.dev/clog/v2"
+
+	"gogs.io/gogs/internal/conf"
+	"gogs.io/gogs/internal/email"
+)
+
+// HookIssueReviewRequested is the webhook action of reviewers being requested
+// for a pull request.
+const HookIssueReviewRequested api.HookIssueAction = "review_requested"
+
+// getUsersByIssueUserFlag returns users that have given boolean column of
+// issue-user relation set for the issue.
+func getUsersByIssueUserFlag(e Engine, issueID int64, col string) ([]*User, error) {
+	users := make([]*User, 0, 2)
+	return users, e.Where("id IN (SELECT uid FROM `issue_user` WHERE issue_id = ? AND "+col+" = ?)", issueID, true).
+		Asc("lower_name").
+		Find(&users)
+}
+
+func (issue *Issue) loadAssignees(e Engine) (err error) {
+	if issue.Assignees != nil {
+		return nil
+	}
+	issue.Assignees, err = getUsersByIssueUserFlag(e, issue.ID, "is_assigned")
+	return err
+}
+
+func (issue *Issue) loadRequestedReviewers(e Engine) (err error) {
+	if !issue.IsPull || issue.RequestedReviewers != nil {
+		return nil
+	}
+	issue.RequestedReviewers, err = getUsersByIssueUserFlag(e, issue.ID, "is_review_requested")
+	return err
+}
+
+// IsAssignee returns true if given user is one of assignees of the issue.
+// This method assumes assignees have been loaded.
+func (issue *Issue) IsAssignee(userID int64) bool {
+	for i := range issue.Assignees {
+		if issue.Assignees[i].ID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRequestedReviewer returns true if given user has been requested to review
+// the pull request. This method assumes requested reviewers have been loaded.
+func (issue *Issue) IsRequestedReviewer(userID int64) bool {
+	for i := range issue.RequestedReviewers {
+		if issue.RequestedReviewers[i].ID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// setIssueUserFlag sets given boolean column of issue-user relation for the
+// user, and creates the relation if it does not exist yet.
+func setIssueUserFlag(e Engine, issue *Issue, userID int64, col string, value bool) error {
+	iu := &IssueUser{
+		UserID:  userID,
+		IssueID: issue.ID,
+	}
+	has, err := e.Get(iu)
+	if err != nil {
+		return err
+	} else if has {
+		_, err = e.Exec("UPDATE `issue_user` SET "+col+" = ? WHERE id = ?", value, iu.ID)
+		return err
+	} else if !value {
+		return nil
+	}
+
+	iu.RepoID = issue.RepoID
+	iu.MilestoneID = issue.MilestoneID
+	iu.IsClosed = issue.IsClosed
+	switch col {
+	case "is_assigned":
+		iu.IsAssigned = true
+	case "is_review_requested":
+		iu.IsReviewRequested = true
+	case "is_approved":
+		iu.IsApproved = true
+	}
+	_, err = e.Insert(iu)
+	return err
+}
+
+// ErrUserNotAssignable is returned when some of given users cannot be assigned
+// to or requested to review issues of the repository.
+type ErrUserNotAssignable struct {
+	UserIDs []int64
+}
+
+func IsErrUserNotAssignable(err error) bool {
+	_, ok := err.(ErrUserNotAssignable)
+	return ok
+}
+
+func (err ErrUserNotAssignable) Error() string {
+	return fmt.Sprintf("users are not assignable: [user_ids: %v]", err.UserIDs)
+}
+
+// validIssueUserIDs removes duplicates of given user IDs while keeping the
+// order. It returns ErrUserNotAssignable with IDs of users that are not
+// assignable in the repository.
+func validIssueUserIDs(e Engine, repo *Repository, userIDs []int64) ([]int64, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	assignees, err := repo.getAssignees(e)
+	if err != nil {
+		return nil, fmt.Errorf("get assignees: %v", err)
+	}
+	assignable := make(map[int64]bool, len(assignees))
+	for i := range assignees {
+		assignable[assignees[i].ID] = true
+	}
+
+	validIDs := make([]int64, 0, len(userIDs))
+	seen := make(map[int64]bool, len(userIDs))
+	var rejectedIDs []int64
+	for _, id := range userIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		if !assignable[id] {
+			rejectedIDs = append(rejectedIDs, id)
+			continue
+		}
+		validIDs = append(validIDs, id)
+	}
+	if len(rejectedIDs) > 0 {
+		return nil, ErrUserNotAssignable{UserIDs: rejectedIDs}
+	}
+	return validIDs, nil
+}
+
+// ValidateAssignableUsers returns ErrUserNotAssignable if any of given users
+// cannot be assigned to or requested to review issues of the repository.
+func ValidateAssignableUsers(repo *Repository, userIDs []int64) error {
+	_, err := validIssueUserIDs(x, repo, userIDs)
+	return err
+}
+
+// loadIssuesUsers loads assignees and requested reviewers of all given issues
+// at once.
+func loadIssuesUsers(e Engine, issues []*Issue) error {
+	if len(issues) == 0 {
+		return nil
+	}
+
+	issueIDs := make([]int64, len(issues))
+	for i := range issues {
+		issueIDs[i] = issues[i].ID
+	}
+	ius := make([]*IssueUser, 0, len(issues))
+	if err := e.In("issue_id", issueIDs).
+		And("is_assigned = ? OR is_review_requested = ?", true, true).
+		Find(&ius); err != nil {
+		return fmt.Errorf("find issue users: %v", err)
+	}
+
+	userIDs := make([]int64, 0, len(ius))
+	for i := range ius {
+		userIDs = append(userIDs, ius[i].UserID)
+	}
+	users := make(map[int64]*User, len(userIDs))
+	if len(userIDs) > 0 {
+		if err := e.In("id", userIDs).Find(&users); err != nil {
+			return fmt.Errorf("find users: %v", err)
+		}
+	}
+
+	assignees := make(map[int64][]*User, len(issues))
+	reviewers := make(map[int64][]*User, len(issues))
+	for _, iu := range ius {
+		u := users[iu.UserID]
+		if u == nil {
+			continue
+		}
+		if iu.IsAssigned {
+			assignees[iu.IssueID] = append(assignees[iu.IssueID], u)
+		}
+		if iu.IsReviewRequested {
+			reviewers[iu.IssueID] = append(reviewers[iu.IssueID], u)
+		}
+	}
+
+	byLowerName := func(users []*User) []*User {
+		sort.Slice(users, func(i, j int) bool {
+			return users[i].LowerName < users[j].LowerName
+		})
+		return users
+	}
+	for _, issue := range issues {
+		issue.Assignees = byLowerName(append([]*User{}, assignees[issue.ID]...))
+		if issue.IsPull {
+			issue.RequestedReviewers = byLowerName(append([]*User{}, reviewers[issue.ID]...))
+		}
+	}
+	return nil
+}
+
+// diffUserIDs returns IDs that are in new list but not in old list as added,
+// and IDs that are in old list but not in new list as removed.
+func diffUserIDs(oldIDs, newIDs []int64) (added, removed []int64) {
+	oldSet := make(map[int64]bool, len(oldIDs))
+	for _, id := range oldIDs {
+		oldSet[id] = true
+	}
+	newSet := make(map[int64]bool, len(newIDs))
+	for _, id := range newIDs {
+		newSet[id] = true
+		if !oldSet[id] {
+			added = append(added, id)
+		}
+	}
+	for _, id := range oldIDs {
+		if !newSet[id] {
+			removed = append(removed, id)
+		}
+	}
+	return added, removed
+}
+
+func userIDsOf(users []*User) []int64 {
+	ids := make([]int64, len(users))
+	for i := range users {
+		ids[i] = users[i].ID
+	}
+	return ids
+}
+
+// setIssueAssignees replaces assignees of the issue with given users, the first
+// valid user becomes the primary assignee. It returns IDs of newly added and
+// removed assignees.
+func setIssueAssignees(e Engine, issue *Issue, assigneeIDs []int64) (added, removed []int64, err error) {
+	assigneeIDs, err = validIssueUserIDs(e, issue.Repo, assigneeIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	oldAssignees, err := getUsersByIssueUserFlag(e, issue.ID, "is_assigned")
+	if err != nil {
+		return nil, nil, fmt.Errorf("get assignees: %v", err)
+	}
+	added, removed = diffUserIDs(userIDsOf(oldAssignees), assigneeIDs)
+	for _, id := range removed {
+		if err = setIssueUserFlag(e, issue, id, "is_assigned", false); err != nil {
+			return nil, nil, fmt.Errorf("unassign user [%d]: %v", id, err)
+		}
+	}
+	for _, id := range added {
+		if err = setIssueUserFlag(e, issue, id, "is_assigned", true); err != nil {
+			return nil, nil, fmt.Errorf("assign user [%d]: %v", id, err)
+		}
+	}
+
+	// Keep the primary assignee if it is still assigned.
+	primaryID := int64(0)
+	for _, id := range assigneeIDs {
+		if id == issue.AssigneeID {
+			primaryID = id
+			break
+		}
+	}
+	if primaryID == 0 && len(assigneeIDs) > 0 {
+		primaryID = assigneeIDs[0]
+	}
+	if issue.AssigneeID != primaryID {
+		issue.AssigneeID = primaryID
+		issue.Assignee = nil
+		if err = updateIssueCols(e, issue, "assignee_id"); err != nil {
+			return nil, nil, fmt.Errorf("update issue: %v", err)
+		}
+	}
+
+	issue.Assignees = nil
+	return added, removed, nil
+}
+
+// setIssueRequestedReviewers replaces requested reviewers of the pull request
+// with given users. It returns IDs of newly requested and removed reviewers.
+func setIssueRequestedReviewers(e Engine, issue *Issue, reviewerIDs []int64) (added, removed []int64, err error) {
+	reviewerIDs, err = validIssueUserIDs(e, issue.Repo, reviewerIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	oldReviewers, err := getUsersByIssueUserFlag(e, issue.ID, "is_review_requested")
+	if err != nil {
+		return nil, nil, fmt.Errorf("get requested reviewers: %v", err)
+	}
+	added, removed = diffUserIDs(userIDsOf(oldReviewers), reviewerIDs)
+	for _, id := range removed {
+		if err = setIssueUserFlag(e, issue, id, "is_review_requested", false); err != nil {
+			return nil, nil, fmt.Errorf("remove reviewer [%d]: %v", id, err)
+		}
+	}
+	for _, id := range added {
+		if err = setIssueUserFlag(e, issue, id, "is_review_requested", true); err != nil {
+			return nil, nil, fmt.Errorf("request reviewer [%d]: %v", id, err)
+		}
+	}
+
+	issue.RequestedReviewers = nil
+	return added, removed, nil
+}
+
+// ChangeAssignees replaces assignees of the issue with given users, then
+// notifies newly added assignees and fires webhooks.
+// This method assumes issue.Repo has been loaded.
+func (issue *Issue) ChangeAssignees(doer *User, assigneeIDs []int64) (err error) {
+	sess := x.NewSession()
+	defer sess.Close()
+	if err = sess.Begin(); err != nil {
+		return err
+	}
+
+	added, removed, err := setIssueAssignees(sess, issue, assigneeIDs)
+	if err != nil {
+		return err
+	}
+	for _, id := range removed {
+		if err = createAssigneeEvent(sess, doer, issue, id, false); err != nil {
+			return fmt.Errorf("create unassigned event: %v", err)
+		}
+	}
+	for _, id := range added {
+		if err = createAssigneeEvent(sess, doer, issue, id, true); err != nil {
+			return fmt.Errorf("create assigned event: %v", err)
+		}
+	}
+	if err = sess.Commit(); err != nil {
+		return err
+	}
+
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+	if err = issue.LoadAttributes(); err != nil {
+		return fmt.Errorf("load attributes: %v", err)
+	}
+
+	mailIssueUsers(issue, doer, added, email.SendIssueAssignedMail)
+
+	action := api.HOOK_ISSUE_ASSIGNED
+	if len(added) == 0 {
+		action = api.HOOK_ISSUE_UNASSIGNED
+	}
+	if issue.IsPull {
+		issue.PullRequest.Issue = issue
+		err = PrepareWebhooks(issue.Repo, HookEventTypePullRequest, &api.PullRequestPayload{
+			Action:      action,
+			Index:       issue.Index,
+			PullRequest: issue.PullRequest.APIFormat(),
+			Repository:  issue.Repo.APIFormatLegacy(nil),
+			Sender:      doer.APIFormat(),
+		})
+	} else {
+		err = PrepareWebhooks(issue.Repo, HookEventTypeIssues, &api.IssuesPayload{
+			Action:     action,
+			Index:      issue.Index,
+			Issue:      issue.APIFormat(),
+			Repository: issue.Repo.APIFormatLegacy(nil),
+			Sender:     doer.APIFormat(),
+		})
+	}
+	if err != nil {
+		log.Error("PrepareWebhooks [is_pull: %v, action: %s]: %v", issue.IsPull, action, err)
+	}
+	return nil
+}
+
+// ChangeAssignee replaces all assignees of the issue with the single given user,
+// the assignees are cleared when assigneeID is 0.
+func (issue *Issue) ChangeAssignee(doer *User, assigneeID int64) error {
+	if assigneeID <= 0 {
+		return issue.ChangeAssignees(doer, nil)
+	}
+	return issue.ChangeAssignees(doer, []int64{assigneeID})
+}
+
+// ChangeRequestedReviewers replaces requested reviewers of the pull request
+// with given users, then notifies newly requested reviewers and fires webhooks.
+// This method assumes issue.Repo has been loaded.
+func (issue *Issue) ChangeRequestedReviewers(doer *User, reviewerIDs []int64) (err error) {
+	if !issue.IsPull {
+		return fmt.Errorf("issue [%d] is not a pull request", issue.ID)
+	}
+
+	sess := x.NewSession()
+	defer sess.Close()
+	if err = sess.Begin(); err != nil {
+		return err
+	}
+
+	added, _, err := setIssueRequestedReviewers(sess, issue, reviewerIDs)
+	if err != nil {
+		return err
+	}
+	if err = sess.Commit(); err != nil {
+		return err
+	}
+
+	if len(added) == 0 {
+		return nil
+	}
+	if err = issue.LoadAttributes(); err != nil {
+		return fmt.Errorf("load attributes: %v", err)
+	}
+
+	mailIssueUsers(issue, doer, added, email.SendReviewRequestedMail)
+
+	issue.PullRequest.Issue = issue
+	if err = PrepareWebhooks(issue.Repo, HookEventTypePullRequest, &api.PullRequestPayload{
+		Action:      HookIssueReviewRequested,
+		Index:       issue.Index,
+		PullRequest: issue.PullRequest.APIFormat(),
+		Repository:  issue.Repo.APIFormatLegacy(nil),
+		Sender:      doer.APIFormat(),
+	}); err != nil {
+		log.Error("PrepareWebhooks [action: %s]: %v", HookIssueReviewRequested, err)
+	}
+	return nil
+}
+
+// mailIssueUsers sends emails with given sender to active users of given IDs,
+// except the doer.
+func mailIssueUsers(issue *Issue, doer *User, userIDs []int64, send func(email.Issue, email.Repository, email.User, []string)) {
+	if !conf.User.EnableEmailNotification || len(userIDs) == 0 {
+		return
+	}
+
+	users := make([]*User, 0, len(userIDs))
+	if err := x.In("id", userIDs).Find(&users); err != nil {
+		log.Error("Failed to get users by IDs %v: %v", userIDs, err)
+		return
+	}
+
+	tos := make([]string, 0, len(users))
+	for _, u := range users {
+		if u.ID == doer.ID || u.IsOrganization() || !u.IsActive {
+			continue
+		}
+		tos = append(tos, u.Email)
+	}
+	send(NewMailerIssue(issue), NewMailerRepo(issue.Repo), NewMailerUser(doer), tos)
+}
@@ -0,0 +1,87 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffUserIDs(t *testing.T) {
+	added, removed := diffUserIDs([]int64{1, 2, 3}, []int64{3, 4, 1})
+	assert.Equal(t, []int64{4}, added)
+	assert.Equal(t, []int64{2}, removed)
+
+	added, removed = diffUserIDs(nil, nil)
+	assert.Empty(t, added)
+	assert.Empty(t, removed)
+}
+
+// newTestUser inserts a user with given name, and grants the user read access
+// to the repository when repo is not nil.
+func newTestUser(t *testing.T, name string, repo *Repository) *User {
+	t.Helper()
+
+	u := &User{Name: name, LowerName: name, Email: name + "@example.com", IsActive: true}
+	_, err := x.Insert(u)
+	require.NoError(t, err)
+
+	if repo != nil {
+		_, err = x.Insert(&Access{UserID: u.ID, RepoID: repo.ID, Mode: AccessModeRead})
+		require.NoError(t, err)
+	}
+	return u
+}
+
+func TestValidateAssignableUsers(t *testing.T) {
+	setupLegacyTestDB(t)
+
+	pr := newTestPullRequest(t)
+	repo := pr.Issue.Repo
+	owner := pr.Issue.Poster
+	bob := newTestUser(t, "bob", repo)
+	carol := newTestUser(t, "carol", nil)
+
+	validIDs, err := validIssueUserIDs(x, repo, []int64{bob.ID, owner.ID, bob.ID})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{bob.ID, owner.ID}, validIDs)
+
+	err = ValidateAssignableUsers(repo, []int64{carol.ID, bob.ID, carol.ID})
+	assert.Equal(t, ErrUserNotAssignable{UserIDs: []int64{carol.ID}}, err)
+
+	// Nothing is changed when any of users is not assignable.
+	err = pr.Issue.ChangeAssignees(owner, []int64{bob.ID, carol.ID})
+	assert.True(t, IsErrUserNotAssignable(err))
+	assignees, err := getUsersByIssueUserFlag(x, pr.IssueID, "is_assigned")
+	require.NoError(t, err)
+	assert.Empty(t, assignees)
+}
+
+func TestLoadIssuesUsers(t *testing.T) {
+	setupLegacyTestDB(t)
+
+	pr := newTestPullRequest(t)
+	issue := pr.Issue
+	owner := issue.Poster
+	bob := newTestUser(t, "bob", issue.Repo)
+
+	require.NoError(t, issue.ChangeAssignees(owner, []int64{bob.ID, owner.ID}))
+	require.NoError(t, issue.ChangeRequestedReviewers(owner, []int64{bob.ID}))
+
+	other := &Issue{RepoID: issue.RepoID, Index: 2, PosterID: owner.ID, Title: "Other"}
+	_, err := x.Insert(other)
+	require.NoError(t, err)
+
+	issues := []*Issue{{ID: issue.ID, IsPull: true}, {ID: other.ID}}
+	require.NoError(t, loadIssuesUsers(x, issues))
+
+	assert.Equal(t, []int64{owner.ID, bob.ID}, userIDsOf(issues[0].Assignees))
+	assert.Equal(t, []int64{bob.ID}, userIDsOf(issues[0].RequestedReviewers))
+	assert.NotNil(t, issues[1].Assignees)
+	assert.Empty(t, issues[1].Assignees)
+	assert.Nil(t, issues[1].RequestedReviewers)
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	log "unknwon.dev/clog/v2"
+	"xorm.io/xorm"
+
+	"gogs.io/gogs/internal/conf"
+	"gogs.io/gogs/internal/email"
+)
+
+// DueFilter filters issues by their due dates.
+type DueFilter string
+
+const (
+	DueFilterNone        DueFilter = ""
+	DueFilterOverdue     DueFilter = "overdue"
+	DueFilterScheduled   DueFilter = "scheduled"
+	DueFilterUnscheduled DueFilter = "unscheduled"
+)
+
+// ParseDueFilter returns the due filter of given name, or DueFilterNone if the
+// name is unknown.
+func ParseDueFilter(name string) DueFilter {
+	switch filter := DueFilter(name); filter {
+	case DueFilterOverdue, DueFilterScheduled, DueFilterUnscheduled:
+		return filter
+	}
+	return DueFilterNone
+}
+
+// startOfToday returns the beginning of the current day in local time, issues
+// with due dates before it are overdue.
+func startOfToday() time.Time {
+	now := time.Now().Local()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// applyDueFilter narrows down issues of the session by given filter.
+func applyDueFilter(sess *xorm.Session, filter DueFilter) {
+	switch filter {
+	case DueFilterOverdue:
+		sess.And("issue.deadline_unix > 0").And("issue.deadline_unix < ?", startOfToday().Unix())
+	case DueFilterScheduled:
+		sess.And("issue.deadline_unix > 0")
+	case DueFilterUnscheduled:
+		sess.And("issue.deadline_unix <= 0")
+	}
+}
+
+// HasDeadline returns true if the issue has a due date.
+func (issue *Issue) HasDeadline() bool {
+	return !issue.Deadline.IsZero()
+}
+
+// IsOverdue returns true if the issue is still open after its due date.
+func (issue *Issue) IsOverdue() bool {
+	return !issue.IsClosed && issue.HasDeadline() && issue.Deadline.Before(startOfToday())
+}
+
+// DeadlineOf returns the beginning of the date of given time in local time,
+// which is how due dates of issues are stored. It returns zero time for zero
+// time.
+func DeadlineOf(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// ChangeDeadline sets the due date of the issue to the date of given time, or
+// removes the due date if the time is zero.
+func (issue *Issue) ChangeDeadline(doer *User, deadline time.Time) (err error) {
+	deadline = DeadlineOf(deadline)
+
+	oldDeadline := issue.Deadline
+	if oldDeadline.Equal(deadline) {
+		return nil
+	}
+
+	sess := x.NewSession()
+	defer sess.Close()
+	if err = sess.Begin(); err != nil {
+		return err
+	}
+
+	issue.Deadline = deadline
+	if err = updateIssueCols(sess, issue, "deadline_unix"); err != nil {
+		return fmt.Errorf("updateIssueCols: %v", err)
+	} else if err = createDeadlineEvent(sess, doer, issue, oldDeadline, deadline); err != nil {
+		return fmt.Errorf("createDeadlineEvent: %v", err)
+	}
+
+	return sess.Commit()
+}
+
+// issueOverdueDigest is the list of overdue issues to be sent to a user.
+type issueOverdueDigest struct {
+	User   *User
+	Issues []*Issue
+}
+
+// isIssueUnitEnabled returns true if issues or pull requests, depending on the
+// type of the issue, are still enabled in its repository.
+func isIssueUnitEnabled(issue *Issue) bool {
+	if issue.IsPull {
+		return issue.Repo.AllowsPulls()
+	}
+	return issue.Repo.EnableIssues && !issue.Repo.EnableExternalTracker
+}
+
+// getIssueOverdueDigests returns open issues past their due dates grouped by
+// users to be notified, in ascending order of user IDs. Issues are reported to
+// their assignees, or posters when there is no assignee. Issues are excluded
+// from the digest of a user who is no longer allowed to read them.
+func getIssueOverdueDigests(e Engine) ([]*issueOverdueDigest, error) {
+	issues := make([]*Issue, 0, 10)
+	sess := e.Where("issue.is_closed = ?", false)
+	applyDueFilter(sess, DueFilterOverdue)
+	if err := sess.Asc("issue.deadline_unix").Find(&issues); err != nil {
+		return nil, fmt.Errorf("find overdue issues: %v", err)
+	}
+
+	userIssues := make(map[int64][]*Issue)
+	userIDs := make([]int64, 0, len(issues))
+	for _, issue := range issues {
+		if err := issue.loadAttributes(e); err != nil {
+			log.Error("loadAttributes [%d]: %v", issue.ID, err)
+			continue
+		} else if !isIssueUnitEnabled(issue) {
+			continue
+		}
+
+		recipientIDs := userIDsOf(issue.Assignees)
+		if len(recipientIDs) == 0 {
+			recipientIDs = []int64{issue.PosterID}
+		}
+		for _, userID := range recipientIDs {
+			if userID <= 0 {
+				continue
+			}
+			if _, ok := userIssues[userID]; !ok {
+				userIDs = append(userIDs, userID)
+			}
+			userIssues[userID] = append(userIssues[userID], issue)
+		}
+	}
+	sort.Slice(userIDs, func(i, j int) bool { return userIDs[i] < userIDs[j] })
+
+	ctx := context.TODO()
+	digests := make([]*issueOverdueDigest, 0, len(userIDs))
+	for _, userID := range userIDs {
+		u, err := getUserByID(e, userID)
+		if err != nil {
+			if !IsErrUserNotExist(err) {
+				log.Error("getUserByID [%d]: %v", userID, err)
+			}
+			continue
+		} else if u.IsOrganization() || !u.IsActive {
+			continue
+		}
+
+		// Users could have lost access to repositories after being assigned.
+		readable := make([]*Issue, 0, len(userIssues[userID]))
+		for _, issue := range userIssues[userID] {
+			if Handle.Permissions().Authorize(ctx, u.ID, issue.RepoID, AccessModeRead,
+				AccessModeOptions{
+					OwnerID: issue.Repo.OwnerID,
+					Private: issue.Repo.IsPrivate,
+				},
+			) {
+				readable = append(readable, issue)
+			}
+		}
+		if len(readable) > 0 {
+			digests = append(digests, &issueOverdueDigest{User: u, Issues: readable})
+		}
+	}
+	return digests, nil
+}
+
+// SendIssueOverdueDigests sends every user one email that lists open issues
+// assigned to the user and past their due dates. Overdue issues without any
+// assignee are reported to their posters.
+func SendIssueOverdueDigests() {
+	if taskStatusTable.IsRunning(taskNameIssueOverdueDigest) {
+		return
+	}
+	taskStatusTable.Start(taskNameIssueOverdueDigest)
+	defer taskStatusTable.Stop(taskNameIssueOverdueDigest)
+
+	if !conf.User.EnableEmailNotification {
+		return
+	}
+
+	log.Trace("Doing: SendIssueOverdueDigests")
+
+	digests, err := getIssueOverdueDigests(x)
+	if err != nil {
+		log.Error("Failed to get issue overdue digests: %v", err)
+		return
+	}
+
+	for _, digest := range digests {
+		issues := make([]email.Issue, len(digest.Issues))
+		for i := range digest.Issues {
+			issues[i] = NewMailerIssue(digest.Issues[i])
+		}
+		email.SendIssueOverdueDigestMail(NewMailerUser(digest.User), issues)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDueFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		want DueFilter
+	}{
+		{name: "", want: DueFilterNone},
+		{name: "overdue", want: DueFilterOverdue},
+		{name: "scheduled", want: DueFilterScheduled},
+		{name: "unscheduled", want: DueFilterUnscheduled},
+		{name: "unknown", want: DueFilterNone},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, ParseDueFilter(test.name))
+		})
+	}
+}
+
+func TestIssue_IsOverdue(t *testing.T) {
+	today := startOfToday()
+	tests := []struct {
+		name     string
+		deadline time.Time
+		isClosed bool
+		want     bool
+	}{
+		{name: "no due date", want: false},
+		{name: "due yesterday", deadline: today.AddDate(0, 0, -1), want: true},
+		{name: "due today", deadline: today, want: false},
+		{name: "due tomorrow", deadline: today.AddDate(0, 0, 1), want: false},
+		{name: "closed after due date", deadline: today.AddDate(0, 0, -1), isClosed: true, want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			issue := &Issue{
+				Deadline: test.deadline,
+				IsClosed: test.isClosed,
+			}
+			assert.Equal(t, test.want, issue.IsOverdue())
+		})
+	}
+}
+
+func TestDeadlineOf(t *testing.T) {
+	assert.True(t, DeadlineOf(time.Time{}).IsZero())
+
+	got := DeadlineOf(time.Date(2026, 3, 14, 15, 9, 26, 0, time.Local))
+	assert.Equal(t, time.Date(2026, 3, 14, 0, 0, 0, 0, time.Local), got)
+}
+
+func TestGetIssueOverdueDigests(t *testing.T) {
+	setupLegacyTestDB(t)
+
+	pr := newTestPullRequest(t)
+	publicRepo := pr.Issue.Repo
+	alice := pr.Issue.Poster
+	bob := newTestUser(t, "bob", nil)
+
+	privateRepo := &Repository{OwnerID: alice.ID, Name: "private", LowerName: "private", IsPrivate: true, EnableIssues: true}
+	_, err := x.Insert(privateRepo)
+	require.NoError(t, err)
+	noIssuesRepo := &Repository{OwnerID: alice.ID, Name: "no-issues", LowerName: "no-issues"}
+	_, err = x.Insert(noIssuesRepo)
+	require.NoError(t, err)
+	_, err = x.Exec("UPDATE `repository` SET enable_issues = ? WHERE id IN (?, ?)", true, publicRepo.ID, privateRepo.ID)
+	require.NoError(t, err)
+	_, err = x.Exec("UPDATE `repository` SET enable_issues = ? WHERE id = ?", false, noIssuesRepo.ID)
+	require.NoError(t, err)
+
+	overdue := DeadlineOf(time.Now().AddDate(0, 0, -3))
+	var index int64 = 10
+	newOverdueIssue := func(repo *Repository, title string, assignee *User) *Issue {
+		index++
+		issue := &Issue{RepoID: repo.ID, Index: index, PosterID: alice.ID, Title: title, Deadline: overdue}
+		_, err := x.Insert(issue)
+		require.NoError(t, err)
+		if assignee != nil {
+			_, err = x.Insert(&IssueUser{UserID: assignee.ID, IssueID: issue.ID, RepoID: repo.ID, IsAssigned: true})
+			require.NoError(t, err)
+		}
+		return issue
+	}
+	publicIssue := newOverdueIssue(publicRepo, "public", bob)
+	// Bob has no access to the private repository.
+	privateIssue := newOverdueIssue(privateRepo, "private", bob)
+	unassignedIssue := newOverdueIssue(privateRepo, "unassigned", nil)
+	// Issues are disabled in the repository.
+	newOverdueIssue(noIssuesRepo, "disabled", nil)
+
+	digests, err := getIssueOverdueDigests(x)
+	require.NoError(t, err)
+
+	got := make(map[string][]int64)
+	for _, digest := range digests {
+		for _, issue := range digest.Issues {
+			got[digest.User.Name] = append(got[digest.User.Name], issue.ID)
+		}
+	}
+	want := map[string][]int64{
+		"alice": {unassignedIssue.ID},
+		"bob":   {publicIssue.ID},
+	}
+	assert.Equal(t, want, got)
+	assert.NotContains(t, got["bob"], privateIssue.ID)
+}
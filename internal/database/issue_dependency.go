@@ -0,0 +1,247 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/unknwon/com"
+	"xorm.io/xorm"
+
+	dberrors "gogs.io/gogs/internal/database/errors"
+	"gogs.io/gogs/internal/errutil"
+)
+
+// IssueDependency represents a blocking relationship that the issue is blocked
+// by the dependency, both issues may belong to different repositories.
+type IssueDependency struct {
+	ID           int64
+	IssueID      int64 `xorm:"UNIQUE(issue_dependency) NOT NULL"`
+	DependencyID int64 `xorm:"UNIQUE(issue_dependency) INDEX NOT NULL"`
+	CreatorID    int64
+
+	Created     time.Time `xorm:"-" json:"-" gorm:"-"`
+	CreatedUnix int64
+}
+
+func (d *IssueDependency) BeforeInsert() {
+	d.CreatedUnix = time.Now().Unix()
+}
+
+func (d *IssueDependency) AfterSet(colName string, _ xorm.Cell) {
+	switch colName {
+	case "created_unix":
+		d.Created = time.Unix(d.CreatedUnix, 0).Local()
+	}
+}
+
+type ErrIssueDependencyExist struct {
+	args map[string]any
+}
+
+func IsErrIssueDependencyExist(err error) bool {
+	_, ok := err.(ErrIssueDependencyExist)
+	return ok
+}
+
+func (err ErrIssueDependencyExist) Error() string {
+	return fmt.Sprintf("issue dependency already exists: %v", err.args)
+}
+
+type ErrIssueDependencyCircular struct {
+	args map[string]any
+}
+
+func IsErrIssueDependencyCircular(err error) bool {
+	_, ok := err.(ErrIssueDependencyCircular)
+	return ok
+}
+
+func (err ErrIssueDependencyCircular) Error() string {
+	return fmt.Sprintf("issue dependency would be circular: %v", err.args)
+}
+
+var _ errutil.NotFound = (*ErrIssueDependencyNotExist)(nil)
+
+type ErrIssueDependencyNotExist struct {
+	args map[string]any
+}
+
+func IsErrIssueDependencyNotExist(err error) bool {
+	_, ok := err.(ErrIssueDependencyNotExist)
+	return ok
+}
+
+func (err ErrIssueDependencyNotExist) Error() string {
+	return fmt.Sprintf("issue dependency does not exist: %v", err.args)
+}
+
+func (ErrIssueDependencyNotExist) NotFound() bool {
+	return true
+}
+
+type ErrIssueBlocked struct {
+	args map[string]any
+}
+
+func IsErrIssueBlocked(err error) bool {
+	_, ok := err.(ErrIssueBlocked)
+	return ok
+}
+
+func (err ErrIssueBlocked) Error() string {
+	return fmt.Sprintf("issue is blocked by open dependencies: %v", err.args)
+}
+
+// getIssuesByDependency returns issues on one side of dependencies of given
+// issue, which are blockers when col is "issue_id" and blocked issues when col
+// is "dependency_id".
+func getIssuesByDependency(e Engine, issueID int64, col string) ([]*Issue, error) {
+	other := "dependency_id"
+	if col == "dependency_id" {
+		other = "issue_id"
+	}
+
+	issues := make([]*Issue, 0, 2)
+	if err := e.Where("issue_dependency."+col+" = ?", issueID).
+		Join("INNER", "issue_dependency", "issue.id = issue_dependency."+other).
+		Asc("issue_dependency.id").
+		Find(&issues); err != nil {
+		return nil, err
+	}
+
+	// Issues may belong to different repositories, whose owners are needed to
+	// compose links.
+	for _, issue := range issues {
+		if err := issue.loadAttributes(e); err != nil {
+			return nil, fmt.Errorf("loadAttributes [%d]: %v", issue.ID, err)
+		} else if err = issue.Repo.getOwner(e); err != nil {
+			return nil, fmt.Errorf("getOwner [repo_id: %d]: %v", issue.RepoID, err)
+		}
+	}
+	return issues, nil
+}
+
+// BlockedBy returns issues that block the issue.
+func (issue *Issue) BlockedBy() ([]*Issue, error) {
+	return getIssuesByDependency(x, issue.ID, "issue_id")
+}
+
+// Blocks returns issues that are blocked by the issue.
+func (issue *Issue) Blocks() ([]*Issue, error) {
+	return getIssuesByDependency(x, issue.ID, "dependency_id")
+}
+
+func countOpenBlockers(e Engine, issueID int64) (int64, error) {
+	return e.Where("issue_dependency.issue_id = ?", issueID).
+		And("issue.is_closed = ?", false).
+		Join("INNER", "issue_dependency", "issue.id = issue_dependency.dependency_id").
+		Count(new(Issue))
+}
+
+// HasOpenBlockers returns true if the issue is blocked by any open issue.
+func (issue *Issue) HasOpenBlockers() (bool, error) {
+	count, err := countOpenBlockers(x, issue.ID)
+	return count > 0, err
+}
+
+// isIssueBlockedBy returns true if the issue is blocked by the dependency
+// directly or transitively.
+func isIssueBlockedBy(e Engine, issueID, dependencyID int64) (bool, error) {
+	visited := map[int64]bool{issueID: true}
+	queue := []int64{issueID}
+	for len(queue) > 0 {
+		deps := make([]*IssueDependency, 0, 2)
+		if err := e.In("issue_id", queue).Find(&deps); err != nil {
+			return false, err
+		}
+
+		queue = queue[:0]
+		for _, dep := range deps {
+			if dep.DependencyID == dependencyID {
+				return true, nil
+			} else if !visited[dep.DependencyID] {
+				visited[dep.DependencyID] = true
+				queue = append(queue, dep.DependencyID)
+			}
+		}
+	}
+	return false, nil
+}
+
+// GetIssueByRelativeRef returns the issue referenced by "#index" in given
+// repository or "owner/repo#index" in any repository.
+func GetIssueByRelativeRef(repo *Repository, ref string) (*Issue, error) {
+	ref = strings.TrimSpace(ref)
+	if !strings.HasPrefix(ref, "#") {
+		issue, err := GetIssueByRef(ref)
+		if errutil.IsNotFound(err) || dberrors.IsInvalidRepoReference(err) {
+			return nil, ErrIssueNotExist{args: map[string]any{"ref": ref}}
+		}
+		return issue, err
+	}
+
+	index := com.StrTo(ref[1:]).MustInt64()
+	if index <= 0 {
+		return nil, ErrIssueNotExist{args: map[string]any{"ref": ref}}
+	}
+	return GetIssueByIndex(repo.ID, index)
+}
+
+// AddIssueDependency makes the issue blocked by the dependency. It returns an
+// error if the relationship already exists or would create a cycle.
+func AddIssueDependency(doer *User, issue, dependency *Issue) (err error) {
+	if issue.ID == dependency.ID {
+		return ErrIssueDependencyCircular{args: map[string]any{"issueID": issue.ID, "dependencyID": dependency.ID}}
+	}
+
+	sess := x.NewSession()
+	defer sess.Close()
+	if err = sess.Begin(); err != nil {
+		return err
+	}
+
+	has, err := sess.Get(&IssueDependency{IssueID: issue.ID, DependencyID: dependency.ID})
+	if err != nil {
+		return err
+	} else if has {
+		return ErrIssueDependencyExist{args: map[string]any{"issueID": issue.ID, "dependencyID": dependency.ID}}
+	}
+
+	circular, err := isIssueBlockedBy(sess, dependency.ID, issue.ID)
+	if err != nil {
+		return fmt.Errorf("check circular dependency: %v", err)
+	} else if circular {
+		return ErrIssueDependencyCircular{args: map[string]any{"issueID": issue.ID, "dependencyID": dependency.ID}}
+	}
+
+	if _, err = sess.Insert(&IssueDependency{
+		IssueID:      issue.ID,
+		DependencyID: dependency.ID,
+		CreatorID:    doer.ID,
+	}); err != nil {
+		return err
+	}
+	return sess.Commit()
+}
+
+// RemoveIssueDependency removes the dependency from blockers of the issue.
+func RemoveIssueDependency(issue, dependency *Issue) error {
+	affected, err := x.Delete(&IssueDependency{IssueID: issue.ID, DependencyID: dependency.ID})
+	if err != nil {
+		return err
+	} else if affected == 0 {
+		return ErrIssueDependencyNotExist{args: map[string]any{"issueID": issue.ID, "dependencyID": dependency.ID}}
+	}
+	return nil
+}
+
+// deleteIssueDependencies deletes all dependencies that involve given issue.
+func deleteIssueDependencies(e Engine, issueID int64) error {
+	_, err := e.Where("issue_id = ? OR dependency_id = ?", issueID, issueID).Delete(new(IssueDependency))
+	return err
+}
@@ -0,0 +1,93 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestIssue inserts an open issue to the repository and returns it with
+// attributes loaded.
+func newTestIssue(t *testing.T, repo *Repository, poster *User, title string) *Issue {
+	t.Helper()
+
+	issue := &Issue{RepoID: repo.ID, Index: repo.NextIssueIndex(), PosterID: poster.ID, Title: title}
+	_, err := x.Insert(issue)
+	require.NoError(t, err)
+	_, err = x.Exec("UPDATE `repository` SET num_issues = num_issues + 1 WHERE id = ?", repo.ID)
+	require.NoError(t, err)
+	repo.NumIssues++
+
+	issue, err = GetIssueByID(issue.ID)
+	require.NoError(t, err)
+	return issue
+}
+
+func TestIssueDependencies(t *testing.T) {
+	setupLegacyTestDB(t)
+
+	pr := newTestPullRequest(t)
+	repo := pr.Issue.Repo
+	doer := pr.Issue.Poster
+	a := newTestIssue(t, repo, doer, "A")
+	b := newTestIssue(t, repo, doer, "B")
+	c := newTestIssue(t, repo, doer, "C")
+
+	err := AddIssueDependency(doer, a, a)
+	assert.True(t, IsErrIssueDependencyCircular(err))
+
+	// A is blocked by B, B is blocked by C.
+	require.NoError(t, AddIssueDependency(doer, a, b))
+	require.NoError(t, AddIssueDependency(doer, b, c))
+
+	err = AddIssueDependency(doer, a, b)
+	assert.True(t, IsErrIssueDependencyExist(err))
+	err = AddIssueDependency(doer, c, a)
+	assert.True(t, IsErrIssueDependencyCircular(err), "transitive cycle")
+
+	blockers, err := a.BlockedBy()
+	require.NoError(t, err)
+	require.Len(t, blockers, 1)
+	assert.Equal(t, b.ID, blockers[0].ID)
+	blocks, err := c.Blocks()
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	assert.Equal(t, b.ID, blocks[0].ID)
+
+	blocked, err := a.HasOpenBlockers()
+	require.NoError(t, err)
+	assert.True(t, blocked)
+
+	err = RemoveIssueDependency(a, c)
+	assert.True(t, IsErrIssueDependencyNotExist(err))
+	require.NoError(t, RemoveIssueDependency(b, c))
+	blocks, err = c.Blocks()
+	require.NoError(t, err)
+	assert.Empty(t, blocks)
+}
+
+func TestIssue_ChangeStatus_Blocked(t *testing.T) {
+	setupLegacyTestDB(t)
+
+	pr := newTestPullRequest(t)
+	repo := pr.Issue.Repo
+	doer := pr.Issue.Poster
+	a := newTestIssue(t, repo, doer, "A")
+	b := newTestIssue(t, repo, doer, "B")
+	require.NoError(t, AddIssueDependency(doer, a, b))
+
+	// Blocked issues can be closed unless the repository disallows it.
+	repo.BlockCloseByDependencies = true
+	err := a.ChangeStatus(doer, repo, true)
+	assert.True(t, IsErrIssueBlocked(err))
+	assert.False(t, a.IsClosed)
+
+	require.NoError(t, b.ChangeStatus(doer, repo, true))
+	require.NoError(t, a.ChangeStatus(doer, repo, true))
+	assert.True(t, a.IsClosed)
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"xorm.io/xorm"
+)
+
+// createIssueEvent records a change of the issue made by the doer as an event
+// comment in the issue timeline.
+func createIssueEvent(e *xorm.Session, doer *User, issue *Issue, opts *CreateCommentOptions) (err error) {
+	if issue.Repo == nil {
+		issue.Repo, err = getRepositoryByID(e, issue.RepoID)
+		if err != nil {
+			return fmt.Errorf("get repository by ID: %v", err)
+		}
+	}
+	if err = issue.Repo.getOwner(e); err != nil {
+		return fmt.Errorf("get owner: %v", err)
+	}
+
+	opts.Doer = doer
+	opts.Repo = issue.Repo
+	opts.Issue = issue
+	if _, err = createComment(e, opts); err != nil {
+		return fmt.Errorf("create comment: %v", err)
+	}
+	return nil
+}
+
+func createLabelEvent(e *xorm.Session, doer *User, issue *Issue, label *Label, added bool) error {
+	opts := &CreateCommentOptions{
+		Type:    CommentTypeLabel,
+		LabelID: label.ID,
+	}
+	if added {
+		opts.NewValue = label.Name
+	} else {
+		opts.OldValue = label.Name
+	}
+	return createIssueEvent(e, doer, issue, opts)
+}
+
+func createMilestoneEvent(e *xorm.Session, doer *User, issue *Issue, oldMilestone, newMilestone *Milestone) error {
+	opts := &CreateCommentOptions{
+		Type: CommentTypeMilestone,
+	}
+	if oldMilestone != nil {
+		opts.OldValue = oldMilestone.Name
+	}
+	if newMilestone != nil {
+		opts.NewValue = newMilestone.Name
+	}
+	return createIssueEvent(e, doer, issue, opts)
+}
+
+func createAssigneeEvent(e *xorm.Session, doer *User, issue *Issue, assigneeID int64, added bool) error {
+	assignee, err := getUserByID(e, assigneeID)
+	if err != nil {
+		return fmt.Errorf("get user by ID [%d]: %v", assigneeID, err)
+	}
+
+	opts := &CreateCommentOptions{
+		Type:       CommentTypeAssignees,
+		AssigneeID: assignee.ID,
+	}
+	if added {
+		opts.NewValue = assignee.Name
+	} else {
+		opts.OldValue = assignee.Name
+	}
+	return createIssueEvent(e, doer, issue, opts)
+}
+
+func createTitleEvent(e *xorm.Session, doer *User, issue *Issue, oldTitle, newTitle string) error {
+	return createIssueEvent(e, doer, issue, &CreateCommentOptions{
+		Type:     CommentTypeChangeTitle,
+		OldValue: oldTitle,
+		NewValue: newTitle,
+	})
+}
+
+// deadlineEventFormat is the format of due dates recorded in events.
+const deadlineEventFormat = "2006-01-02"
+
+func createDeadlineEvent(e *xorm.Session, doer *User, issue *Issue, oldDeadline, newDeadline time.Time) error {
+	opts := &CreateCommentOptions{
+		Type: CommentTypeDeadline,
+	}
+	if !oldDeadline.IsZero() {
+		opts.OldValue = oldDeadline.Format(deadlineEventFormat)
+	}
+	if !newDeadline.IsZero() {
+		opts.NewValue = newDeadline.Format(deadlineEventFormat)
+	}
+	return createIssueEvent(e, doer, issue, opts)
+}
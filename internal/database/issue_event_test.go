@@ -0,0 +1,81 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentType_String(t *testing.T) {
+	assert.Equal(t, "comment", CommentTypeComment.String())
+	assert.Equal(t, "label", CommentTypeLabel.String())
+	assert.Equal(t, "change_title", CommentTypeChangeTitle.String())
+}
+
+func TestIssueEvents(t *testing.T) {
+	setupLegacyTestDB(t)
+
+	pr := newTestPullRequest(t)
+	issue := pr.Issue
+	doer := issue.Poster
+	bob := newTestUser(t, "bob", issue.Repo)
+
+	bug := &Label{RepoID: issue.RepoID, Name: "bug", Color: "#ee0701"}
+	feature := &Label{RepoID: issue.RepoID, Name: "feature", Color: "#84b6eb"}
+	_, err := x.Insert(bug, feature)
+	require.NoError(t, err)
+	milestone := &Milestone{RepoID: issue.RepoID, Name: "v1.0"}
+	_, err = x.Insert(milestone)
+	require.NoError(t, err)
+
+	require.NoError(t, issue.AddLabel(doer, bug))
+	// Adding an existing label is not recorded again.
+	require.NoError(t, issue.AddLabel(doer, bug))
+	require.NoError(t, issue.ReplaceLabels(doer, []*Label{feature}))
+	require.NoError(t, issue.RemoveLabel(doer, feature))
+
+	// Setting the same title is not recorded.
+	require.NoError(t, issue.ChangeTitle(doer, issue.Title))
+	require.NoError(t, issue.ChangeTitle(doer, "Add a feature"))
+
+	issue.MilestoneID = milestone.ID
+	require.NoError(t, ChangeMilestoneAssign(doer, issue, 0))
+	issue.MilestoneID = 0
+	require.NoError(t, ChangeMilestoneAssign(doer, issue, milestone.ID))
+
+	require.NoError(t, issue.ChangeAssignees(doer, []int64{bob.ID}))
+	require.NoError(t, issue.ChangeAssignees(doer, nil))
+
+	comments := make([]*Comment, 0, 10)
+	require.NoError(t, x.Where("issue_id = ?", issue.ID).Asc("id").Find(&comments))
+
+	type event struct {
+		Type     CommentType
+		OldValue string
+		NewValue string
+	}
+	got := make([]event, len(comments))
+	for i, c := range comments {
+		assert.Equal(t, doer.ID, c.PosterID)
+		got[i] = event{Type: c.Type, OldValue: c.OldValue, NewValue: c.NewValue}
+	}
+	want := []event{
+		{Type: CommentTypeLabel, NewValue: "bug"},
+		{Type: CommentTypeLabel, OldValue: "bug"},
+		{Type: CommentTypeLabel, NewValue: "feature"},
+		{Type: CommentTypeLabel, OldValue: "feature"},
+		{Type: CommentTypeChangeTitle, OldValue: "Add feature", NewValue: "Add a feature"},
+		{Type: CommentTypeMilestone, NewValue: "v1.0"},
+		{Type: CommentTypeMilestone, OldValue: "v1.0"},
+		{Type: CommentTypeAssignees, NewValue: "bob"},
+		{Type: CommentTypeAssignees, OldValue: "bob"},
+	}
+	assert.Equal(t, want, got)
+	assert.Equal(t, bug.ID, comments[0].LabelID)
+	assert.Equal(t, bob.ID, comments[7].AssigneeID)
+}
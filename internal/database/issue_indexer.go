@@ -0,0 +1,322 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/unknwon/com"
+	log "unknwon.dev/clog/v2"
+
+	"gogs.io/gogs/internal/conf"
+	"gogs.io/gogs/internal/indexer"
+	"gogs.io/gogs/internal/sync"
+)
+
+const (
+	// issueIndexerPoolSize is the number of issue search indexes kept in memory.
+	issueIndexerPoolSize = 64
+	// issueIndexerMetaUpdated is the metadata key of the time when the index
+	// of a repository was last rebuilt, an index without it is incomplete.
+	issueIndexerMetaUpdated = "updated"
+	// issueIndexerTitleWeight is how many times an occurrence in the title of
+	// an issue counts compared to its content and comments.
+	issueIndexerTitleWeight = 5
+	// issueIndexerMaxResults is the maximum number of issues a keyword search
+	// ranks by the index, searches matching more issues fall back to the
+	// database so that results and totals stay complete.
+	issueIndexerMaxResults = 1000
+	// issueIndexerSaveDelay is how long changes of issues are kept in memory
+	// before their indexes are saved, so that a series of changes to issues
+	// of a repository saves its index once.
+	issueIndexerSaveDelay = 5 * time.Second
+)
+
+var (
+	// issueIndexerPool manages issue search indexes of repositories by their
+	// IDs, which is nil if the issue indexer is disabled.
+	issueIndexerPool *indexer.Pool
+	// issueIndexerQueue is the queue of issue search index operations, which are
+	// in the format of "repo:<repo ID>" to rebuild the index of a repository,
+	// "issue:<issue ID>" to update an issue, and "remove:<repo ID>:<issue ID>"
+	// to remove an issue from the index of a repository.
+	issueIndexerQueue = sync.NewUniqueQueue(1000)
+)
+
+// InitIssueIndexer starts updating issue search indexes in the background, and
+// builds indexes of repositories that have not been indexed.
+func InitIssueIndexer() {
+	if !conf.Indexer.IssueIndexerEnabled {
+		return
+	}
+
+	issueIndexerPool = indexer.NewPool(conf.Indexer.IssueIndexerPath, issueIndexerPoolSize)
+	go processIssueIndexerQueue()
+	go func() {
+		if err := queueIssueIndexRebuilds(false); err != nil {
+			log.Error("Failed to queue building issue search indexes: %v", err)
+		}
+	}()
+}
+
+// queueIssueIndexRebuilds queues repositories to have their issue search
+// indexes rebuilt, only the ones not yet indexed unless all is true.
+func queueIssueIndexRebuilds(all bool) error {
+	repoIDs := make([]int64, 0, 100)
+	if err := x.Table("repository").Cols("id").Find(&repoIDs); err != nil {
+		return errors.Wrap(err, "list repositories")
+	}
+
+	for _, repoID := range repoIDs {
+		if !all {
+			meta, err := issueIndexerPool.ReadMeta(com.ToStr(repoID))
+			if err == nil && meta[issueIndexerMetaUpdated] != "" {
+				continue
+			}
+		}
+		issueIndexerQueue.Add(fmt.Sprintf("repo:%d", repoID))
+	}
+	return nil
+}
+
+// RebuildIssueIndexes queues issue search indexes of all repositories to be
+// rebuilt from the database.
+func RebuildIssueIndexes() error {
+	if issueIndexerPool == nil {
+		return errors.New("issue indexer is not enabled")
+	}
+	return queueIssueIndexRebuilds(true)
+}
+
+// UpdateIssueIndexer queues the issue to have its title, content and comments
+// updated in the issue search index.
+func UpdateIssueIndexer(issueID int64) {
+	if issueIndexerPool == nil {
+		return
+	}
+	go issueIndexerQueue.Add(fmt.Sprintf("issue:%d", issueID))
+}
+
+// removeIssueIndexer queues the issue to be removed from the issue search index
+// of the repository.
+func removeIssueIndexer(repoID, issueID int64) {
+	if issueIndexerPool == nil {
+		return
+	}
+	go issueIndexerQueue.Add(fmt.Sprintf("remove:%d:%d", repoID, issueID))
+}
+
+// issueIndexBatch holds indexes that have been changed but not yet saved, by
+// their names.
+type issueIndexBatch map[string]*indexer.Index
+
+// get returns the index with given name for changing it.
+func (b issueIndexBatch) get(name string) (*indexer.Index, error) {
+	if idx, ok := b[name]; ok {
+		return idx, nil
+	}
+	return issueIndexerPool.Get(name)
+}
+
+// save saves all changed indexes and empties the batch.
+func (b issueIndexBatch) save() {
+	for name, idx := range b {
+		if err := issueIndexerPool.Put(name, idx); err != nil {
+			log.Error("Failed to save issue search index [repo_id: %s]: %v", name, err)
+		}
+		delete(b, name)
+	}
+}
+
+func processIssueIndexerQueue() {
+	batch := make(issueIndexBatch)
+	timer := time.NewTimer(issueIndexerSaveDelay)
+	timer.Stop()
+	for {
+		select {
+		case op := <-issueIndexerQueue.Queue():
+			log.Trace("IssueIndexer: %s", op)
+			issueIndexerQueue.Remove(op)
+
+			pending := len(batch)
+			var err error
+			fields := strings.Split(op, ":")
+			switch {
+			case len(fields) == 2 && fields[0] == "repo":
+				err = rebuildIssueIndex(batch, com.StrTo(fields[1]).MustInt64())
+			case len(fields) == 2 && fields[0] == "issue":
+				err = updateIssueIndex(batch, com.StrTo(fields[1]).MustInt64())
+			case len(fields) == 3 && fields[0] == "remove":
+				err = removeIssueIndex(batch, com.StrTo(fields[1]).MustInt64(), fields[2])
+			default:
+				err = errors.New("unknown operation")
+			}
+			if err != nil {
+				log.Error("Failed to update issue search index [%s]: %v", op, err)
+			}
+
+			// Changes are saved within the delay since the first unsaved one.
+			if pending == 0 && len(batch) > 0 {
+				timer.Reset(issueIndexerSaveDelay)
+			}
+		case <-timer.C:
+			batch.save()
+		}
+	}
+}
+
+// issueIndexContent returns the document of the issue to be indexed, which is
+// the title in the first line followed by the content and comments.
+func issueIndexContent(issue *Issue, comments []*Comment) string {
+	var buf strings.Builder
+	buf.WriteString(issue.Title)
+	buf.WriteString("\n")
+	buf.WriteString(issue.Content)
+	for _, c := range comments {
+		if c.Type == CommentTypeComment {
+			buf.WriteString("\n")
+			buf.WriteString(c.Content)
+		}
+	}
+	return buf.String()
+}
+
+// rebuildIssueIndex rebuilds the issue search index of the repository from
+// the database and saves it right away, unsaved changes of the index in the
+// batch are discarded.
+func rebuildIssueIndex(batch issueIndexBatch, repoID int64) error {
+	name := com.ToStr(repoID)
+	delete(batch, name)
+	if _, err := GetRepositoryByID(repoID); err != nil {
+		if IsErrRepoNotExist(err) {
+			return issueIndexerPool.Remove(name)
+		}
+		return errors.Wrap(err, "get repository by ID")
+	}
+
+	idx := indexer.New("")
+	issues := make([]*Issue, 0, 100)
+	if err := x.Where("repo_id = ?", repoID).Find(&issues); err != nil {
+		return errors.Wrap(err, "list issues")
+	}
+
+	for _, issue := range issues {
+		comments, err := getCommentsByIssueID(x, issue.ID)
+		if err != nil {
+			return errors.Wrapf(err, "get comments of issue %d", issue.ID)
+		}
+		idx.Put(com.ToStr(issue.ID), issueIndexContent(issue, comments))
+	}
+	idx.SetMeta(issueIndexerMetaUpdated, strconv.FormatInt(time.Now().Unix(), 10))
+	return issueIndexerPool.Put(name, idx)
+}
+
+// updateIssueIndex updates the issue in the issue search index of its
+// repository and adds the index to the batch, the whole index is rebuilt if it
+// is incomplete.
+func updateIssueIndex(batch issueIndexBatch, issueID int64) error {
+	issue, err := getRawIssueByID(x, issueID)
+	if err != nil {
+		if IsErrIssueNotExist(err) {
+			return nil
+		}
+		return errors.Wrap(err, "get issue by ID")
+	}
+
+	name := com.ToStr(issue.RepoID)
+	idx, err := batch.get(name)
+	if err != nil {
+		return errors.Wrap(err, "get index")
+	} else if idx.Meta(issueIndexerMetaUpdated) == "" {
+		return rebuildIssueIndex(batch, issue.RepoID)
+	}
+
+	comments, err := getCommentsByIssueID(x, issue.ID)
+	if err != nil {
+		return errors.Wrap(err, "get comments")
+	}
+	idx.Put(com.ToStr(issue.ID), issueIndexContent(issue, comments))
+	batch[name] = idx
+	return nil
+}
+
+// removeIssueIndex removes the issue from the issue search index of the
+// repository and adds the index to the batch.
+func removeIssueIndex(batch issueIndexBatch, repoID int64, issueID string) error {
+	name := com.ToStr(repoID)
+	idx, err := batch.get(name)
+	if err != nil {
+		return errors.Wrap(err, "get index")
+	} else if idx.Meta(issueIndexerMetaUpdated) == "" {
+		return nil
+	}
+
+	idx.Delete(issueID)
+	batch[name] = idx
+	return nil
+}
+
+// deleteIssueIndex deletes the issue search index of the repository.
+func deleteIssueIndex(repoID int64) {
+	if issueIndexerPool == nil {
+		return
+	}
+	if err := issueIndexerPool.Remove(com.ToStr(repoID)); err != nil {
+		log.Error("Failed to delete issue search index [repo_id: %d]: %v", repoID, err)
+	}
+}
+
+// searchIssueIndex returns IDs of issues of the repositories that contain all
+// the keywords in their titles, contents or comments, ordered by relevance.
+// It returns false if the issue indexer is not enabled, or the keywords match
+// too many issues to be ranked.
+func searchIssueIndex(repoIDs []int64, keywords []string) ([]int64, bool) {
+	if issueIndexerPool == nil {
+		return nil, false
+	}
+
+	type hit struct {
+		issueID int64
+		score   int
+	}
+	var hits []hit
+	for _, repoID := range repoIDs {
+		idx, err := issueIndexerPool.Get(com.ToStr(repoID))
+		if err != nil {
+			log.Error("Failed to get issue search index [repo_id: %d]: %v", repoID, err)
+			return nil, false
+		} else if idx.Meta(issueIndexerMetaUpdated) == "" {
+			// The index is being built, fall back to the database for now.
+			return nil, false
+		}
+
+		for _, h := range idx.Rank(keywords, issueIndexerTitleWeight) {
+			hits = append(hits, hit{issueID: com.StrTo(h.ID).MustInt64(), score: h.Score})
+		}
+		if len(hits) > issueIndexerMaxResults {
+			log.Trace("Too many issues matched by index, falling back to database: %v", keywords)
+			return nil, false
+		}
+	}
+
+	// Newer issues go first when they are equally relevant.
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		return hits[i].issueID > hits[j].issueID
+	})
+
+	issueIDs := make([]int64, len(hits))
+	for i := range hits {
+		issueIDs[i] = hits[i].issueID
+	}
+	return issueIDs, true
+}
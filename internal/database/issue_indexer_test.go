@@ -0,0 +1,87 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/unknwon/com"
+
+	"gogs.io/gogs/internal/indexer"
+)
+
+func TestIssueIndexContent(t *testing.T) {
+	issue := &Issue{Title: "Crash on startup", Content: "The server crashes."}
+	comments := []*Comment{
+		{Type: CommentTypeComment, Content: "Same here."},
+		{Type: CommentTypeChangeTitle, Content: "Ignored"},
+		{Type: CommentTypeComment, Content: "Fixed in master."},
+	}
+	want := "Crash on startup\nThe server crashes.\nSame here.\nFixed in master."
+	assert.Equal(t, want, issueIndexContent(issue, comments))
+}
+
+func TestIssueRankOrder(t *testing.T) {
+	assert.Equal(t, "CASE issue.id WHEN 7 THEN 0 WHEN 3 THEN 1 END", issueRankOrder([]int64{7, 3}))
+}
+
+func setupTestIssueIndexer(t *testing.T) {
+	t.Helper()
+
+	issueIndexerPool = indexer.NewPool(t.TempDir(), issueIndexerPoolSize)
+	t.Cleanup(func() {
+		issueIndexerPool = nil
+	})
+}
+
+func TestIssueIndexBatch(t *testing.T) {
+	setupLegacyTestDB(t)
+	setupTestIssueIndexer(t)
+
+	pr := newTestPullRequest(t)
+	repo := pr.Issue.Repo
+	name := com.ToStr(repo.ID)
+
+	batch := make(issueIndexBatch)
+	require.NoError(t, rebuildIssueIndex(batch, repo.ID))
+	assert.Empty(t, batch, "rebuilt index is saved right away")
+
+	issue := newTestIssue(t, repo, pr.Issue.Poster, "Crash on startup")
+	require.NoError(t, updateIssueIndex(batch, issue.ID))
+	require.Contains(t, batch, name)
+
+	// The change is searchable before being saved.
+	ids, ok := searchIssueIndex([]int64{repo.ID}, []string{"crash"})
+	require.True(t, ok)
+	assert.Equal(t, []int64{issue.ID}, ids)
+	saved, err := indexer.Open(issueIndexerPool.Path(name))
+	require.NoError(t, err)
+	assert.Equal(t, 1, saved.Len())
+
+	require.NoError(t, removeIssueIndex(batch, repo.ID, com.ToStr(pr.Issue.ID)))
+	batch.save()
+	assert.Empty(t, batch)
+	saved, err = indexer.Open(issueIndexerPool.Path(name))
+	require.NoError(t, err)
+	assert.Equal(t, 1, saved.Len())
+	assert.Len(t, saved.Rank([]string{"crash"}, issueIndexerTitleWeight), 1)
+}
+
+func TestSearchIssueIndex_TooManyResults(t *testing.T) {
+	setupTestIssueIndexer(t)
+
+	idx := indexer.New("")
+	for i := 1; i <= issueIndexerMaxResults+1; i++ {
+		idx.Put(com.ToStr(i), "Crash on startup")
+	}
+	idx.SetMeta(issueIndexerMetaUpdated, "1")
+	require.NoError(t, issueIndexerPool.Put("1", idx))
+
+	ids, ok := searchIssueIndex([]int64{1}, []string{"crash"})
+	assert.False(t, ok, "falls back to the database")
+	assert.Nil(t, ids)
+}
@@ -53,10 +53,12 @@ func GetLabelTemplateFile(name string) ([][2]string, error) {
 	return list, nil
 }
 
-// Label represents a label of repository for issues.
+// Label represents a label of repository for issues. A label of organization
+// has OrgID instead of RepoID, and is available to all its repositories.
 type Label struct {
 	ID              int64
 	RepoID          int64 `xorm:"INDEX"`
+	OrgID           int64 `xorm:"INDEX"`
 	Name            string
 	Color           string `xorm:"VARCHAR(7)"`
 	NumIssues       int
@@ -73,6 +75,20 @@ func (l *Label) APIFormat() *api.Label {
 	}
 }
 
+// IsOrgLabel returns true if the label belongs to an organization.
+func (l *Label) IsOrgLabel() bool {
+	return l.OrgID > 0
+}
+
+// IsAvailableTo returns true if the label can be used by issues of given
+// repository.
+func (l *Label) IsAvailableTo(repo *Repository) bool {
+	if l.IsOrgLabel() {
+		return l.OrgID == repo.OwnerID
+	}
+	return l.RepoID == repo.ID
+}
+
 // CalOpenIssues calculates the open issues of label.
 func (l *Label) CalOpenIssues() {
 	l.NumOpenIssues = l.NumIssues - l.NumClosedIssues
@@ -180,6 +196,65 @@ func GetLabelOfRepoByName(repoID int64, labelName string) (*Label, error) {
 	return getLabelOfRepoByName(x, repoID, labelName)
 }
 
+// getLabelAvailableToRepoByID returns a label by ID that is available to given
+// repository.
+func getLabelAvailableToRepoByID(e Engine, repo *Repository, labelID int64) (*Label, error) {
+	l, err := getLabelOfRepoByID(e, 0, labelID)
+	if err != nil {
+		return nil, err
+	} else if !l.IsAvailableTo(repo) {
+		return nil, ErrLabelNotExist{args: map[string]any{"repoID": repo.ID, "labelID": labelID}}
+	}
+	return l, nil
+}
+
+// GetLabelAvailableToRepoByID returns a label by ID that is available to given
+// repository, which is either of the repository or of its organization.
+func GetLabelAvailableToRepoByID(repo *Repository, labelID int64) (*Label, error) {
+	return getLabelAvailableToRepoByID(x, repo, labelID)
+}
+
+// getLabelAvailableToRepoByName returns a label by name that is available to
+// given repository, a label of the repository takes precedence over the one of
+// its organization.
+func getLabelAvailableToRepoByName(e Engine, repo *Repository, labelName string) (*Label, error) {
+	l, err := getLabelOfRepoByName(e, repo.ID, labelName)
+	if err == nil || !IsErrLabelNotExist(err) || labelName == "" {
+		return l, err
+	}
+
+	l = new(Label)
+	has, err := e.Where("org_id > 0 AND org_id = ? AND name = ?", repo.OwnerID, labelName).Get(l)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrLabelNotExist{args: map[string]any{"repoID": repo.ID}}
+	}
+	return l, nil
+}
+
+// GetLabelAvailableToRepoByName returns a label by name that is available to
+// given repository, a label of the repository takes precedence over the one of
+// its organization.
+func GetLabelAvailableToRepoByName(repo *Repository, labelName string) (*Label, error) {
+	return getLabelAvailableToRepoByName(x, repo, labelName)
+}
+
+// GetLabelsAvailableToRepo returns all labels of given repository and of its
+// organization.
+func GetLabelsAvailableToRepo(repo *Repository) ([]*Label, error) {
+	labels := make([]*Label, 0, 10)
+	return labels, x.Where("repo_id = ? OR (org_id > 0 AND org_id = ?)", repo.ID, repo.OwnerID).Asc("name").Find(&labels)
+}
+
+// GetLabelsAvailableToRepoByIDs returns a list of labels by IDs that are
+// available to given repository, it silently ignores other label IDs.
+func GetLabelsAvailableToRepoByIDs(repo *Repository, labelIDs []int64) ([]*Label, error) {
+	labels := make([]*Label, 0, len(labelIDs))
+	return labels, x.Where("repo_id = ? OR (org_id > 0 AND org_id = ?)", repo.ID, repo.OwnerID).
+		In("id", tool.Int64sToStrings(labelIDs)).Asc("name").Find(&labels)
+}
+
 // GetLabelsInRepoByIDs returns a list of labels by IDs in given repository,
 // it silently ignores label IDs that are not belong to the repository.
 func GetLabelsInRepoByIDs(repoID int64, labelIDs []int64) ([]*Label, error) {
@@ -235,6 +310,10 @@ func DeleteLabel(repoID, labelID int64) error {
 		return err
 	}
 
+	return deleteLabel(labelID)
+}
+
+func deleteLabel(labelID int64) (err error) {
 	sess := x.NewSession()
 	defer sess.Close()
 	if err = sess.Begin(); err != nil {
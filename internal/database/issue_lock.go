@@ -0,0 +1,83 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"fmt"
+)
+
+// LockReasons is the list of reasons that a conversation can be locked for,
+// a conversation can also be locked without any reason.
+var LockReasons = []string{"off-topic", "too heated", "resolved", "spam"}
+
+// IsValidLockReason returns true if given reason is empty or one of the
+// LockReasons.
+func IsValidLockReason(reason string) bool {
+	if reason == "" {
+		return true
+	}
+	for _, r := range LockReasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
+type ErrInvalidLockReason struct {
+	args map[string]any
+}
+
+func IsErrInvalidLockReason(err error) bool {
+	_, ok := err.(ErrInvalidLockReason)
+	return ok
+}
+
+func (err ErrInvalidLockReason) Error() string {
+	return fmt.Sprintf("invalid lock reason: %v", err.args)
+}
+
+// ChangeLock locks the conversation of the issue with given reason, or
+// unlocks it. Only writers of the repository are allowed to comment on an
+// issue while it is locked.
+func (issue *Issue) ChangeLock(doer *User, locked bool, reason string) (err error) {
+	if !locked {
+		reason = ""
+	} else if !IsValidLockReason(reason) {
+		return ErrInvalidLockReason{args: map[string]any{"reason": reason}}
+	}
+	if issue.IsLocked == locked && issue.LockReason == reason {
+		return nil
+	}
+
+	sess := x.NewSession()
+	defer sess.Close()
+	if err = sess.Begin(); err != nil {
+		return err
+	}
+
+	wasLocked := issue.IsLocked
+	issue.IsLocked = locked
+	issue.LockReason = reason
+	if err = updateIssueCols(sess, issue, "is_locked", "lock_reason"); err != nil {
+		return fmt.Errorf("updateIssueCols: %v", err)
+	}
+
+	// Changing only the reason of a locked conversation is not worth an event.
+	if wasLocked != locked {
+		opts := &CreateCommentOptions{
+			Type:     CommentTypeLock,
+			NewValue: reason,
+		}
+		if !locked {
+			opts.Type = CommentTypeUnlock
+		}
+		if err = createIssueEvent(sess, doer, issue, opts); err != nil {
+			return fmt.Errorf("createIssueEvent: %v", err)
+		}
+	}
+
+	return sess.Commit()
+}
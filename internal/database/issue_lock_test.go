@@ -0,0 +1,31 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsValidLockReason(t *testing.T) {
+	tests := []struct {
+		reason string
+		want   bool
+	}{
+		{reason: "", want: true},
+		{reason: "off-topic", want: true},
+		{reason: "too heated", want: true},
+		{reason: "resolved", want: true},
+		{reason: "spam", want: true},
+		{reason: "Spam", want: false},
+		{reason: "unknown", want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.reason, func(t *testing.T) {
+			assert.Equal(t, test.want, IsValidLockReason(test.reason))
+		})
+	}
+}
@@ -7,6 +7,7 @@ package database
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/unknwon/com"
@@ -91,13 +92,17 @@ func (mi mailerIssue) HTMLURL() string {
 	return mi.issue.HTMLURL()
 }
 
+func (mi mailerIssue) Deadline() time.Time {
+	return mi.issue.Deadline
+}
+
 func NewMailerIssue(issue *Issue) email.Issue {
 	return mailerIssue{issue}
 }
 
 // mailIssueCommentToParticipants can be used for both new issue creation and comment.
 // This functions sends two list of emails:
-// 1. Repository watchers, users who participated in comments and the assignee.
+// 1. Repository watchers, users who participated in comments, assignees and requested reviewers.
 // 2. Users who are not in 1. but get mentioned in current issue/comment.
 func mailIssueCommentToParticipants(issue *Issue, doer *User, mentions []string) error {
 	ctx := context.TODO()
@@ -149,11 +154,16 @@ func mailIssueCommentToParticipants(issue *Issue, doer *User, mentions []string)
 		tos = append(tos, participants[i].Email)
 		names = append(names, participants[i].Name)
 	}
-	if issue.Assignee != nil && issue.Assignee.ID != doer.ID {
-		if !com.IsSliceContainsStr(names, issue.Assignee.Name) {
-			tos = append(tos, issue.Assignee.Email)
-			names = append(names, issue.Assignee.Name)
+	assignees := make([]*User, 0, len(issue.Assignees)+len(issue.RequestedReviewers))
+	assignees = append(assignees, issue.Assignees...)
+	assignees = append(assignees, issue.RequestedReviewers...)
+	for _, assignee := range assignees {
+		if assignee.ID == doer.ID || com.IsSliceContainsStr(names, assignee.Name) {
+			continue
 		}
+
+		tos = append(tos, assignee.Email)
+		names = append(names, assignee.Name)
 	}
 	email.SendIssueCommentMail(NewMailerIssue(issue), NewMailerRepo(issue.Repo), NewMailerUser(doer), tos)
 
@@ -0,0 +1,324 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"xorm.io/xorm"
+)
+
+// issueQuerySorts maps values of the "sort" qualifier to sort types of
+// IssuesOptions.
+var issueQuerySorts = []struct {
+	qualifier string
+	sortType  string
+}{
+	{"created", "latest"},
+	{"created-asc", "oldest"},
+	{"updated", "recentupdate"},
+	{"updated-asc", "leastupdate"},
+	{"comments", "mostcomment"},
+	{"comments-asc", "leastcomment"},
+	{"due", "nearduedate"},
+	{"due-desc", "farduedate"},
+	{"priority", "priority"},
+	{"relevance", "relevance"},
+}
+
+// IssueQuery is a parsed issue search query, which consists of qualifiers and
+// free text, e.g. `is:open label:bug author:alice assignee:@me milestone:"v2"
+// sort:updated crash`.
+type IssueQuery struct {
+	State     string // Either "open", "closed" or empty.
+	Labels    []string
+	Author    string
+	Assignee  string
+	Mentions  string
+	Milestone string
+	// Sort is the sort type of IssuesOptions, e.g. "recentupdate".
+	Sort        string
+	NoLabel     bool
+	NoMilestone bool
+	NoAssignee  bool
+	Keywords    []string
+}
+
+// splitIssueQuery splits the query by spaces, a quoted part is kept as a
+// whole with quotes removed.
+func splitIssueQuery(s string) []string {
+	var (
+		tokens []string
+		buf    strings.Builder
+		quoted bool
+	)
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case unicode.IsSpace(r) && !quoted:
+			if buf.Len() > 0 {
+				tokens = append(tokens, buf.String())
+				buf.Reset()
+			}
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	if buf.Len() > 0 {
+		tokens = append(tokens, buf.String())
+	}
+	return tokens
+}
+
+// ParseIssueQuery parses the issue search query. Unknown qualifiers and
+// qualifiers with invalid values are treated as free text.
+func ParseIssueQuery(s string) *IssueQuery {
+	q := new(IssueQuery)
+	for _, token := range splitIssueQuery(s) {
+		key, value, ok := strings.Cut(token, ":")
+		if !ok || value == "" || !q.apply(strings.ToLower(key), value) {
+			q.Keywords = append(q.Keywords, token)
+		}
+	}
+	return q
+}
+
+// apply sets the qualifier with the value to the query, and returns false if
+// the qualifier is unknown or the value is invalid.
+func (q *IssueQuery) apply(key, value string) bool {
+	switch key {
+	case "is":
+		switch value = strings.ToLower(value); value {
+		case "open", "closed":
+			q.State = value
+			return true
+		}
+	case "label":
+		q.Labels = append(q.Labels, value)
+		return true
+	case "author":
+		q.Author = value
+		return true
+	case "assignee":
+		q.Assignee = value
+		return true
+	case "mentions":
+		q.Mentions = value
+		return true
+	case "milestone":
+		q.Milestone = value
+		return true
+	case "no":
+		switch strings.ToLower(value) {
+		case "label":
+			q.NoLabel = true
+			return true
+		case "milestone":
+			q.NoMilestone = true
+			return true
+		case "assignee":
+			q.NoAssignee = true
+			return true
+		}
+	case "sort":
+		for _, s := range issueQuerySorts {
+			if strings.EqualFold(s.qualifier, value) {
+				q.Sort = s.sortType
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// String returns the query in its text form, which can be parsed back by
+// ParseIssueQuery.
+func (q *IssueQuery) String() string {
+	parts := make([]string, 0, 5)
+	add := func(key, value string) {
+		if strings.ContainsFunc(value, unicode.IsSpace) {
+			value = `"` + value + `"`
+		}
+		if key != "" {
+			value = key + ":" + value
+		}
+		parts = append(parts, value)
+	}
+
+	if q.State != "" {
+		add("is", q.State)
+	}
+	for _, label := range q.Labels {
+		add("label", label)
+	}
+	if q.Author != "" {
+		add("author", q.Author)
+	}
+	if q.Assignee != "" {
+		add("assignee", q.Assignee)
+	}
+	if q.Mentions != "" {
+		add("mentions", q.Mentions)
+	}
+	if q.Milestone != "" {
+		add("milestone", q.Milestone)
+	}
+	if q.NoLabel {
+		add("no", "label")
+	}
+	if q.NoMilestone {
+		add("no", "milestone")
+	}
+	if q.NoAssignee {
+		add("no", "assignee")
+	}
+	for _, s := range issueQuerySorts {
+		if s.sortType == q.Sort {
+			add("sort", s.qualifier)
+			break
+		}
+	}
+	for _, keyword := range q.Keywords {
+		add("", keyword)
+	}
+	return strings.Join(parts, " ")
+}
+
+// IssueFilter narrows down issues by conditions of an IssueQuery that have
+// been resolved against the database. A user ID of -1 matches nothing.
+type IssueFilter struct {
+	PosterID      int64
+	AssigneeID    int64
+	MentionedID   int64
+	LabelNames    []string
+	MilestoneName string
+	NoLabel       bool
+	NoMilestone   bool
+	NoAssignee    bool
+	Keywords      []string
+}
+
+// Filter resolves users of the query, where "@me" stands for the doer, and
+// returns the filter for listing issues.
+func (q *IssueQuery) Filter(ctx context.Context, doer *User) (*IssueFilter, error) {
+	userID := func(name string) (int64, error) {
+		if name == "" {
+			return 0, nil
+		} else if name == "@me" {
+			if doer == nil {
+				return -1, nil
+			}
+			return doer.ID, nil
+		}
+
+		u, err := Handle.Users().GetByUsername(ctx, strings.TrimPrefix(name, "@"))
+		if err != nil {
+			if IsErrUserNotExist(err) {
+				return -1, nil
+			}
+			return 0, errors.Wrapf(err, "get user %q", name)
+		}
+		return u.ID, nil
+	}
+
+	f := &IssueFilter{
+		LabelNames:    q.Labels,
+		MilestoneName: q.Milestone,
+		NoLabel:       q.NoLabel,
+		NoMilestone:   q.NoMilestone,
+		NoAssignee:    q.NoAssignee,
+		Keywords:      q.Keywords,
+	}
+	var err error
+	if f.PosterID, err = userID(q.Author); err != nil {
+		return nil, err
+	} else if f.AssigneeID, err = userID(q.Assignee); err != nil {
+		return nil, err
+	} else if f.MentionedID, err = userID(q.Mentions); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// issueFilterRepoIDs returns the repositories that issues are listed from, or
+// nil if issues are not limited to any repository.
+func issueFilterRepoIDs(repoID int64, repoIDs []int64) []int64 {
+	if repoID > 0 {
+		return []int64{repoID}
+	}
+	return repoIDs
+}
+
+// applyIssueFilter narrows down issues of the repositories of the session by
+// given filter. Keywords are looked up in the issue search index if possible,
+// in which case IDs of matched issues ordered by relevance are returned.
+func applyIssueFilter(sess *xorm.Session, f *IssueFilter, repoIDs []int64) (rankedIDs []int64) {
+	if f == nil {
+		return nil
+	}
+
+	if f.PosterID != 0 {
+		sess.And("issue.poster_id = ?", f.PosterID)
+	}
+	if f.AssigneeID != 0 {
+		sess.And(issueUserFlagCond("is_assigned"), f.AssigneeID, true)
+	}
+	if f.MentionedID != 0 {
+		sess.And(issueUserFlagCond("is_mentioned"), f.MentionedID, true)
+	}
+
+	// Labels and milestones are matched by names so that the same filter works
+	// across repositories.
+	for _, name := range f.LabelNames {
+		sess.And("issue.id IN (SELECT issue_label.issue_id FROM `issue_label` INNER JOIN `label` ON label.id = issue_label.label_id WHERE LOWER(label.name) = ?)", strings.ToLower(name))
+	}
+	if f.MilestoneName != "" {
+		sess.And("issue.milestone_id IN (SELECT id FROM `milestone` WHERE LOWER(name) = ?)", strings.ToLower(f.MilestoneName))
+	}
+
+	if f.NoLabel {
+		sess.And("issue.id NOT IN (SELECT issue_id FROM `issue_label`)")
+	}
+	if f.NoMilestone {
+		sess.And("issue.milestone_id = 0")
+	}
+	if f.NoAssignee {
+		sess.And("issue.id NOT IN (SELECT issue_id FROM `issue_user` WHERE is_assigned = ?)", true)
+	}
+
+	if len(f.Keywords) == 0 {
+		return nil
+	}
+	if len(repoIDs) > 0 {
+		issueIDs, ok := searchIssueIndex(repoIDs, f.Keywords)
+		if ok {
+			if len(issueIDs) == 0 {
+				sess.And("1 = 0")
+			} else {
+				sess.In("issue.id", issueIDs)
+			}
+			return issueIDs
+		}
+	}
+	for _, keyword := range f.Keywords {
+		keyword = "%" + escapeLikePattern(strings.ToLower(keyword)) + "%"
+		sess.And("(LOWER(issue.name) LIKE ? ESCAPE '!' OR LOWER(issue.content) LIKE ? ESCAPE '!')", keyword, keyword)
+	}
+	return nil
+}
+
+// likePatternEscaper escapes wildcards of LIKE patterns with "!", which unlike
+// backslash is not special in string literals of any supported database.
+var likePatternEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// escapeLikePattern returns s with wildcards escaped so that it matches
+// literally in a LIKE clause with "ESCAPE '!'".
+func escapeLikePattern(s string) string {
+	return likePatternEscaper.Replace(s)
+}
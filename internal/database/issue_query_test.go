@@ -0,0 +1,108 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIssueQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		want  *IssueQuery
+	}{
+		{
+			name:  "empty",
+			query: "  ",
+			want:  &IssueQuery{},
+		},
+		{
+			name:  "qualifiers",
+			query: `is:Closed label:bug label:"good first issue" author:alice assignee:@me mentions:bob milestone:"v2.0 beta" sort:updated-asc`,
+			want: &IssueQuery{
+				State:     "closed",
+				Labels:    []string{"bug", "good first issue"},
+				Author:    "alice",
+				Assignee:  "@me",
+				Mentions:  "bob",
+				Milestone: "v2.0 beta",
+				Sort:      "leastupdate",
+			},
+		},
+		{
+			name:  "no qualifiers",
+			query: "no:label no:Milestone no:assignee",
+			want: &IssueQuery{
+				NoLabel:     true,
+				NoMilestone: true,
+				NoAssignee:  true,
+			},
+		},
+		{
+			name:  "free text",
+			query: `crash "out of memory" is:unknown sort:random foo:bar label:`,
+			want: &IssueQuery{
+				Keywords: []string{"crash", "out of memory", "is:unknown", "sort:random", "foo:bar", "label:"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, ParseIssueQuery(test.query))
+		})
+	}
+}
+
+func TestIssueQuery_String(t *testing.T) {
+	q := &IssueQuery{
+		State:     "open",
+		Labels:    []string{"bug", "good first issue"},
+		Assignee:  "@me",
+		Milestone: "v2.0 beta",
+		NoLabel:   true,
+		Sort:      "mostcomment",
+		Keywords:  []string{"out of memory"},
+	}
+	want := `is:open label:bug label:"good first issue" assignee:@me milestone:"v2.0 beta" no:label sort:comments "out of memory"`
+	assert.Equal(t, want, q.String())
+	assert.Equal(t, q, ParseIssueQuery(q.String()))
+}
+
+func TestEscapeLikePattern(t *testing.T) {
+	assert.Equal(t, "100!% done", escapeLikePattern("100% done"))
+	assert.Equal(t, "snake!_case", escapeLikePattern("snake_case"))
+	assert.Equal(t, "wow!!", escapeLikePattern("wow!"))
+}
+
+func TestIssues_KeywordWildcards(t *testing.T) {
+	setupLegacyTestDB(t)
+
+	pr := newTestPullRequest(t)
+	repo := pr.Issue.Repo
+	doer := pr.Issue.Poster
+	percent := newTestIssue(t, repo, doer, "Coverage at 100% now")
+	newTestIssue(t, repo, doer, "Coverage at 1000 lines")
+	underscore := newTestIssue(t, repo, doer, "Rename snake_case fields")
+	newTestIssue(t, repo, doer, "Rename snakeXcase fields")
+
+	search := func(keyword string) []int64 {
+		issues, err := Issues(&IssuesOptions{
+			RepoID: repo.ID,
+			Filter: &IssueFilter{Keywords: []string{keyword}},
+		})
+		require.NoError(t, err)
+		ids := make([]int64, len(issues))
+		for i := range issues {
+			ids[i] = issues[i].ID
+		}
+		return ids
+	}
+	assert.Equal(t, []int64{percent.ID}, search("100%"))
+	assert.Equal(t, []int64{underscore.ID}, search("snake_case"))
+}
@@ -0,0 +1,243 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/gogs/git-module"
+	"gopkg.in/yaml.v3"
+	log "unknwon.dev/clog/v2"
+)
+
+var (
+	// IssueTemplateCandidates are possible paths of the single issue template.
+	IssueTemplateCandidates = []string{
+		"ISSUE_TEMPLATE.md",
+		".gogs/ISSUE_TEMPLATE.md",
+		".github/ISSUE_TEMPLATE.md",
+	}
+	// IssueTemplateDirCandidates are possible paths of the directory that
+	// contains multiple issue templates. It takes precedence over the single
+	// issue template.
+	IssueTemplateDirCandidates = []string{
+		"ISSUE_TEMPLATE",
+		".gogs/ISSUE_TEMPLATE",
+		".github/ISSUE_TEMPLATE",
+	}
+	// PullRequestTemplateCandidates are possible paths of the pull request template.
+	PullRequestTemplateCandidates = []string{
+		"PULL_REQUEST_TEMPLATE.md",
+		".gogs/PULL_REQUEST_TEMPLATE.md",
+		".github/PULL_REQUEST_TEMPLATE.md",
+		"PULL_REQUEST.md",
+		".gogs/PULL_REQUEST.md",
+		".github/PULL_REQUEST.md",
+	}
+	// PullRequestTitleTemplateCandidates are possible paths of the pull request
+	// title template.
+	PullRequestTitleTemplateCandidates = []string{
+		"PULL_REQUEST_TITLE.md",
+		".gogs/PULL_REQUEST_TITLE.md",
+		".github/PULL_REQUEST_TITLE.md",
+	}
+)
+
+// IssueTemplate is a template in the repository to pre-fill new issues.
+type IssueTemplate struct {
+	// FileName is the name of the template file, it identifies the template
+	// within the templates directory.
+	FileName string
+	Name     string
+	About    string
+	Title    string
+	Labels   []string
+	Content  string
+}
+
+// issueTemplateFrontMatter is the YAML front-matter of an issue template. The
+// labels can be either a list or a comma-separated string.
+type issueTemplateFrontMatter struct {
+	Name   string `yaml:"name"`
+	About  string `yaml:"about"`
+	Title  string `yaml:"title"`
+	Labels any    `yaml:"labels"`
+}
+
+// ParseIssueTemplate parses the content of an issue template file with
+// optional YAML front-matter that defines name, about, title and labels.
+func ParseIssueTemplate(fileName string, data []byte) (*IssueTemplate, error) {
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+	tmpl := &IssueTemplate{
+		FileName: fileName,
+		Name:     strings.TrimSuffix(fileName, path.Ext(fileName)),
+		Content:  string(data),
+	}
+
+	if !bytes.HasPrefix(data, []byte("---\n")) {
+		return tmpl, nil
+	}
+	rest := data[4:]
+	end := bytes.Index(rest, []byte("\n---"))
+	if end < 0 {
+		return tmpl, nil
+	}
+
+	var meta issueTemplateFrontMatter
+	if err := yaml.Unmarshal(rest[:end], &meta); err != nil {
+		return nil, fmt.Errorf("unmarshal front-matter: %v", err)
+	}
+
+	body := rest[end+4:]
+	if i := bytes.IndexByte(body, '\n'); i >= 0 {
+		body = body[i+1:]
+	} else {
+		body = nil
+	}
+	tmpl.Content = string(body)
+
+	if meta.Name != "" {
+		tmpl.Name = meta.Name
+	}
+	tmpl.About = meta.About
+	tmpl.Title = meta.Title
+	switch labels := meta.Labels.(type) {
+	case string:
+		for _, label := range strings.Split(labels, ",") {
+			if label = strings.TrimSpace(label); label != "" {
+				tmpl.Labels = append(tmpl.Labels, label)
+			}
+		}
+	case []any:
+		for _, label := range labels {
+			if s := strings.TrimSpace(fmt.Sprint(label)); s != "" {
+				tmpl.Labels = append(tmpl.Labels, s)
+			}
+		}
+	}
+	return tmpl, nil
+}
+
+// defaultBranchCommit returns the latest commit of the default branch, it
+// returns nil when the repository is empty.
+func (repo *Repository) defaultBranchCommit() (*git.Commit, error) {
+	if repo.IsBare {
+		return nil, nil
+	}
+
+	gitRepo, err := git.Open(repo.RepoPath())
+	if err != nil {
+		return nil, fmt.Errorf("open repository: %v", err)
+	}
+	commit, err := gitRepo.BranchCommit(repo.DefaultBranch)
+	if err != nil {
+		return nil, nil
+	}
+	return commit, nil
+}
+
+// firstBlobContent returns the content of the first existing file among given
+// paths in the commit.
+func firstBlobContent(commit *git.Commit, paths []string) (string, bool) {
+	for _, p := range paths {
+		entry, err := commit.TreeEntry(p)
+		if err != nil || !entry.IsBlob() {
+			continue
+		}
+		data, err := entry.Blob().Bytes()
+		if err != nil {
+			continue
+		}
+		return string(data), true
+	}
+	return "", false
+}
+
+// GetIssueTemplates returns issue templates in the default branch of the
+// repository. Templates in the templates directory are returned if it exists,
+// otherwise the single issue template.
+func (repo *Repository) GetIssueTemplates() ([]*IssueTemplate, error) {
+	commit, err := repo.defaultBranchCommit()
+	if err != nil {
+		return nil, err
+	} else if commit == nil {
+		return []*IssueTemplate{}, nil
+	}
+
+	tmpls := make([]*IssueTemplate, 0, 3)
+	for _, dir := range IssueTemplateDirCandidates {
+		tree, err := commit.Subtree(dir)
+		if err != nil {
+			continue
+		}
+		entries, err := tree.Entries()
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			if !entry.IsBlob() || strings.ToLower(path.Ext(entry.Name())) != ".md" {
+				continue
+			}
+			data, err := entry.Blob().Bytes()
+			if err != nil {
+				return nil, fmt.Errorf("read %q: %v", path.Join(dir, entry.Name()), err)
+			}
+			tmpl, err := ParseIssueTemplate(entry.Name(), data)
+			if err != nil {
+				// A malformed template should not prevent using other templates.
+				log.Warn("Skipped malformed issue template %q [repo_id: %d]: %v", path.Join(dir, entry.Name()), repo.ID, err)
+				continue
+			}
+			tmpls = append(tmpls, tmpl)
+		}
+		if len(tmpls) > 0 {
+			sort.Slice(tmpls, func(i, j int) bool {
+				return tmpls[i].FileName < tmpls[j].FileName
+			})
+			return tmpls, nil
+		}
+	}
+
+	for _, p := range IssueTemplateCandidates {
+		content, found := firstBlobContent(commit, []string{p})
+		if !found {
+			continue
+		}
+		tmpl, err := ParseIssueTemplate(path.Base(p), []byte(content))
+		if err != nil {
+			log.Warn("Skipped malformed issue template %q [repo_id: %d]: %v", p, repo.ID, err)
+			continue
+		}
+		return append(tmpls, tmpl), nil
+	}
+	return tmpls, nil
+}
+
+// GetPullRequestTemplate returns the content of the pull request template in
+// the default branch of the repository, and whether it exists.
+func (repo *Repository) GetPullRequestTemplate() (string, bool, error) {
+	commit, err := repo.defaultBranchCommit()
+	if err != nil || commit == nil {
+		return "", false, err
+	}
+	content, found := firstBlobContent(commit, PullRequestTemplateCandidates)
+	return content, found, nil
+}
+
+// GetPullRequestTitleTemplate returns the content of the pull request title
+// template in the default branch of the repository, and whether it exists.
+func (repo *Repository) GetPullRequestTitleTemplate() (string, bool, error) {
+	commit, err := repo.defaultBranchCommit()
+	if err != nil || commit == nil {
+		return "", false, err
+	}
+	content, found := firstBlobContent(commit, PullRequestTitleTemplateCandidates)
+	return content, found, nil
+}
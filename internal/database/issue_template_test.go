@@ -0,0 +1,82 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIssueTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		data     string
+		want     *IssueTemplate
+	}{
+		{
+			name:     "no front-matter",
+			fileName: "ISSUE_TEMPLATE.md",
+			data:     "Describe the issue\n",
+			want: &IssueTemplate{
+				FileName: "ISSUE_TEMPLATE.md",
+				Name:     "ISSUE_TEMPLATE",
+				Content:  "Describe the issue\n",
+			},
+		},
+		{
+			name:     "labels as list",
+			fileName: "bug.md",
+			data: `---
+name: Bug report
+about: Report a bug
+title: "[BUG] "
+labels:
+  - bug
+  - needs triage
+---
+Steps to reproduce
+`,
+			want: &IssueTemplate{
+				FileName: "bug.md",
+				Name:     "Bug report",
+				About:    "Report a bug",
+				Title:    "[BUG] ",
+				Labels:   []string{"bug", "needs triage"},
+				Content:  "Steps to reproduce\n",
+			},
+		},
+		{
+			name:     "labels as string with CRLF",
+			fileName: "feature.md",
+			data:     "---\r\nname: Feature\r\nlabels: enhancement, help wanted\r\n---\r\nDescribe the feature\r\n",
+			want: &IssueTemplate{
+				FileName: "feature.md",
+				Name:     "Feature",
+				Labels:   []string{"enhancement", "help wanted"},
+				Content:  "Describe the feature\n",
+			},
+		},
+		{
+			name:     "unclosed front-matter",
+			fileName: "broken.md",
+			data:     "---\nname: Broken\n",
+			want: &IssueTemplate{
+				FileName: "broken.md",
+				Name:     "broken",
+				Content:  "---\nname: Broken\n",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := ParseIssueTemplate(test.fileName, []byte(test.data))
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
@@ -0,0 +1,385 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"xorm.io/xorm"
+
+	"gogs.io/gogs/internal/errutil"
+)
+
+// TrackedTime represents time in seconds that a user has spent on an issue.
+type TrackedTime struct {
+	ID      int64
+	IssueID int64  `xorm:"INDEX NOT NULL"`
+	Issue   *Issue `xorm:"-" json:"-" gorm:"-"`
+	UserID  int64  `xorm:"INDEX NOT NULL"`
+	Time    int64  `xorm:"NOT NULL"`
+
+	Created     time.Time `xorm:"-" json:"-" gorm:"-"`
+	CreatedUnix int64     `xorm:"INDEX"`
+}
+
+func (t *TrackedTime) BeforeInsert() {
+	if t.Created.IsZero() {
+		t.Created = time.Now()
+	}
+	t.CreatedUnix = t.Created.Unix()
+}
+
+func (t *TrackedTime) AfterSet(colName string, _ xorm.Cell) {
+	switch colName {
+	case "created_unix":
+		t.Created = time.Unix(t.CreatedUnix, 0).Local()
+	}
+}
+
+// Duration returns the tracked time as a duration.
+func (t *TrackedTime) Duration() time.Duration {
+	return time.Duration(t.Time) * time.Second
+}
+
+// Stopwatch represents a running timer of a user on an issue.
+type Stopwatch struct {
+	ID      int64
+	IssueID int64  `xorm:"UNIQUE(stopwatch) NOT NULL"`
+	Issue   *Issue `xorm:"-" json:"-" gorm:"-"`
+	UserID  int64  `xorm:"UNIQUE(stopwatch) INDEX NOT NULL"`
+
+	Created     time.Time `xorm:"-" json:"-" gorm:"-"`
+	CreatedUnix int64
+}
+
+func (s *Stopwatch) BeforeInsert() {
+	s.CreatedUnix = time.Now().Unix()
+}
+
+func (s *Stopwatch) AfterSet(colName string, _ xorm.Cell) {
+	switch colName {
+	case "created_unix":
+		s.Created = time.Unix(s.CreatedUnix, 0).Local()
+	}
+}
+
+// Seconds returns the number of seconds elapsed since the stopwatch started.
+func (s *Stopwatch) Seconds() int64 {
+	return time.Now().Unix() - s.CreatedUnix
+}
+
+type ErrInvalidTrackedTime struct {
+	args map[string]any
+}
+
+func IsErrInvalidTrackedTime(err error) bool {
+	_, ok := err.(ErrInvalidTrackedTime)
+	return ok
+}
+
+func (err ErrInvalidTrackedTime) Error() string {
+	return fmt.Sprintf("invalid tracked time: %v", err.args)
+}
+
+var _ errutil.NotFound = (*ErrTrackedTimeNotExist)(nil)
+
+type ErrTrackedTimeNotExist struct {
+	args map[string]any
+}
+
+func IsErrTrackedTimeNotExist(err error) bool {
+	_, ok := err.(ErrTrackedTimeNotExist)
+	return ok
+}
+
+func (err ErrTrackedTimeNotExist) Error() string {
+	return fmt.Sprintf("tracked time does not exist: %v", err.args)
+}
+
+func (ErrTrackedTimeNotExist) NotFound() bool {
+	return true
+}
+
+var _ errutil.NotFound = (*ErrStopwatchNotExist)(nil)
+
+type ErrStopwatchNotExist struct {
+	args map[string]any
+}
+
+func IsErrStopwatchNotExist(err error) bool {
+	_, ok := err.(ErrStopwatchNotExist)
+	return ok
+}
+
+func (err ErrStopwatchNotExist) Error() string {
+	return fmt.Sprintf("stopwatch does not exist: %v", err.args)
+}
+
+func (ErrStopwatchNotExist) NotFound() bool {
+	return true
+}
+
+// FormatTrackedTime returns human readable form of given seconds, e.g. "1h 5m".
+func FormatTrackedTime(seconds int64) string {
+	if seconds < 60 {
+		return fmt.Sprintf("%ds", seconds)
+	}
+
+	hours := seconds / 3600
+	minutes := seconds % 3600 / 60
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dh %dm", hours, minutes)
+}
+
+// AddTrackedTime records time spent by the user on the issue. The entry is
+// dated at given time, or now if the time is zero.
+func AddTrackedTime(doer *User, issue *Issue, d time.Duration, created time.Time) (*TrackedTime, error) {
+	seconds := int64(d / time.Second)
+	if seconds <= 0 {
+		return nil, ErrInvalidTrackedTime{args: map[string]any{"seconds": seconds}}
+	}
+
+	t := &TrackedTime{
+		IssueID: issue.ID,
+		Issue:   issue,
+		UserID:  doer.ID,
+		Time:    seconds,
+		Created: created,
+	}
+	if _, err := x.Insert(t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// GetTrackedTimeByID returns the tracked time entry with given ID.
+func GetTrackedTimeByID(id int64) (*TrackedTime, error) {
+	t := new(TrackedTime)
+	has, err := x.ID(id).Get(t)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrTrackedTimeNotExist{args: map[string]any{"id": id}}
+	}
+	return t, nil
+}
+
+// DeleteTrackedTime deletes the tracked time entry with given ID.
+func DeleteTrackedTime(id int64) error {
+	_, err := x.ID(id).Delete(new(TrackedTime))
+	return err
+}
+
+// TrackedTimeOptions contains options to find tracked time entries, zero
+// values are ignored.
+type TrackedTimeOptions struct {
+	UserID      int64
+	IssueID     int64
+	RepoID      int64
+	MilestoneID int64
+	Since       time.Time
+	Before      time.Time
+}
+
+func buildTrackedTimesQuery(opts TrackedTimeOptions) *xorm.Session {
+	sess := x.Where("tracked_time.time > 0")
+	if opts.UserID > 0 {
+		sess.And("tracked_time.user_id = ?", opts.UserID)
+	}
+	if opts.IssueID > 0 {
+		sess.And("tracked_time.issue_id = ?", opts.IssueID)
+	}
+	if opts.RepoID > 0 || opts.MilestoneID > 0 {
+		sess.Join("INNER", "issue", "issue.id = tracked_time.issue_id")
+		if opts.RepoID > 0 {
+			sess.And("issue.repo_id = ?", opts.RepoID)
+		}
+		if opts.MilestoneID > 0 {
+			sess.And("issue.milestone_id = ?", opts.MilestoneID)
+		}
+	}
+	if !opts.Since.IsZero() {
+		sess.And("tracked_time.created_unix >= ?", opts.Since.Unix())
+	}
+	if !opts.Before.IsZero() {
+		sess.And("tracked_time.created_unix < ?", opts.Before.Unix())
+	}
+	return sess
+}
+
+// GetTrackedTimes returns tracked time entries that match given options, in
+// order of their dates, with their issues loaded.
+func GetTrackedTimes(opts TrackedTimeOptions) ([]*TrackedTime, error) {
+	times := make([]*TrackedTime, 0, 10)
+	if err := buildTrackedTimesQuery(opts).Asc("tracked_time.created_unix").Find(&times); err != nil {
+		return nil, err
+	}
+
+	issues := make(map[int64]*Issue)
+	for _, t := range times {
+		issue, ok := issues[t.IssueID]
+		if !ok {
+			var err error
+			issue, err = getIssueByID(x, t.IssueID)
+			if err != nil {
+				return nil, fmt.Errorf("getIssueByID [%d]: %v", t.IssueID, err)
+			}
+			issues[t.IssueID] = issue
+		}
+		t.Issue = issue
+	}
+	return times, nil
+}
+
+// TotalTrackedTime returns total seconds of tracked time entries that match
+// given options.
+func TotalTrackedTime(opts TrackedTimeOptions) (int64, error) {
+	return buildTrackedTimesQuery(opts).SumInt(new(TrackedTime), "tracked_time.time")
+}
+
+// TrackedTimeTotal is the total time spent by a user.
+type TrackedTimeTotal struct {
+	UserID int64
+	User   *User
+	Time   int64
+}
+
+// GetIssueTrackedTimeTotals returns total time spent on the issue by each user.
+func GetIssueTrackedTimeTotals(issueID int64) ([]*TrackedTimeTotal, error) {
+	times := make([]*TrackedTime, 0, 10)
+	if err := x.Where("issue_id = ?", issueID).Asc("id").Find(&times); err != nil {
+		return nil, err
+	}
+
+	totals := make([]*TrackedTimeTotal, 0, 2)
+	byUser := make(map[int64]*TrackedTimeTotal)
+	for _, t := range times {
+		total, ok := byUser[t.UserID]
+		if !ok {
+			u, err := getUserByID(x, t.UserID)
+			if err != nil {
+				if IsErrUserNotExist(err) {
+					continue
+				}
+				return nil, fmt.Errorf("getUserByID [%d]: %v", t.UserID, err)
+			}
+			total = &TrackedTimeTotal{UserID: t.UserID, User: u}
+			byUser[t.UserID] = total
+			totals = append(totals, total)
+		}
+		total.Time += t.Time
+	}
+	return totals, nil
+}
+
+// GetStopwatch returns the running stopwatch of the user on the issue.
+func GetStopwatch(userID, issueID int64) (*Stopwatch, error) {
+	s := new(Stopwatch)
+	has, err := x.Where("user_id = ? AND issue_id = ?", userID, issueID).Get(s)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrStopwatchNotExist{args: map[string]any{"userID": userID, "issueID": issueID}}
+	}
+	return s, nil
+}
+
+// GetUserStopwatches returns all running stopwatches of the user with their
+// issues loaded.
+func GetUserStopwatches(userID int64) ([]*Stopwatch, error) {
+	stopwatches := make([]*Stopwatch, 0, 2)
+	if err := x.Where("user_id = ?", userID).Asc("id").Find(&stopwatches); err != nil {
+		return nil, err
+	}
+
+	for _, s := range stopwatches {
+		issue, err := getIssueByID(x, s.IssueID)
+		if err != nil {
+			return nil, fmt.Errorf("getIssueByID [%d]: %v", s.IssueID, err)
+		}
+		s.Issue = issue
+	}
+	return stopwatches, nil
+}
+
+// StartStopwatch starts a stopwatch of the user on the issue. It does nothing
+// if the stopwatch is already running.
+func StartStopwatch(doer *User, issue *Issue) error {
+	_, err := GetStopwatch(doer.ID, issue.ID)
+	if err == nil {
+		return nil
+	} else if !IsErrStopwatchNotExist(err) {
+		return err
+	}
+
+	_, err = x.Insert(&Stopwatch{
+		IssueID: issue.ID,
+		UserID:  doer.ID,
+	})
+	return err
+}
+
+// StopStopwatch stops the running stopwatch of the user on the issue and
+// records the elapsed time. It returns nil tracked time if less than one
+// second has elapsed.
+func StopStopwatch(doer *User, issue *Issue) (*TrackedTime, error) {
+	s, err := GetStopwatch(doer.ID, issue.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sess := x.NewSession()
+	defer sess.Close()
+	if err = sess.Begin(); err != nil {
+		return nil, err
+	}
+
+	// Only the request that actually removed the stopwatch records the time,
+	// so concurrent stops do not track the same interval twice.
+	affected, err := sess.ID(s.ID).Delete(new(Stopwatch))
+	if err != nil {
+		return nil, fmt.Errorf("delete stopwatch: %v", err)
+	} else if affected != 1 {
+		return nil, ErrStopwatchNotExist{args: map[string]any{"userID": doer.ID, "issueID": issue.ID}}
+	}
+
+	var t *TrackedTime
+	if seconds := s.Seconds(); seconds > 0 {
+		t = &TrackedTime{
+			IssueID: issue.ID,
+			Issue:   issue,
+			UserID:  doer.ID,
+			Time:    seconds,
+		}
+		if _, err = sess.Insert(t); err != nil {
+			return nil, fmt.Errorf("insert tracked time: %v", err)
+		}
+	}
+
+	return t, sess.Commit()
+}
+
+// CancelStopwatch stops the running stopwatch of the user on the issue
+// without recording the elapsed time.
+func CancelStopwatch(doer *User, issue *Issue) error {
+	_, err := x.Where("user_id = ? AND issue_id = ?", doer.ID, issue.ID).Delete(new(Stopwatch))
+	return err
+}
+
+// deleteIssueTrackedTimes deletes all tracked time entries and stopwatches of
+// the issue.
+func deleteIssueTrackedTimes(e Engine, issueID int64) error {
+	if _, err := e.Where("issue_id = ?", issueID).Delete(new(TrackedTime)); err != nil {
+		return err
+	}
+	_, err := e.Where("issue_id = ?", issueID).Delete(new(Stopwatch))
+	return err
+}
@@ -0,0 +1,113 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatTrackedTime(t *testing.T) {
+	tests := []struct {
+		seconds int64
+		want    string
+	}{
+		{seconds: 0, want: "0s"},
+		{seconds: 59, want: "59s"},
+		{seconds: 60, want: "1m"},
+		{seconds: 3599, want: "59m"},
+		{seconds: 3600, want: "1h"},
+		{seconds: 5400, want: "1h 30m"},
+		{seconds: 90061, want: "25h 1m"},
+	}
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			assert.Equal(t, test.want, FormatTrackedTime(test.seconds))
+		})
+	}
+}
+
+func TestTrackedTimes(t *testing.T) {
+	setupLegacyTestDB(t)
+
+	pr := newTestPullRequest(t)
+	issue := pr.Issue
+	doer := issue.Poster
+	other := newTestUser(t, "bob", issue.Repo)
+
+	_, err := AddTrackedTime(doer, issue, 500*time.Millisecond, time.Time{})
+	assert.True(t, IsErrInvalidTrackedTime(err))
+
+	day := time.Date(2026, 3, 15, 12, 0, 0, 0, time.Local)
+	first, err := AddTrackedTime(doer, issue, time.Hour, day)
+	require.NoError(t, err)
+	_, err = AddTrackedTime(doer, issue, 30*time.Minute, day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	_, err = AddTrackedTime(other, issue, time.Minute, day)
+	require.NoError(t, err)
+
+	times, err := GetTrackedTimes(TrackedTimeOptions{UserID: doer.ID})
+	require.NoError(t, err)
+	require.Len(t, times, 2)
+	assert.Equal(t, first.ID, times[0].ID)
+	assert.Equal(t, issue.ID, times[0].Issue.ID)
+
+	times, err = GetTrackedTimes(TrackedTimeOptions{
+		UserID: doer.ID,
+		Since:  day.AddDate(0, 0, 1),
+	})
+	require.NoError(t, err)
+	require.Len(t, times, 1)
+	assert.Equal(t, int64(1800), times[0].Time)
+
+	total, err := TotalTrackedTime(TrackedTimeOptions{RepoID: issue.RepoID})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3600+1800+60), total)
+
+	require.NoError(t, DeleteTrackedTime(first.ID))
+	_, err = GetTrackedTimeByID(first.ID)
+	assert.True(t, IsErrTrackedTimeNotExist(err))
+}
+
+func TestStopwatch(t *testing.T) {
+	setupLegacyTestDB(t)
+
+	pr := newTestPullRequest(t)
+	issue := pr.Issue
+	doer := issue.Poster
+
+	_, err := StopStopwatch(doer, issue)
+	assert.True(t, IsErrStopwatchNotExist(err))
+
+	require.NoError(t, StartStopwatch(doer, issue))
+	require.NoError(t, StartStopwatch(doer, issue), "starting twice is a no-op")
+	stopwatches, err := GetUserStopwatches(doer.ID)
+	require.NoError(t, err)
+	require.Len(t, stopwatches, 1)
+
+	// Pretend the stopwatch was started two minutes ago.
+	_, err = x.Exec("UPDATE stopwatch SET created_unix = ? WHERE id = ?", time.Now().Add(-2*time.Minute).Unix(), stopwatches[0].ID)
+	require.NoError(t, err)
+
+	tracked, err := StopStopwatch(doer, issue)
+	require.NoError(t, err)
+	require.NotNil(t, tracked)
+	assert.GreaterOrEqual(t, tracked.Time, int64(120))
+
+	// The stopwatch is gone, so stopping again must not record time twice.
+	_, err = StopStopwatch(doer, issue)
+	assert.True(t, IsErrStopwatchNotExist(err))
+	total, err := TotalTrackedTime(TrackedTimeOptions{IssueID: issue.ID})
+	require.NoError(t, err)
+	assert.Equal(t, tracked.Time, total)
+
+	require.NoError(t, StartStopwatch(doer, issue))
+	require.NoError(t, CancelStopwatch(doer, issue))
+	_, err = GetStopwatch(doer.ID, issue.ID)
+	assert.True(t, IsErrStopwatchNotExist(err))
+}
@@ -0,0 +1,238 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"fmt"
+
+	"xorm.io/xorm"
+)
+
+// IssueRedirect points an index of a repository to the issue that used to
+// have it before being transferred to another repository.
+type IssueRedirect struct {
+	ID       int64
+	RepoID   int64 `xorm:"UNIQUE(issue_redirect)"`
+	OldIndex int64 `xorm:"UNIQUE(issue_redirect)"`
+	IssueID  int64 `xorm:"INDEX"`
+}
+
+type ErrInvalidIssueTransfer struct {
+	args map[string]any
+}
+
+func IsErrInvalidIssueTransfer(err error) bool {
+	_, ok := err.(ErrInvalidIssueTransfer)
+	return ok
+}
+
+func (err ErrInvalidIssueTransfer) Error() string {
+	return fmt.Sprintf("invalid issue transfer: %v", err.args)
+}
+
+// nextIssueIndex returns the index for a new issue of the repository. Issues
+// transferred out of the repository leave gaps in the counters, so the index
+// also has to be greater than any existing or redirected index.
+func nextIssueIndex(e Engine, repo *Repository) (int64, error) {
+	index := repo.NextIssueIndex()
+
+	last := new(Issue)
+	has, err := e.Where("repo_id = ?", repo.ID).Desc("index").Get(last)
+	if err != nil {
+		return 0, fmt.Errorf("get last issue: %v", err)
+	} else if has && last.Index >= index {
+		index = last.Index + 1
+	}
+
+	redirect := new(IssueRedirect)
+	has, err = e.Where("repo_id = ?", repo.ID).Desc("old_index").Get(redirect)
+	if err != nil {
+		return 0, fmt.Errorf("get last issue redirect: %v", err)
+	} else if has && redirect.OldIndex >= index {
+		index = redirect.OldIndex + 1
+	}
+	return index, nil
+}
+
+// GetIssueByRedirect returns the issue that had the index in the repository
+// before being transferred to another repository.
+func GetIssueByRedirect(repoID, oldIndex int64) (*Issue, error) {
+	redirect := new(IssueRedirect)
+	has, err := x.Where("repo_id = ? AND old_index = ?", repoID, oldIndex).Get(redirect)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrIssueNotExist{args: map[string]any{"repoID": repoID, "index": oldIndex}}
+	}
+	return GetIssueByID(redirect.IssueID)
+}
+
+func deleteIssueRedirects(e Engine, issueID int64) error {
+	_, err := e.Where("issue_id = ?", issueID).Delete(new(IssueRedirect))
+	return err
+}
+
+// TransferIssue moves the issue to the target repository with a new index.
+// Labels and the milestone are mapped to the ones of the target repository
+// with the same name and dropped otherwise, comments and attachments move
+// along with the issue. The old index keeps redirecting to the issue, and the
+// transfer is recorded in the issue timeline and the feeds of both
+// repositories.
+func TransferIssue(doer *User, issue *Issue, target *Repository) (err error) {
+	if issue.IsPull {
+		return ErrInvalidIssueTransfer{args: map[string]any{"issueID": issue.ID, "reason": "pull request"}}
+	} else if issue.RepoID == target.ID {
+		return ErrInvalidIssueTransfer{args: map[string]any{"issueID": issue.ID, "reason": "same repository"}}
+	}
+
+	sess := x.NewSession()
+	defer sess.Close()
+	if err = sess.Begin(); err != nil {
+		return err
+	}
+
+	sourceRepoID := issue.RepoID
+	if err = transferIssue(sess, doer, issue, target); err != nil {
+		return err
+	}
+	if err = sess.Commit(); err != nil {
+		return err
+	}
+
+	removeIssueIndexer(sourceRepoID, issue.ID)
+	UpdateIssueIndexer(issue.ID)
+	return nil
+}
+
+func transferIssue(e *xorm.Session, doer *User, issue *Issue, target *Repository) (err error) {
+	if err = issue.loadAttributes(e); err != nil {
+		return fmt.Errorf("load attributes: %v", err)
+	}
+	source := issue.Repo
+	if err = source.getOwner(e); err != nil {
+		return fmt.Errorf("get owner of source: %v", err)
+	} else if err = target.getOwner(e); err != nil {
+		return fmt.Errorf("get owner of target: %v", err)
+	}
+
+	// Detach the milestone and labels while the issue still belongs to the
+	// source repository, so their counters stay correct. Labels of the
+	// organization are attached again if the target is in the same
+	// organization.
+	oldMilestone := issue.Milestone
+	if issue.MilestoneID > 0 {
+		oldMilestoneID := issue.MilestoneID
+		issue.MilestoneID = 0
+		if err = changeMilestoneAssign(e, issue, oldMilestoneID); err != nil {
+			return fmt.Errorf("detach milestone: %v", err)
+		}
+	}
+	oldLabels := append([]*Label(nil), issue.Labels...)
+	for _, label := range oldLabels {
+		if err = deleteIssueLabel(e, issue, label); err != nil {
+			return fmt.Errorf("detach label [%d]: %v", label.ID, err)
+		}
+	}
+
+	oldIndex := issue.Index
+	issue.Index, err = nextIssueIndex(e, target)
+	if err != nil {
+		return err
+	}
+	issue.RepoID = target.ID
+	issue.Repo = target
+	if err = updateIssue(e, issue); err != nil {
+		return fmt.Errorf("update issue: %v", err)
+	}
+
+	if _, err = e.Exec("UPDATE `issue_user` SET repo_id = ? WHERE issue_id = ?", target.ID, issue.ID); err != nil {
+		return fmt.Errorf("update issue users: %v", err)
+	}
+	if _, err = e.Exec("UPDATE `repository` SET num_issues = num_issues - 1 WHERE id = ?", source.ID); err != nil {
+		return err
+	} else if _, err = e.Exec("UPDATE `repository` SET num_issues = num_issues + 1 WHERE id = ?", target.ID); err != nil {
+		return err
+	}
+	if issue.IsClosed {
+		if _, err = e.Exec("UPDATE `repository` SET num_closed_issues = num_closed_issues - 1 WHERE id = ?", source.ID); err != nil {
+			return err
+		} else if _, err = e.Exec("UPDATE `repository` SET num_closed_issues = num_closed_issues + 1 WHERE id = ?", target.ID); err != nil {
+			return err
+		}
+	}
+
+	for _, oldLabel := range oldLabels {
+		label, err := getLabelAvailableToRepoByName(e, target, oldLabel.Name)
+		if err != nil {
+			if IsErrLabelNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("get label of target by name: %v", err)
+		}
+
+		if err = newIssueLabel(e, issue, label); err != nil {
+			return fmt.Errorf("attach label [%d]: %v", label.ID, err)
+		}
+		// Keep label events of the timeline pointing to the label the issue
+		// has now.
+		if _, err = e.Exec("UPDATE `comment` SET label_id = ? WHERE issue_id = ? AND type = ? AND label_id = ?",
+			label.ID, issue.ID, CommentTypeLabel, oldLabel.ID); err != nil {
+			return fmt.Errorf("update label events: %v", err)
+		}
+	}
+
+	if oldMilestone != nil {
+		milestone := new(Milestone)
+		has, err := e.Where("repo_id = ? AND name = ?", target.ID, oldMilestone.Name).Get(milestone)
+		if err != nil {
+			return fmt.Errorf("get milestone of target by name: %v", err)
+		} else if has {
+			issue.MilestoneID = milestone.ID
+			if err = changeMilestoneAssign(e, issue, 0); err != nil {
+				return fmt.Errorf("attach milestone: %v", err)
+			}
+		}
+	}
+
+	// Cards of projects that the issue no longer belongs to are dropped.
+	if _, err = e.Exec("DELETE FROM `project_card` WHERE issue_id = ? AND project_id IN "+
+		"(SELECT id FROM `project` WHERE repo_id = ? OR (owner_id > 0 AND owner_id != ?))",
+		issue.ID, source.ID, target.OwnerID); err != nil {
+		return fmt.Errorf("delete project cards: %v", err)
+	}
+
+	if _, err = e.Insert(&IssueRedirect{
+		RepoID:   source.ID,
+		OldIndex: oldIndex,
+		IssueID:  issue.ID,
+	}); err != nil {
+		return fmt.Errorf("insert issue redirect: %v", err)
+	}
+
+	if err = createIssueEvent(e, doer, issue, &CreateCommentOptions{
+		Type:     CommentTypeTransfer,
+		OldValue: fmt.Sprintf("%s#%d", source.FullName(), oldIndex),
+		NewValue: fmt.Sprintf("%s#%d", target.FullName(), issue.Index),
+	}); err != nil {
+		return fmt.Errorf("create transfer event: %v", err)
+	}
+
+	// The event is visible from both repositories, so is the action.
+	for _, repo := range []*Repository{source, target} {
+		if err = notifyWatchers(e, &Action{
+			ActUserID:    doer.ID,
+			ActUserName:  doer.Name,
+			OpType:       ActionTransferIssue,
+			Content:      fmt.Sprintf("%s|%d|%s|%d", source.FullName(), oldIndex, target.FullName(), issue.Index),
+			RepoID:       repo.ID,
+			RepoUserName: repo.Owner.Name,
+			RepoName:     repo.Name,
+			IsPrivate:    source.IsPrivate || target.IsPrivate,
+		}); err != nil {
+			return fmt.Errorf("notify watchers: %v", err)
+		}
+	}
+	return nil
+}
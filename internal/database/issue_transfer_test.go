@@ -0,0 +1,77 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransferIssue(t *testing.T) {
+	setupLegacyTestDB(t)
+
+	pr := newTestPullRequest(t)
+	source := pr.Issue.Repo
+	doer := pr.Issue.Poster
+
+	err := TransferIssue(doer, pr.Issue, source)
+	assert.True(t, IsErrInvalidIssueTransfer(err), "pull requests cannot be transferred")
+
+	target := &Repository{OwnerID: doer.ID, Name: "target", LowerName: "target", EnableIssues: true}
+	_, err = x.Insert(target)
+	require.NoError(t, err)
+	// The target already has issues #1 and #2, while its counter is behind.
+	newTestIssue(t, target, doer, "Existing")
+	_, err = x.Insert(&Issue{RepoID: target.ID, Index: 2, PosterID: doer.ID, Title: "Imported"})
+	require.NoError(t, err)
+
+	sourceBug := &Label{RepoID: source.ID, Name: "bug", Color: "#ee0701"}
+	sourceOnly := &Label{RepoID: source.ID, Name: "wontfix", Color: "#ffffff"}
+	targetBug := &Label{RepoID: target.ID, Name: "bug", Color: "#ee0701"}
+	for _, label := range []*Label{sourceBug, sourceOnly, targetBug} {
+		_, err = x.Insert(label)
+		require.NoError(t, err)
+	}
+	sourceMilestone := &Milestone{RepoID: source.ID, Name: "v1"}
+	require.NoError(t, NewMilestone(sourceMilestone))
+	targetMilestone := &Milestone{RepoID: target.ID, Name: "v1"}
+	require.NoError(t, NewMilestone(targetMilestone))
+
+	issue := newTestIssue(t, source, doer, "Move me")
+	require.NoError(t, NewIssueLabels(issue, []*Label{sourceBug, sourceOnly}))
+	issue.MilestoneID = sourceMilestone.ID
+	require.NoError(t, ChangeMilestoneAssign(doer, issue, 0))
+	oldIndex := issue.Index
+
+	issue, err = GetIssueByID(issue.ID)
+	require.NoError(t, err)
+	require.NoError(t, TransferIssue(doer, issue, target))
+
+	issue, err = GetIssueByID(issue.ID)
+	require.NoError(t, err)
+	assert.Equal(t, target.ID, issue.RepoID)
+	assert.Equal(t, int64(3), issue.Index, "index follows the last issue of the target")
+	require.Len(t, issue.Labels, 1)
+	assert.Equal(t, targetBug.ID, issue.Labels[0].ID)
+	assert.Equal(t, targetMilestone.ID, issue.MilestoneID)
+
+	sourceBug, err = GetLabelByID(sourceBug.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, sourceBug.NumIssues)
+	sourceMilestone, err = GetMilestoneByRepoID(source.ID, sourceMilestone.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 0, sourceMilestone.NumIssues)
+	targetMilestone, err = GetMilestoneByRepoID(target.ID, targetMilestone.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 1, targetMilestone.NumIssues)
+
+	redirected, err := GetIssueByRedirect(source.ID, oldIndex)
+	require.NoError(t, err)
+	assert.Equal(t, issue.ID, redirected.ID)
+	_, err = GetIssueByRedirect(source.ID, oldIndex+1)
+	assert.True(t, IsErrIssueNotExist(err))
+}
@@ -0,0 +1,221 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/gogs/git-module"
+	"github.com/pkg/errors"
+	"github.com/unknwon/com"
+	log "unknwon.dev/clog/v2"
+
+	"gogs.io/gogs/internal/conf"
+	"gogs.io/gogs/internal/gitutil"
+	"gogs.io/gogs/internal/linguist"
+	"gogs.io/gogs/internal/sync"
+)
+
+// LanguageStat is the number of bytes of a language in the default branch of a
+// repository, which is computed at the commit. A repository without any
+// language has a single stat with empty language, which records the commit
+// computed.
+type LanguageStat struct {
+	ID        int64
+	RepoID    int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	CommitID  string `xorm:"VARCHAR(40) NOT NULL"`
+	Language  string `xorm:"VARCHAR(50) UNIQUE(s) NOT NULL"`
+	Size      int64  `xorm:"NOT NULL DEFAULT 0"`
+	IsPrimary bool   `xorm:"NOT NULL DEFAULT false"`
+
+	Percentage float64 `xorm:"-" json:"-" gorm:"-"`
+}
+
+// Color returns the color of the language.
+func (s *LanguageStat) Color() string {
+	return linguist.Color(s.Language)
+}
+
+// PercentageString returns the percentage of the language with one decimal.
+func (s *LanguageStat) PercentageString() string {
+	return fmt.Sprintf("%.1f", s.Percentage)
+}
+
+// GetLanguageStats returns language statistics of the repository in descending
+// order of sizes, with percentages calculated.
+func GetLanguageStats(repoID int64) ([]*LanguageStat, error) {
+	stats := make([]*LanguageStat, 0, 5)
+	if err := x.Where("repo_id = ? AND language != ?", repoID, "").Desc("size").Asc("language").Find(&stats); err != nil {
+		return nil, err
+	}
+
+	var total int64
+	for _, s := range stats {
+		total += s.Size
+	}
+	if total > 0 {
+		for _, s := range stats {
+			s.Percentage = float64(s.Size) * 100 / float64(total)
+		}
+	}
+	return stats, nil
+}
+
+// GetPrimaryLanguages returns names of all primary languages of repositories
+// that are listed to the user, i.e. public and not unlisted repositories, or
+// repositories the user owns or has access to. The userID is 0 for anonymous.
+func GetPrimaryLanguages(userID int64) ([]string, error) {
+	languages := make([]string, 0, 10)
+	sess := x.Table("language_stat").
+		Join("INNER", "repository", "repository.id = language_stat.repo_id").
+		Where("language_stat.is_primary = ?", true)
+	if userID > 0 {
+		sess.And("repository.owner_id = ? OR repository.id IN (SELECT repo_id FROM `access` WHERE user_id = ?) OR (repository.is_private = ? AND repository.is_unlisted = ?)",
+			userID, userID, false, false)
+	} else {
+		sess.And("repository.is_private = ? AND repository.is_unlisted = ?", false, false)
+	}
+	return languages, sess.Distinct("language_stat.language").Asc("language_stat.language").Find(&languages)
+}
+
+// LoadPrimaryLanguages loads primary languages of the repositories.
+func (repos RepositoryList) LoadPrimaryLanguages() error {
+	if len(repos) == 0 {
+		return nil
+	}
+
+	repoIDs := make([]int64, len(repos))
+	for i := range repos {
+		repoIDs[i] = repos[i].ID
+	}
+	stats := make([]*LanguageStat, 0, len(repos))
+	if err := x.Where("is_primary = ?", true).In("repo_id", repoIDs).Find(&stats); err != nil {
+		return err
+	}
+
+	languages := make(map[int64]string, len(stats))
+	for _, s := range stats {
+		languages[s.RepoID] = s.Language
+	}
+	for i := range repos {
+		repos[i].PrimaryLanguage = languages[repos[i].ID]
+	}
+	return nil
+}
+
+// languageStatWorkingPool serializes updates of language statistics of the
+// same repository, which would otherwise race to insert the same rows.
+var languageStatWorkingPool = sync.NewExclusivePool()
+
+// UpdateLanguageStats computes language statistics of the default branch of
+// the repository, which is skipped if the branch has not changed since last
+// time.
+func UpdateLanguageStats(repo *Repository) error {
+	if repo.IsBare {
+		return nil
+	}
+
+	languageStatWorkingPool.CheckIn(com.ToStr(repo.ID))
+	defer languageStatWorkingPool.CheckOut(com.ToStr(repo.ID))
+
+	gitRepo, err := git.Open(repo.RepoPath())
+	if err != nil {
+		return errors.Wrap(err, "open repository")
+	}
+	commitID, err := gitRepo.BranchCommitID(repo.DefaultBranch)
+	if err != nil {
+		if err == git.ErrReferenceNotExist {
+			return nil
+		}
+		return errors.Wrapf(err, "get commit ID of branch %q", repo.DefaultBranch)
+	}
+
+	last := new(LanguageStat)
+	has, err := x.Where("repo_id = ?", repo.ID).Get(last)
+	if err != nil {
+		return errors.Wrap(err, "get last language stat")
+	} else if has && last.CommitID == commitID {
+		return nil
+	}
+
+	blobs, err := gitutil.ListTreeBlobs(repo.RepoPath(), commitID, time.Duration(conf.Git.Timeout.Index)*time.Second)
+	if err != nil {
+		return errors.Wrap(err, "list tree blobs")
+	}
+	files := make([]linguist.File, 0, len(blobs))
+	var attrPaths []string
+	for _, blob := range blobs {
+		files = append(files, linguist.File{Path: blob.Path, Size: blob.Size})
+		if path.Base(blob.Path) == ".gitattributes" {
+			attrPaths = append(attrPaths, blob.Path)
+		}
+	}
+
+	var attrs *linguist.Attributes
+	if len(attrPaths) > 0 {
+		commit, err := gitRepo.CatFileCommit(commitID)
+		if err != nil {
+			return errors.Wrap(err, "get commit")
+		}
+		attrs = gitutil.ReadAttributes(commit, attrPaths...)
+	}
+
+	sizes := linguist.Stats(files, attrs)
+	stats := make([]*LanguageStat, 0, len(sizes))
+	for lang, size := range sizes {
+		stats = append(stats, &LanguageStat{
+			RepoID:   repo.ID,
+			CommitID: commitID,
+			Language: lang,
+			Size:     size,
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Size != stats[j].Size {
+			return stats[i].Size > stats[j].Size
+		}
+		return stats[i].Language < stats[j].Language
+	})
+	if len(stats) > 0 {
+		stats[0].IsPrimary = true
+	} else {
+		stats = append(stats, &LanguageStat{
+			RepoID:   repo.ID,
+			CommitID: commitID,
+		})
+	}
+
+	sess := x.NewSession()
+	defer sess.Close()
+	if err = sess.Begin(); err != nil {
+		return err
+	}
+
+	if _, err = sess.Delete(&LanguageStat{RepoID: repo.ID}); err != nil {
+		return errors.Wrap(err, "delete old language stats")
+	}
+	if _, err = sess.Insert(stats); err != nil {
+		return errors.Wrap(err, "insert language stats")
+	}
+	return sess.Commit()
+}
+
+// UpdateLanguageStatsByRepoID computes language statistics of the repository
+// in the background.
+func UpdateLanguageStatsByRepoID(repoID int64) {
+	go func() {
+		repo, err := GetRepositoryByID(repoID)
+		if err != nil {
+			log.Error("Failed to get repository [repo_id: %d] for language stats: %v", repoID, err)
+			return
+		}
+		if err = UpdateLanguageStats(repo); err != nil {
+			log.Error("Failed to update language stats [repo_id: %d]: %v", repoID, err)
+		}
+	}()
+}
@@ -0,0 +1,107 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/conf"
+)
+
+func TestGetPrimaryLanguages(t *testing.T) {
+	setupLegacyTestDB(t)
+
+	repos := []*Repository{
+		{OwnerID: 1, LowerName: "public", Name: "public"},
+		{OwnerID: 1, LowerName: "unlisted", Name: "unlisted", IsUnlisted: true},
+		{OwnerID: 1, LowerName: "private", Name: "private", IsPrivate: true},
+		{OwnerID: 2, LowerName: "collaborated", Name: "collaborated", IsPrivate: true},
+	}
+	languages := []string{"Go", "Rust", "Haskell", "OCaml"}
+	for i, repo := range repos {
+		_, err := x.Insert(repo)
+		require.NoError(t, err)
+		_, err = x.Insert(&LanguageStat{RepoID: repo.ID, CommitID: "0", Language: languages[i], IsPrimary: true})
+		require.NoError(t, err)
+	}
+	_, err := x.Insert(&Access{UserID: 3, RepoID: repos[3].ID, Mode: AccessModeRead})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		userID int64
+		want   []string
+	}{
+		{name: "anonymous", userID: 0, want: []string{"Go"}},
+		{name: "owner", userID: 1, want: []string{"Go", "Haskell", "Rust"}},
+		{name: "collaborator", userID: 3, want: []string{"Go", "OCaml"}},
+		{name: "other", userID: 4, want: []string{"Go"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := GetPrimaryLanguages(test.userID)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestUpdateLanguageStats_NoLanguage(t *testing.T) {
+	setupLegacyTestDB(t)
+
+	oldRoot := conf.Repository.Root
+	conf.Repository.Root = t.TempDir()
+	t.Cleanup(func() {
+		conf.Repository.Root = oldRoot
+	})
+
+	owner := &User{Name: "alice", LowerName: "alice", Email: "alice@example.com", IsActive: true}
+	_, err := x.Insert(owner)
+	require.NoError(t, err)
+	repo := &Repository{OwnerID: owner.ID, Name: "repo", LowerName: "repo", DefaultBranch: "master"}
+	_, err = x.Insert(repo)
+	require.NoError(t, err)
+
+	// A repository with only a license file has no language.
+	work := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(work, "LICENSE"), []byte("MIT License\n"), 0644))
+	for _, args := range [][]string{
+		{"init", "-b", "master"},
+		{"add", "-A"},
+		{"-c", "user.name=alice", "-c", "user.email=alice@example.com", "commit", "-m", "Initial commit"},
+		{"clone", "--bare", work, repo.RepoPath()},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = work
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	require.NoError(t, UpdateLanguageStats(repo))
+	stats, err := GetLanguageStats(repo.ID)
+	require.NoError(t, err)
+	assert.Empty(t, stats)
+
+	// The commit is recorded, so the stats are not computed again.
+	marker := new(LanguageStat)
+	has, err := x.Where("repo_id = ?", repo.ID).Get(marker)
+	require.NoError(t, err)
+	require.True(t, has)
+	assert.Equal(t, "", marker.Language)
+	_, err = x.ID(marker.ID).Cols("size").Update(&LanguageStat{Size: 1})
+	require.NoError(t, err)
+
+	require.NoError(t, UpdateLanguageStats(repo))
+	got := new(LanguageStat)
+	_, err = x.ID(marker.ID).Get(got)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), got.Size)
+}
@@ -8,15 +8,21 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
 	_ "modernc.org/sqlite"
 	log "unknwon.dev/clog/v2"
+	"xorm.io/core"
+	"xorm.io/xorm"
 
 	"gogs.io/gogs/internal/conf"
 	"gogs.io/gogs/internal/dbtest"
+	"gogs.io/gogs/internal/dbutil"
 	"gogs.io/gogs/internal/testutil"
 )
 
@@ -68,3 +74,42 @@ func clearTables(t *testing.T, db *gorm.DB) error {
 	}
 	return nil
 }
+
+// setupLegacyTestDB points the legacy xorm engine and the global database handle
+// to a temporary SQLite database for the duration of the test. Tests calling it
+// must not run in parallel because both are global variables.
+func setupLegacyTestDB(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	} else if !conf.UseSQLite3 {
+		t.Skip("Legacy tables are only tested with SQLite")
+	}
+
+	path := filepath.Join(t.TempDir(), "gogs.db")
+	engine, err := xorm.NewEngine("sqlite3", "file:"+path+"?cache=shared&mode=rwc")
+	require.NoError(t, err)
+	engine.SetMapper(core.GonicMapper{})
+	require.NoError(t, engine.Sync2(legacyTables...))
+
+	db, err := dbutil.OpenDB(
+		conf.DatabaseOpts{Type: "sqlite3", Path: path},
+		&gorm.Config{
+			SkipDefaultTransaction: true,
+			NamingStrategy: schema.NamingStrategy{
+				SingularTable: true,
+			},
+		},
+	)
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(Tables...))
+
+	oldX, oldHandle := x, Handle
+	x, Handle = engine, &DB{db: db}
+	t.Cleanup(func() {
+		x, Handle = oldX, oldHandle
+		_ = engine.Close()
+		if sqlDB, err := db.DB(); err == nil {
+			_ = sqlDB.Close()
+		}
+	})
+}
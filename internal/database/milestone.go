@@ -31,6 +31,8 @@ type Milestone struct {
 	Completeness    int  // Percentage(1-100).
 	IsOverDue       bool `xorm:"-" json:"-" gorm:"-"`
 
+	TotalTrackedTime int64 `xorm:"-" json:"-" gorm:"-"`
+
 	DeadlineString string    `xorm:"-" json:"-" gorm:"-"`
 	Deadline       time.Time `xorm:"-" json:"-" gorm:"-"`
 	DeadlineUnix   int64
@@ -338,10 +340,24 @@ func ChangeMilestoneAssign(doer *User, issue *Issue, oldMilestoneID int64) (err
 		return err
 	}
 
+	var oldMilestone *Milestone
+	if oldMilestoneID > 0 {
+		oldMilestone, err = getMilestoneByRepoID(sess, issue.RepoID, oldMilestoneID)
+		if err != nil && !IsErrMilestoneNotExist(err) {
+			return fmt.Errorf("get old milestone: %v", err)
+		}
+	}
+
 	if err = changeMilestoneAssign(sess, issue, oldMilestoneID); err != nil {
 		return err
 	}
 
+	if oldMilestoneID != issue.MilestoneID {
+		if err = createMilestoneEvent(sess, doer, issue, oldMilestone, issue.Milestone); err != nil {
+			return fmt.Errorf("createMilestoneEvent: %v", err)
+		}
+	}
+
 	if err = sess.Commit(); err != nil {
 		return fmt.Errorf("commit: %v", err)
 	}
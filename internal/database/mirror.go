@@ -353,6 +353,9 @@ func SyncMirrors() {
 
 		if len(results) == 0 {
 			log.Trace("SyncMirrors [repo_id: %d]: no commits fetched", m.RepoID)
+		} else {
+			UpdateRepoIndexer(m.RepoID)
+			UpdateLanguageStatsByRepoID(m.RepoID)
 		}
 
 		gitRepo, err := git.Open(m.Repo.RepoPath())
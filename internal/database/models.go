@@ -54,9 +54,11 @@ func init() {
 		new(Repository), new(DeployKey), new(Collaboration), new(Upload),
 		new(Watch), new(Star),
 		new(Issue), new(PullRequest), new(Comment), new(Attachment), new(IssueUser),
-		new(Label), new(IssueLabel), new(Milestone),
+		new(Label), new(IssueLabel), new(Milestone), new(IssueDependency), new(IssueRedirect), new(Reaction),
+		new(TrackedTime), new(Stopwatch), new(Project), new(ProjectColumn), new(ProjectCard), new(SavedSearch),
+		new(LanguageStat),
 		new(Mirror), new(Release), new(Webhook), new(HookTask),
-		new(ProtectBranch), new(ProtectBranchWhitelist),
+		new(ProtectBranch), new(ProtectBranchWhitelist), new(CommitStatus),
 		new(Team), new(OrgUser), new(TeamUser), new(TeamRepo),
 	)
 
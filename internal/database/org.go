@@ -231,8 +231,11 @@ func DeleteOrganization(org *User) error {
 		&Team{OrgID: org.ID},
 		&OrgUser{OrgID: org.ID},
 		&TeamUser{OrgID: org.ID},
+		&Label{OrgID: org.ID},
 	); err != nil {
 		return fmt.Errorf("deleteBeans: %v", err)
+	} else if err = deleteOwnerProjects(sess, org.ID); err != nil {
+		return fmt.Errorf("deleteOwnerProjects: %v", err)
 	}
 	return sess.Commit()
 }
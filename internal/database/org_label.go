@@ -0,0 +1,155 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"fmt"
+
+	"xorm.io/xorm"
+)
+
+// GetLabelsByOrgID returns all labels that belong to given organization by ID.
+func GetLabelsByOrgID(orgID int64) ([]*Label, error) {
+	labels := make([]*Label, 0, 10)
+	return labels, x.Where("org_id = ?", orgID).Asc("name").Find(&labels)
+}
+
+// GetLabelOfOrgByID returns a label by ID in given organization.
+func GetLabelOfOrgByID(orgID, labelID int64) (*Label, error) {
+	l := new(Label)
+	has, err := x.Where("id = ? AND org_id = ?", labelID, orgID).Get(l)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrLabelNotExist{args: map[string]any{"orgID": orgID, "labelID": labelID}}
+	}
+	return l, nil
+}
+
+// DeleteOrgLabel deletes a label of given organization and removes it from
+// all issues of repositories of the organization.
+func DeleteOrgLabel(orgID, labelID int64) error {
+	_, err := GetLabelOfOrgByID(orgID, labelID)
+	if err != nil {
+		if IsErrLabelNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	return deleteLabel(labelID)
+}
+
+// detachIssueOrgLabels removes labels of the organization from the issue, it
+// is used when the repository of the issue is no longer owned by the
+// organization.
+func detachIssueOrgLabels(e *xorm.Session, issue *Issue, orgID int64) error {
+	labels, err := getLabelsByIssueID(e, issue.ID)
+	if err != nil {
+		return fmt.Errorf("get labels by issue ID: %v", err)
+	}
+	for _, l := range labels {
+		if l.OrgID != orgID {
+			continue
+		}
+		if err = deleteIssueLabel(e, issue, l); err != nil {
+			return fmt.Errorf("delete issue label [%d]: %v", l.ID, err)
+		}
+	}
+	return nil
+}
+
+// detachRepoOrgLabels removes labels of the organization from all issues of
+// the repository.
+func detachRepoOrgLabels(e *xorm.Session, repoID, orgID int64) error {
+	issues := make([]*Issue, 0, 10)
+	if err := e.Where("repo_id = ?", repoID).Find(&issues); err != nil {
+		return fmt.Errorf("find issues: %v", err)
+	}
+	for _, issue := range issues {
+		if err := detachIssueOrgLabels(e, issue, orgID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recalculateLabelStats recounts issues of the label.
+func recalculateLabelStats(e Engine, labelID int64) error {
+	_, err := e.Exec("UPDATE `label` SET "+
+		"num_issues = (SELECT COUNT(*) FROM `issue_label` WHERE label_id = ?), "+
+		"num_closed_issues = (SELECT COUNT(*) FROM `issue_label`, `issue` WHERE issue_label.label_id = ? AND issue.id = issue_label.issue_id AND issue.is_closed = ?) "+
+		"WHERE id = ?", labelID, labelID, true, labelID)
+	return err
+}
+
+// MergeRepoLabelsIntoOrg replaces labels of repositories of the organization
+// with the label of the organization that has the identical name, issues and
+// events of the replaced labels are moved to the label of the organization. It
+// returns the number of labels that have been replaced.
+func MergeRepoLabelsIntoOrg(orgID int64) (merged int, err error) {
+	orgLabels, err := GetLabelsByOrgID(orgID)
+	if err != nil {
+		return 0, fmt.Errorf("get labels by organization ID: %v", err)
+	} else if len(orgLabels) == 0 {
+		return 0, nil
+	}
+
+	sess := x.NewSession()
+	defer sess.Close()
+	if err = sess.Begin(); err != nil {
+		return 0, err
+	}
+
+	repos := make([]*Repository, 0, 10)
+	if err = sess.Where("owner_id = ?", orgID).Cols("id").Find(&repos); err != nil {
+		return 0, fmt.Errorf("find repositories: %v", err)
+	} else if len(repos) == 0 {
+		return 0, nil
+	}
+	repoIDs := make([]int64, len(repos))
+	for i := range repos {
+		repoIDs[i] = repos[i].ID
+	}
+
+	for _, orgLabel := range orgLabels {
+		repoLabels := make([]*Label, 0, len(repoIDs))
+		if err = sess.Where("name = ?", orgLabel.Name).In("repo_id", repoIDs).Find(&repoLabels); err != nil {
+			return 0, fmt.Errorf("find repository labels: %v", err)
+		}
+
+		for _, repoLabel := range repoLabels {
+			issueLabels := make([]*IssueLabel, 0, repoLabel.NumIssues)
+			if err = sess.Where("label_id = ?", repoLabel.ID).Find(&issueLabels); err != nil {
+				return 0, fmt.Errorf("find issue labels: %v", err)
+			}
+			for _, il := range issueLabels {
+				if hasIssueLabel(sess, il.IssueID, orgLabel.ID) {
+					_, err = sess.ID(il.ID).Delete(new(IssueLabel))
+				} else {
+					_, err = sess.Exec("UPDATE `issue_label` SET label_id = ? WHERE id = ?", orgLabel.ID, il.ID)
+				}
+				if err != nil {
+					return 0, fmt.Errorf("move issue label [%d]: %v", il.ID, err)
+				}
+			}
+
+			if _, err = sess.Exec("UPDATE `comment` SET label_id = ? WHERE type = ? AND label_id = ?",
+				orgLabel.ID, CommentTypeLabel, repoLabel.ID); err != nil {
+				return 0, fmt.Errorf("update label events: %v", err)
+			} else if _, err = sess.ID(repoLabel.ID).Delete(new(Label)); err != nil {
+				return 0, fmt.Errorf("delete repository label [%d]: %v", repoLabel.ID, err)
+			}
+			merged++
+		}
+
+		if len(repoLabels) > 0 {
+			if err = recalculateLabelStats(sess, orgLabel.ID); err != nil {
+				return 0, fmt.Errorf("recalculate label stats [%d]: %v", orgLabel.ID, err)
+			}
+		}
+	}
+
+	return merged, sess.Commit()
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabel_IsAvailableTo(t *testing.T) {
+	repo := &Repository{ID: 1, OwnerID: 2}
+	tests := []struct {
+		name  string
+		label *Label
+		want  bool
+	}{
+		{name: "same repository", label: &Label{RepoID: 1}, want: true},
+		{name: "other repository", label: &Label{RepoID: 3}, want: false},
+		{name: "owner organization", label: &Label{OrgID: 2}, want: true},
+		{name: "other organization", label: &Label{OrgID: 3}, want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, test.label.IsAvailableTo(repo))
+		})
+	}
+}
+
+func TestGetLabelsAvailableToRepo(t *testing.T) {
+	setupLegacyTestDB(t)
+
+	repo := &Repository{ID: 1, OwnerID: 2}
+	repoBug := &Label{RepoID: repo.ID, Name: "bug"}
+	orgBug := &Label{OrgID: repo.OwnerID, Name: "bug"}
+	orgFeature := &Label{OrgID: repo.OwnerID, Name: "feature"}
+	otherOrg := &Label{OrgID: 3, Name: "other"}
+	for _, label := range []*Label{repoBug, orgBug, orgFeature, otherOrg} {
+		_, err := x.Insert(label)
+		require.NoError(t, err)
+	}
+
+	labels, err := GetLabelsAvailableToRepo(repo)
+	require.NoError(t, err)
+	var ids []int64
+	for _, label := range labels {
+		ids = append(ids, label.ID)
+	}
+	assert.ElementsMatch(t, []int64{repoBug.ID, orgBug.ID, orgFeature.ID}, ids)
+
+	label, err := GetLabelAvailableToRepoByName(repo, "bug")
+	require.NoError(t, err)
+	assert.Equal(t, repoBug.ID, label.ID, "label of the repository takes precedence")
+	label, err = GetLabelAvailableToRepoByName(repo, "feature")
+	require.NoError(t, err)
+	assert.Equal(t, orgFeature.ID, label.ID)
+	_, err = GetLabelAvailableToRepoByName(repo, "other")
+	assert.True(t, IsErrLabelNotExist(err))
+
+	label, err = GetLabelAvailableToRepoByID(repo, orgFeature.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "feature", label.Name)
+	_, err = GetLabelAvailableToRepoByID(repo, otherOrg.ID)
+	assert.True(t, IsErrLabelNotExist(err))
+}
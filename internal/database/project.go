@@ -0,0 +1,543 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"xorm.io/xorm"
+
+	"gogs.io/gogs/internal/errutil"
+)
+
+// DefaultProjectColumns is the list of columns that a new project starts with.
+var DefaultProjectColumns = []string{"To do", "In progress", "Done"}
+
+// Project represents a kanban board of a repository, or of an organization
+// that spans all its repositories when RepoID is zero.
+type Project struct {
+	ID          int64
+	OwnerID     int64  `xorm:"INDEX NOT NULL DEFAULT 0"`
+	RepoID      int64  `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Title       string `xorm:"NOT NULL"`
+	Description string `xorm:"TEXT"`
+	IsClosed    bool
+	CreatorID   int64
+
+	Columns []*ProjectColumn `xorm:"-" json:"-" gorm:"-"`
+
+	Created     time.Time `xorm:"-" json:"-" gorm:"-"`
+	CreatedUnix int64
+	Updated     time.Time `xorm:"-" json:"-" gorm:"-"`
+	UpdatedUnix int64
+}
+
+func (p *Project) BeforeInsert() {
+	p.CreatedUnix = time.Now().Unix()
+	p.UpdatedUnix = p.CreatedUnix
+}
+
+func (p *Project) BeforeUpdate() {
+	p.UpdatedUnix = time.Now().Unix()
+}
+
+func (p *Project) AfterSet(colName string, _ xorm.Cell) {
+	switch colName {
+	case "created_unix":
+		p.Created = time.Unix(p.CreatedUnix, 0).Local()
+	case "updated_unix":
+		p.Updated = time.Unix(p.UpdatedUnix, 0).Local()
+	}
+}
+
+// NumCards returns the number of cards in all loaded columns.
+func (p *Project) NumCards() int {
+	n := 0
+	for _, col := range p.Columns {
+		n += len(col.Cards)
+	}
+	return n
+}
+
+// ProjectColumn represents a column of a project, e.g. "In progress".
+type ProjectColumn struct {
+	ID        int64
+	ProjectID int64  `xorm:"INDEX NOT NULL"`
+	Title     string `xorm:"NOT NULL"`
+	Sorting   int
+
+	Cards []*ProjectCard `xorm:"-" json:"-" gorm:"-"`
+}
+
+// ProjectCard represents an issue or a pull request placed in a column of a
+// project. An issue appears at most once in a project.
+type ProjectCard struct {
+	ID        int64
+	ProjectID int64  `xorm:"UNIQUE(project_card) NOT NULL"`
+	ColumnID  int64  `xorm:"INDEX NOT NULL"`
+	IssueID   int64  `xorm:"UNIQUE(project_card) INDEX NOT NULL"`
+	Issue     *Issue `xorm:"-" json:"-" gorm:"-"`
+	Sorting   int
+	CreatorID int64
+
+	Created     time.Time `xorm:"-" json:"-" gorm:"-"`
+	CreatedUnix int64
+}
+
+func (c *ProjectCard) BeforeInsert() {
+	c.CreatedUnix = time.Now().Unix()
+}
+
+func (c *ProjectCard) AfterSet(colName string, _ xorm.Cell) {
+	switch colName {
+	case "created_unix":
+		c.Created = time.Unix(c.CreatedUnix, 0).Local()
+	}
+}
+
+var _ errutil.NotFound = (*ErrProjectNotExist)(nil)
+
+type ErrProjectNotExist struct {
+	args map[string]any
+}
+
+func IsErrProjectNotExist(err error) bool {
+	_, ok := err.(ErrProjectNotExist)
+	return ok
+}
+
+func (err ErrProjectNotExist) Error() string {
+	return fmt.Sprintf("project does not exist: %v", err.args)
+}
+
+func (ErrProjectNotExist) NotFound() bool {
+	return true
+}
+
+var _ errutil.NotFound = (*ErrProjectColumnNotExist)(nil)
+
+type ErrProjectColumnNotExist struct {
+	args map[string]any
+}
+
+func IsErrProjectColumnNotExist(err error) bool {
+	_, ok := err.(ErrProjectColumnNotExist)
+	return ok
+}
+
+func (err ErrProjectColumnNotExist) Error() string {
+	return fmt.Sprintf("project column does not exist: %v", err.args)
+}
+
+func (ErrProjectColumnNotExist) NotFound() bool {
+	return true
+}
+
+type ErrProjectColumnLast struct {
+	args map[string]any
+}
+
+func IsErrProjectColumnLast(err error) bool {
+	_, ok := err.(ErrProjectColumnLast)
+	return ok
+}
+
+func (err ErrProjectColumnLast) Error() string {
+	return fmt.Sprintf("cannot delete the last column of the project: %v", err.args)
+}
+
+var _ errutil.NotFound = (*ErrProjectCardNotExist)(nil)
+
+type ErrProjectCardNotExist struct {
+	args map[string]any
+}
+
+func IsErrProjectCardNotExist(err error) bool {
+	_, ok := err.(ErrProjectCardNotExist)
+	return ok
+}
+
+func (err ErrProjectCardNotExist) Error() string {
+	return fmt.Sprintf("project card does not exist: %v", err.args)
+}
+
+func (ErrProjectCardNotExist) NotFound() bool {
+	return true
+}
+
+type ErrProjectCardExist struct {
+	args map[string]any
+}
+
+func IsErrProjectCardExist(err error) bool {
+	_, ok := err.(ErrProjectCardExist)
+	return ok
+}
+
+func (err ErrProjectCardExist) Error() string {
+	return fmt.Sprintf("issue is already in the project: %v", err.args)
+}
+
+// NewProject creates a new project with default columns.
+func NewProject(p *Project) (err error) {
+	sess := x.NewSession()
+	defer sess.Close()
+	if err = sess.Begin(); err != nil {
+		return err
+	}
+
+	if _, err = sess.Insert(p); err != nil {
+		return err
+	}
+	for i, title := range DefaultProjectColumns {
+		if _, err = sess.Insert(&ProjectColumn{
+			ProjectID: p.ID,
+			Title:     title,
+			Sorting:   i,
+		}); err != nil {
+			return fmt.Errorf("insert column: %v", err)
+		}
+	}
+
+	return sess.Commit()
+}
+
+// GetProjectByID returns the project with given ID.
+func GetProjectByID(id int64) (*Project, error) {
+	p := new(Project)
+	has, err := x.ID(id).Get(p)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrProjectNotExist{args: map[string]any{"projectID": id}}
+	}
+	return p, nil
+}
+
+// GetProjects returns projects of the repository, or of the organization when
+// repoID is zero, in given state.
+func GetProjects(ownerID, repoID int64, isClosed bool) ([]*Project, error) {
+	projects := make([]*Project, 0, 5)
+	return projects, x.Where("owner_id = ? AND repo_id = ? AND is_closed = ?", ownerID, repoID, isClosed).
+		Desc("id").Find(&projects)
+}
+
+// CountProjects returns the number of projects of the repository, or of the
+// organization when repoID is zero, in given state.
+func CountProjects(ownerID, repoID int64, isClosed bool) int64 {
+	count, _ := x.Where("owner_id = ? AND repo_id = ? AND is_closed = ?", ownerID, repoID, isClosed).Count(new(Project))
+	return count
+}
+
+// UpdateProject updates title, description and state of the project.
+func UpdateProject(p *Project) error {
+	_, err := x.ID(p.ID).Cols("title", "description", "is_closed").Update(p)
+	return err
+}
+
+func deleteProjects(e Engine, projectIDs []int64) error {
+	if len(projectIDs) == 0 {
+		return nil
+	}
+	if _, err := e.In("project_id", projectIDs).Delete(new(ProjectCard)); err != nil {
+		return fmt.Errorf("delete cards: %v", err)
+	}
+	if _, err := e.In("project_id", projectIDs).Delete(new(ProjectColumn)); err != nil {
+		return fmt.Errorf("delete columns: %v", err)
+	}
+	if _, err := e.In("id", projectIDs).Delete(new(Project)); err != nil {
+		return fmt.Errorf("delete projects: %v", err)
+	}
+	return nil
+}
+
+// DeleteProject deletes the project with all its columns and cards.
+func DeleteProject(id int64) (err error) {
+	sess := x.NewSession()
+	defer sess.Close()
+	if err = sess.Begin(); err != nil {
+		return err
+	}
+
+	if err = deleteProjects(sess, []int64{id}); err != nil {
+		return err
+	}
+	return sess.Commit()
+}
+
+// deleteRepoProjects deletes all projects of the repository.
+func deleteRepoProjects(e Engine, repoID int64) error {
+	projectIDs := make([]int64, 0, 5)
+	if err := e.Table("project").Where("repo_id = ?", repoID).Cols("id").Find(&projectIDs); err != nil {
+		return err
+	}
+	return deleteProjects(e, projectIDs)
+}
+
+// deleteOwnerProjects deletes all projects of the organization.
+func deleteOwnerProjects(e Engine, ownerID int64) error {
+	projectIDs := make([]int64, 0, 5)
+	if err := e.Table("project").Where("owner_id = ? AND repo_id = 0", ownerID).Cols("id").Find(&projectIDs); err != nil {
+		return err
+	}
+	return deleteProjects(e, projectIDs)
+}
+
+// deleteIssueProjectCards removes the issue from all projects.
+func deleteIssueProjectCards(e Engine, issueID int64) error {
+	_, err := e.Where("issue_id = ?", issueID).Delete(new(ProjectCard))
+	return err
+}
+
+// LoadColumns loads columns of the project in order, and cards of each column
+// in order with their issues loaded.
+func (p *Project) LoadColumns() error {
+	p.Columns = make([]*ProjectColumn, 0, len(DefaultProjectColumns))
+	if err := x.Where("project_id = ?", p.ID).Asc("sorting", "id").Find(&p.Columns); err != nil {
+		return fmt.Errorf("find columns: %v", err)
+	}
+
+	cards := make([]*ProjectCard, 0, 10)
+	if err := x.Where("project_id = ?", p.ID).Asc("sorting", "id").Find(&cards); err != nil {
+		return fmt.Errorf("find cards: %v", err)
+	}
+
+	columns := make(map[int64]*ProjectColumn, len(p.Columns))
+	for _, col := range p.Columns {
+		columns[col.ID] = col
+	}
+	for _, card := range cards {
+		col, ok := columns[card.ColumnID]
+		if !ok {
+			continue
+		}
+
+		issue, err := getIssueByID(x, card.IssueID)
+		if err != nil {
+			if IsErrIssueNotExist(err) {
+				continue
+			}
+			return fmt.Errorf("getIssueByID [%d]: %v", card.IssueID, err)
+		}
+		card.Issue = issue
+		col.Cards = append(col.Cards, card)
+	}
+	return nil
+}
+
+// GetProjectColumn returns the column with given ID of the project.
+func GetProjectColumn(projectID, id int64) (*ProjectColumn, error) {
+	col := new(ProjectColumn)
+	has, err := x.Where("id = ? AND project_id = ?", id, projectID).Get(col)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrProjectColumnNotExist{args: map[string]any{"projectID": projectID, "columnID": id}}
+	}
+	return col, nil
+}
+
+func getProjectColumnIDs(e Engine, projectID int64) ([]int64, error) {
+	ids := make([]int64, 0, len(DefaultProjectColumns))
+	return ids, e.Table("project_column").Where("project_id = ?", projectID).Asc("sorting", "id").Cols("id").Find(&ids)
+}
+
+// NewProjectColumn appends a new column to the project.
+func NewProjectColumn(projectID int64, title string) (*ProjectColumn, error) {
+	ids, err := getProjectColumnIDs(x, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	col := &ProjectColumn{
+		ProjectID: projectID,
+		Title:     title,
+		Sorting:   len(ids),
+	}
+	if _, err = x.Insert(col); err != nil {
+		return nil, err
+	}
+	return col, nil
+}
+
+// UpdateProjectColumn updates title of the column.
+func UpdateProjectColumn(col *ProjectColumn) error {
+	_, err := x.ID(col.ID).Cols("title").Update(col)
+	return err
+}
+
+// moveID returns a copy of ids with id moved to given position, the position
+// is clamped to the bounds of the list. The id is inserted if it is not in
+// the list.
+func moveID(ids []int64, id int64, position int) []int64 {
+	moved := make([]int64, 0, len(ids)+1)
+	for _, v := range ids {
+		if v != id {
+			moved = append(moved, v)
+		}
+	}
+
+	if position < 0 {
+		position = 0
+	} else if position > len(moved) {
+		position = len(moved)
+	}
+	moved = append(moved, 0)
+	copy(moved[position+1:], moved[position:])
+	moved[position] = id
+	return moved
+}
+
+// MoveProjectColumn moves the column to given zero-based position among
+// columns of its project.
+func MoveProjectColumn(col *ProjectColumn, position int) (err error) {
+	sess := x.NewSession()
+	defer sess.Close()
+	if err = sess.Begin(); err != nil {
+		return err
+	}
+
+	ids, err := getProjectColumnIDs(sess, col.ProjectID)
+	if err != nil {
+		return err
+	}
+	for i, id := range moveID(ids, col.ID, position) {
+		if _, err = sess.ID(id).Cols("sorting").Update(&ProjectColumn{Sorting: i}); err != nil {
+			return fmt.Errorf("update column sorting: %v", err)
+		}
+	}
+
+	return sess.Commit()
+}
+
+// DeleteProjectColumn deletes the column and moves its cards to the end of
+// the first remaining column. The last column of a project cannot be deleted.
+func DeleteProjectColumn(col *ProjectColumn) (err error) {
+	sess := x.NewSession()
+	defer sess.Close()
+	if err = sess.Begin(); err != nil {
+		return err
+	}
+
+	ids, err := getProjectColumnIDs(sess, col.ProjectID)
+	if err != nil {
+		return err
+	}
+	var targetID int64
+	for _, id := range ids {
+		if id != col.ID {
+			targetID = id
+			break
+		}
+	}
+	if targetID == 0 {
+		return ErrProjectColumnLast{args: map[string]any{"columnID": col.ID}}
+	}
+
+	cardIDs, err := getProjectCardIDs(sess, targetID)
+	if err != nil {
+		return err
+	}
+	movingIDs, err := getProjectCardIDs(sess, col.ID)
+	if err != nil {
+		return err
+	}
+	// Renumber all cards of the target column because moving cards out of a
+	// column leaves gaps in its sorting.
+	for i, id := range append(cardIDs, movingIDs...) {
+		if _, err = sess.ID(id).Cols("column_id", "sorting").Update(&ProjectCard{
+			ColumnID: targetID,
+			Sorting:  i,
+		}); err != nil {
+			return fmt.Errorf("move card: %v", err)
+		}
+	}
+
+	if _, err = sess.ID(col.ID).Delete(new(ProjectColumn)); err != nil {
+		return err
+	}
+	return sess.Commit()
+}
+
+// GetProjectCard returns the card with given ID of the project.
+func GetProjectCard(projectID, id int64) (*ProjectCard, error) {
+	card := new(ProjectCard)
+	has, err := x.Where("id = ? AND project_id = ?", id, projectID).Get(card)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, ErrProjectCardNotExist{args: map[string]any{"projectID": projectID, "cardID": id}}
+	}
+	return card, nil
+}
+
+func getProjectCardIDs(e Engine, columnID int64) ([]int64, error) {
+	ids := make([]int64, 0, 10)
+	return ids, e.Table("project_card").Where("column_id = ?", columnID).Asc("sorting", "id").Cols("id").Find(&ids)
+}
+
+// AddProjectCard adds the issue to the end of the column.
+func AddProjectCard(doer *User, col *ProjectColumn, issue *Issue) (*ProjectCard, error) {
+	has, err := x.Where("project_id = ? AND issue_id = ?", col.ProjectID, issue.ID).Exist(new(ProjectCard))
+	if err != nil {
+		return nil, err
+	} else if has {
+		return nil, ErrProjectCardExist{args: map[string]any{"projectID": col.ProjectID, "issueID": issue.ID}}
+	}
+
+	ids, err := getProjectCardIDs(x, col.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	card := &ProjectCard{
+		ProjectID: col.ProjectID,
+		ColumnID:  col.ID,
+		IssueID:   issue.ID,
+		Issue:     issue,
+		Sorting:   len(ids),
+		CreatorID: doer.ID,
+	}
+	if _, err = x.Insert(card); err != nil {
+		return nil, err
+	}
+	return card, nil
+}
+
+// MoveProjectCard moves the card to given zero-based position of the column,
+// which may be other than the current column of the card.
+func MoveProjectCard(card *ProjectCard, col *ProjectColumn, position int) (err error) {
+	sess := x.NewSession()
+	defer sess.Close()
+	if err = sess.Begin(); err != nil {
+		return err
+	}
+
+	ids, err := getProjectCardIDs(sess, col.ID)
+	if err != nil {
+		return err
+	}
+	for i, id := range moveID(ids, card.ID, position) {
+		if _, err = sess.ID(id).Cols("column_id", "sorting").Update(&ProjectCard{
+			ColumnID: col.ID,
+			Sorting:  i,
+		}); err != nil {
+			return fmt.Errorf("update card sorting: %v", err)
+		}
+	}
+
+	if err = sess.Commit(); err != nil {
+		return err
+	}
+	card.ColumnID = col.ID
+	return nil
+}
+
+// DeleteProjectCard removes the card from its project.
+func DeleteProjectCard(card *ProjectCard) error {
+	_, err := x.ID(card.ID).Delete(new(ProjectCard))
+	return err
+}
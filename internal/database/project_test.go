@@ -0,0 +1,77 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_moveID(t *testing.T) {
+	tests := []struct {
+		name     string
+		ids      []int64
+		id       int64
+		position int
+		want     []int64
+	}{
+		{name: "move forward", ids: []int64{1, 2, 3, 4}, id: 1, position: 2, want: []int64{2, 3, 1, 4}},
+		{name: "move backward", ids: []int64{1, 2, 3, 4}, id: 4, position: 0, want: []int64{4, 1, 2, 3}},
+		{name: "same position", ids: []int64{1, 2, 3}, id: 2, position: 1, want: []int64{1, 2, 3}},
+		{name: "insert new", ids: []int64{1, 2}, id: 5, position: 1, want: []int64{1, 5, 2}},
+		{name: "insert into empty", ids: nil, id: 5, position: 3, want: []int64{5}},
+		{name: "clamp negative", ids: []int64{1, 2}, id: 2, position: -1, want: []int64{2, 1}},
+		{name: "clamp overflow", ids: []int64{1, 2, 3}, id: 1, position: 10, want: []int64{2, 3, 1}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, moveID(test.ids, test.id, test.position))
+		})
+	}
+}
+
+func TestProjectCards(t *testing.T) {
+	setupLegacyTestDB(t)
+
+	pr := newTestPullRequest(t)
+	repo := pr.Issue.Repo
+	doer := pr.Issue.Poster
+	a := newTestIssue(t, repo, doer, "A")
+	b := newTestIssue(t, repo, doer, "B")
+
+	p := &Project{RepoID: repo.ID, Title: "Roadmap", CreatorID: doer.ID}
+	require.NoError(t, NewProject(p))
+	require.NoError(t, p.LoadColumns())
+	require.Len(t, p.Columns, len(DefaultProjectColumns))
+	todo, doing := p.Columns[0], p.Columns[1]
+
+	cardA, err := AddProjectCard(doer, todo, a)
+	require.NoError(t, err)
+	_, err = AddProjectCard(doer, todo, b)
+	require.NoError(t, err)
+	_, err = AddProjectCard(doer, doing, a)
+	assert.True(t, IsErrProjectCardExist(err), "an issue appears at most once in a project")
+
+	require.NoError(t, MoveProjectCard(cardA, doing, 0))
+	assert.Equal(t, doing.ID, cardA.ColumnID)
+
+	// Deleting a column moves its cards to the end of the first remaining one.
+	require.NoError(t, DeleteProjectColumn(doing))
+	require.NoError(t, p.LoadColumns())
+	require.Len(t, p.Columns, len(DefaultProjectColumns)-1)
+	require.Len(t, p.Columns[0].Cards, 2)
+	assert.Equal(t, b.ID, p.Columns[0].Cards[0].IssueID)
+	assert.Equal(t, a.ID, p.Columns[0].Cards[1].IssueID)
+
+	require.NoError(t, DeleteProjectCard(cardA))
+	_, err = GetProjectCard(p.ID, cardA.ID)
+	assert.True(t, IsErrProjectCardNotExist(err))
+
+	require.NoError(t, DeleteProjectColumn(p.Columns[1]))
+	err = DeleteProjectColumn(p.Columns[0])
+	assert.True(t, IsErrProjectColumnLast(err))
+}
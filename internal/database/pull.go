@@ -51,6 +51,9 @@ type PullRequest struct {
 	IssueID int64  `xorm:"INDEX" gorm:"index"`
 	Issue   *Issue `xorm:"-" json:"-" gorm:"-"`
 	Index   int64
+	// IsDraft indicates the pull request is still work in progress, it cannot be
+	// merged until marked as ready for review.
+	IsDraft bool
 
 	HeadRepoID   int64
 	HeadRepo     *Repository `xorm:"-" json:"-" gorm:"-"`
@@ -60,6 +63,8 @@ type PullRequest struct {
 	HeadBranch   string
 	BaseBranch   string
 	MergeBase    string `xorm:"VARCHAR(40)" gorm:"type:VARCHAR(40)"`
+	// HeadCommitSHA is the head commit last pushed to the base repository.
+	HeadCommitSHA string `xorm:"VARCHAR(40) INDEX" gorm:"type:VARCHAR(40);index"`
 
 	HasMerged      bool
 	MergedCommitID string `xorm:"VARCHAR(40)" gorm:"type:VARCHAR(40)"`
@@ -67,6 +72,13 @@ type PullRequest struct {
 	Merger         *User     `xorm:"-" json:"-" gorm:"-"`
 	Merged         time.Time `xorm:"-" json:"-" gorm:"-"`
 	MergedUnix     int64
+
+	// AutoMergeStyle is the merge style to use when the pull request becomes
+	// ready to merge, empty means auto-merge is not scheduled.
+	AutoMergeStyle       MergeStyle
+	AutoMergeDoerID      int64
+	AutoMergeDoer        *User  `xorm:"-" json:"-" gorm:"-"`
+	AutoMergeDescription string `xorm:"TEXT" gorm:"type:TEXT"`
 }
 
 func (pr *PullRequest) BeforeUpdate() {
@@ -184,6 +196,131 @@ func (pr *PullRequest) CanAutoMerge() bool {
 	return pr.Status == PullRequestStatusMergeable
 }
 
+// HeadCommitID returns the ID of the latest commit of the head branch.
+func (pr *PullRequest) HeadCommitID() (string, error) {
+	if err := pr.LoadAttributes(); err != nil {
+		return "", fmt.Errorf("load attributes: %v", err)
+	} else if pr.HeadRepo == nil {
+		return "", fmt.Errorf("head repository [%d] does not exist", pr.HeadRepoID)
+	}
+
+	headGitRepo, err := git.Open(pr.HeadRepo.RepoPath())
+	if err != nil {
+		return "", fmt.Errorf("open repository: %v", err)
+	}
+	return headGitRepo.BranchCommitID(pr.HeadBranch)
+}
+
+// LatestCommitStatuses returns the most recent status of each context for the
+// head commit of the pull request. Only statuses reported to the base
+// repository are taken into account, statuses reported to the head repository
+// of a fork are controlled by its owner and must not satisfy required checks.
+func (pr *PullRequest) LatestCommitStatuses() ([]*CommitStatus, error) {
+	headCommitID, err := pr.HeadCommitID()
+	if err != nil {
+		return nil, fmt.Errorf("get head commit ID: %v", err)
+	}
+	return GetLatestCommitStatuses(pr.BaseRepoID, headCommitID)
+}
+
+// protectBranch returns protection settings of the base branch, it returns nil
+// when the base branch is not protected.
+func (pr *PullRequest) protectBranch() (*ProtectBranch, error) {
+	protectBranch, err := GetProtectBranchOfRepoByName(pr.BaseRepoID, pr.BaseBranch)
+	if err != nil {
+		if IsErrBranchNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get protect branch of repository by name: %v", err)
+	} else if !protectBranch.Protected {
+		return nil, nil
+	}
+	return protectBranch, nil
+}
+
+// IsStatusChecksPassed returns true if the head commit of the pull request
+// satisfies required status checks of the protected base branch. It always
+// returns true when the base branch does not require status checks.
+func (pr *PullRequest) IsStatusChecksPassed() (bool, error) {
+	protectBranch, err := pr.protectBranch()
+	if err != nil {
+		return false, err
+	} else if protectBranch == nil || !protectBranch.RequireStatusChecks {
+		return true, nil
+	}
+
+	statuses, err := pr.LatestCommitStatuses()
+	if err != nil {
+		return false, err
+	}
+	return StatusChecksPassed(statuses, protectBranch.RequiredStatusContexts()), nil
+}
+
+// HookIssueReadyForReview is the webhook action of a draft pull request being
+// marked as ready for review.
+const HookIssueReadyForReview api.HookIssueAction = "ready_for_review"
+
+// MarkReadyForReview marks a draft pull request as ready for review, then
+// notifies participants and fires webhooks.
+// This method assumes pr.Issue has been loaded with its attributes.
+func (pr *PullRequest) MarkReadyForReview(doer *User) error {
+	if !pr.IsDraft {
+		return nil
+	}
+
+	pr.IsDraft = false
+	if err := pr.UpdateCols("is_draft"); err != nil {
+		return fmt.Errorf("update pull request: %v", err)
+	}
+
+	issue := pr.Issue
+	if err := issue.MailParticipants(); err != nil {
+		log.Error("MailParticipants: %v", err)
+	}
+
+	issue.PullRequest = pr
+	if err := PrepareWebhooks(issue.Repo, HookEventTypePullRequest, &api.PullRequestPayload{
+		Action:      HookIssueReadyForReview,
+		Index:       issue.Index,
+		PullRequest: pr.APIFormat(),
+		Repository:  issue.Repo.APIFormatLegacy(nil),
+		Sender:      doer.APIFormat(),
+	}); err != nil {
+		log.Error("PrepareWebhooks: %v", err)
+	}
+	return nil
+}
+
+// HookIssueConvertedToDraft is the webhook action of a pull request being
+// converted back to draft.
+const HookIssueConvertedToDraft api.HookIssueAction = "converted_to_draft"
+
+// MarkAsDraft converts a pull request back to draft, then fires webhooks.
+// This method assumes pr.Issue has been loaded with its attributes.
+func (pr *PullRequest) MarkAsDraft(doer *User) error {
+	if pr.IsDraft {
+		return nil
+	}
+
+	pr.IsDraft = true
+	if err := pr.UpdateCols("is_draft"); err != nil {
+		return fmt.Errorf("update pull request: %v", err)
+	}
+
+	issue := pr.Issue
+	issue.PullRequest = pr
+	if err := PrepareWebhooks(issue.Repo, HookEventTypePullRequest, &api.PullRequestPayload{
+		Action:      HookIssueConvertedToDraft,
+		Index:       issue.Index,
+		PullRequest: pr.APIFormat(),
+		Repository:  issue.Repo.APIFormatLegacy(nil),
+		Sender:      doer.APIFormat(),
+	}); err != nil {
+		log.Error("PrepareWebhooks: %v", err)
+	}
+	return nil
+}
+
 // MergeStyle represents the approach to merge commits into base branch.
 type MergeStyle string
 
@@ -445,20 +582,18 @@ func (pr *PullRequest) testPatch() (err error) {
 }
 
 // NewPullRequest creates new pull request with labels for repository.
-func NewPullRequest(repo *Repository, pull *Issue, labelIDs []int64, uuids []string, pr *PullRequest, patch []byte) (err error) {
+// The opts.Repo and opts.Issue are the base repository and the issue of the pull request.
+func NewPullRequest(opts NewIssueOptions, pr *PullRequest, patch []byte) (err error) {
+	repo, pull := opts.Repo, opts.Issue
+
 	sess := x.NewSession()
 	defer sess.Close()
 	if err = sess.Begin(); err != nil {
 		return err
 	}
 
-	if err = newIssue(sess, NewIssueOptions{
-		Repo:        repo,
-		Issue:       pull,
-		LableIDs:    labelIDs,
-		Attachments: uuids,
-		IsPull:      true,
-	}); err != nil {
+	opts.IsPull = true
+	if err = newIssue(sess, opts); err != nil {
 		return fmt.Errorf("newIssue: %v", err)
 	}
 
@@ -484,6 +619,7 @@ func NewPullRequest(repo *Repository, pull *Issue, labelIDs []int64, uuids []str
 	if err = sess.Commit(); err != nil {
 		return fmt.Errorf("commit: %v", err)
 	}
+	UpdateIssueIndexer(pull.ID)
 
 	if err = NotifyWatchers(&Action{
 		ActUserID:    pull.Poster.ID,
@@ -497,8 +633,11 @@ func NewPullRequest(repo *Repository, pull *Issue, labelIDs []int64, uuids []str
 	}); err != nil {
 		log.Error("NotifyWatchers: %v", err)
 	}
-	if err = pull.MailParticipants(); err != nil {
-		log.Error("MailParticipants: %v", err)
+	// Participants of a draft pull request are notified once it is ready for review.
+	if !pr.IsDraft {
+		if err = pull.MailParticipants(); err != nil {
+			log.Error("MailParticipants: %v", err)
+		}
 	}
 
 	pr.Issue = pull
@@ -699,6 +838,12 @@ func (pr *PullRequest) PushToBaseRepo() (err error) {
 		return fmt.Errorf("push: %v", err)
 	}
 
+	pr.HeadCommitSHA, err = headGitRepo.BranchCommitID(pr.HeadBranch)
+	if err != nil {
+		return fmt.Errorf("get head commit ID: %v", err)
+	} else if err = pr.UpdateCols("head_commit_sha"); err != nil {
+		return fmt.Errorf("update head commit SHA: %v", err)
+	}
 	return nil
 }
 
@@ -784,6 +929,20 @@ func AddTestPullRequestTask(doer *User, repoID int64, branch string, isSync bool
 	}
 
 	if isSync {
+		// New commits invalidate approvals and the decision of merging automatically.
+		for _, pr := range prs {
+			if err = pr.DismissApprovals(); err != nil {
+				log.Error("DismissApprovals [pull_id: %d]: %v", pr.ID, err)
+			}
+
+			if !pr.IsAutoMergeScheduled() {
+				continue
+			}
+			if err = pr.CancelAutoMerge(); err != nil {
+				log.Error("CancelAutoMerge [pull_id: %d]: %v", pr.ID, err)
+			}
+		}
+
 		if err = PullRequestList(prs).LoadAttributes(); err != nil {
 			log.Error("PullRequestList.LoadAttributes: %v", err)
 		}
@@ -835,6 +994,10 @@ func (pr *PullRequest) checkAndUpdateStatus() {
 		if err := pr.UpdateCols("status"); err != nil {
 			log.Error("Update[%d]: %v", pr.ID, err)
 		}
+
+		if pr.IsAutoMergeScheduled() && pr.CanAutoMerge() {
+			pr.tryAutoMerge()
+		}
 	}
 }
 
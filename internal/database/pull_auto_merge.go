@@ -0,0 +1,223 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gogs/git-module"
+	log "unknwon.dev/clog/v2"
+)
+
+// RequiredApprovals returns the number of approvals required by the protected
+// base branch before the pull request can be merged.
+func (pr *PullRequest) RequiredApprovals() (int, error) {
+	protectBranch, err := pr.protectBranch()
+	if err != nil {
+		return 0, err
+	} else if protectBranch == nil {
+		return 0, nil
+	}
+	return protectBranch.RequiredApprovals, nil
+}
+
+// Approvers returns users who have approved the pull request.
+func (pr *PullRequest) Approvers() ([]*User, error) {
+	return getUsersByIssueUserFlag(x, pr.IssueID, "is_approved")
+}
+
+// IsApprovalsSatisfied returns true if the pull request has got enough
+// approvals required by the protected base branch.
+func (pr *PullRequest) IsApprovalsSatisfied() (bool, error) {
+	required, err := pr.RequiredApprovals()
+	if err != nil {
+		return false, err
+	} else if required <= 0 {
+		return true, nil
+	}
+
+	approvers, err := pr.Approvers()
+	if err != nil {
+		return false, fmt.Errorf("get approvers: %v", err)
+	}
+	return len(approvers) >= required, nil
+}
+
+// SetApproval approves the pull request on behalf of the doer, or dismisses
+// the approval when approved is false.
+func (pr *PullRequest) SetApproval(doer *User, approved bool) error {
+	if err := pr.LoadIssue(); err != nil {
+		return fmt.Errorf("load issue: %v", err)
+	} else if pr.Issue.IsPoster(doer.ID) {
+		return fmt.Errorf("poster cannot approve own pull request")
+	}
+
+	if err := setIssueUserFlag(x, pr.Issue, doer.ID, "is_approved", approved); err != nil {
+		return err
+	}
+
+	if approved && pr.IsAutoMergeScheduled() {
+		pr.AddToTaskQueue()
+	}
+	return nil
+}
+
+// DismissApprovals dismisses all approvals of the pull request, approvals are
+// stale once new commits are pushed to the head branch.
+func (pr *PullRequest) DismissApprovals() error {
+	_, err := x.Exec("UPDATE `issue_user` SET is_approved = ? WHERE issue_id = ?", false, pr.IssueID)
+	return err
+}
+
+// IsAutoMergeScheduled returns true if the pull request is going to be merged
+// automatically once it is ready.
+func (pr *PullRequest) IsAutoMergeScheduled() bool {
+	return pr.AutoMergeStyle != ""
+}
+
+// LoadAutoMergeDoer loads the user who scheduled the auto-merge.
+func (pr *PullRequest) LoadAutoMergeDoer() (err error) {
+	if !pr.IsAutoMergeScheduled() || pr.AutoMergeDoer != nil {
+		return nil
+	}
+
+	pr.AutoMergeDoer, err = getUserByID(x, pr.AutoMergeDoerID)
+	if IsErrUserNotExist(err) {
+		pr.AutoMergeDoer = NewGhostUser()
+		return nil
+	}
+	return err
+}
+
+// ScheduleAutoMerge makes the pull request to be merged by the doer with given
+// merge style once it is mergeable and all required checks and approvals are
+// satisfied. The schedule is cancelled when new commits are pushed to the head
+// branch.
+func (pr *PullRequest) ScheduleAutoMerge(doer *User, mergeStyle MergeStyle, commitDescription string) error {
+	if pr.HasMerged || pr.IsDraft {
+		return fmt.Errorf("pull request [%d] is merged or draft", pr.ID)
+	}
+
+	if mergeStyle != MergeStyleRebase {
+		mergeStyle = MergeStyleRegular
+	}
+	pr.AutoMergeStyle = mergeStyle
+	pr.AutoMergeDoerID = doer.ID
+	pr.AutoMergeDoer = doer
+	pr.AutoMergeDescription = commitDescription
+	if err := pr.UpdateCols("auto_merge_style", "auto_merge_doer_id", "auto_merge_description"); err != nil {
+		return err
+	}
+
+	// Merge right away if it is ready already.
+	pr.AddToTaskQueue()
+	return nil
+}
+
+// CancelAutoMerge cancels the scheduled auto-merge of the pull request.
+func (pr *PullRequest) CancelAutoMerge() error {
+	pr.AutoMergeStyle = ""
+	pr.AutoMergeDoerID = 0
+	pr.AutoMergeDoer = nil
+	pr.AutoMergeDescription = ""
+	return pr.UpdateCols("auto_merge_style", "auto_merge_doer_id", "auto_merge_description")
+}
+
+// isReadyToMerge returns true if the pull request is mergeable and satisfies
+// all required status checks and approvals.
+func (pr *PullRequest) isReadyToMerge() (bool, error) {
+	if pr.HasMerged || pr.IsDraft || !pr.CanAutoMerge() {
+		return false, nil
+	}
+
+	passed, err := pr.IsStatusChecksPassed()
+	if err != nil {
+		return false, fmt.Errorf("check status checks: %v", err)
+	} else if !passed {
+		return false, nil
+	}
+
+	return pr.IsApprovalsSatisfied()
+}
+
+// tryAutoMerge merges the pull request with the scheduled merge style if it is
+// ready to merge. The schedule is cancelled if the doer is no longer allowed
+// to merge.
+func (pr *PullRequest) tryAutoMerge() {
+	if err := pr.LoadAttributes(); err != nil {
+		log.Error("tryAutoMerge.LoadAttributes [pull_id: %d]: %v", pr.ID, err)
+		return
+	} else if err = pr.LoadIssue(); err != nil {
+		log.Error("tryAutoMerge.LoadIssue [pull_id: %d]: %v", pr.ID, err)
+		return
+	} else if pr.HeadRepo == nil || pr.Issue.IsClosed {
+		return
+	}
+
+	ready, err := pr.isReadyToMerge()
+	if err != nil {
+		log.Error("tryAutoMerge.isReadyToMerge [pull_id: %d]: %v", pr.ID, err)
+		return
+	} else if !ready {
+		return
+	}
+
+	doer, err := getUserByID(x, pr.AutoMergeDoerID)
+	if err != nil && !IsErrUserNotExist(err) {
+		log.Error("tryAutoMerge.getUserByID [user_id: %d]: %v", pr.AutoMergeDoerID, err)
+		return
+	}
+	if doer == nil || !Handle.Permissions().Authorize(context.TODO(), doer.ID, pr.BaseRepoID, AccessModeWrite,
+		AccessModeOptions{
+			OwnerID: pr.BaseRepo.OwnerID,
+			Private: pr.BaseRepo.IsPrivate,
+		},
+	) {
+		log.Trace("tryAutoMerge [pull_id: %d]: doer is no longer allowed to merge", pr.ID)
+		if err = pr.CancelAutoMerge(); err != nil {
+			log.Error("tryAutoMerge.CancelAutoMerge [pull_id: %d]: %v", pr.ID, err)
+		}
+		return
+	}
+
+	baseGitRepo, err := git.Open(pr.BaseRepo.RepoPath())
+	if err != nil {
+		log.Error("tryAutoMerge.Open [repo_id: %d]: %v", pr.BaseRepoID, err)
+		return
+	}
+
+	pr.Issue.Repo = pr.BaseRepo
+	if err = pr.Merge(doer, baseGitRepo, pr.AutoMergeStyle, pr.AutoMergeDescription); err != nil {
+		log.Error("tryAutoMerge.Merge [pull_id: %d]: %v", pr.ID, err)
+		return
+	}
+	log.Trace("Pull request merged automatically: %d", pr.ID)
+}
+
+// getAutoMergePullRequestsBySHA returns unmerged pull requests of the base
+// repository that have auto-merge scheduled and the head commit of given SHA.
+func getAutoMergePullRequestsBySHA(e Engine, baseRepoID int64, sha string) ([]*PullRequest, error) {
+	prs := make([]*PullRequest, 0, 1)
+	return prs, e.Where("base_repo_id = ? AND head_commit_sha = ?", baseRepoID, strings.ToLower(sha)).
+		And("has_merged = ? AND auto_merge_style != ?", false, "").
+		Find(&prs)
+}
+
+// addAutoMergeTasksBySHA adds test tasks for pull requests that have auto-merge
+// scheduled and the head commit of given SHA, so they can be merged once new
+// statuses of the base repository satisfy required status checks.
+func addAutoMergeTasksBySHA(baseRepoID int64, sha string) {
+	prs, err := getAutoMergePullRequestsBySHA(x, baseRepoID, sha)
+	if err != nil {
+		log.Error("Find auto-merge pull requests [repo_id: %d, sha: %s]: %v", baseRepoID, sha, err)
+		return
+	}
+
+	for _, pr := range prs {
+		pr.AddToTaskQueue()
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPullRequest_Approvals(t *testing.T) {
+	setupLegacyTestDB(t)
+
+	pr := newTestPullRequest(t)
+	owner := pr.Issue.Poster
+	bob := newTestUser(t, "bob", pr.Issue.Repo)
+
+	// The poster cannot approve own pull request.
+	assert.Error(t, pr.SetApproval(owner, true))
+
+	require.NoError(t, pr.SetApproval(bob, true))
+	approvers, err := pr.Approvers()
+	require.NoError(t, err)
+	assert.Equal(t, []int64{bob.ID}, userIDsOf(approvers))
+
+	// Approvals are stale once new commits are pushed.
+	require.NoError(t, pr.DismissApprovals())
+	approvers, err = pr.Approvers()
+	require.NoError(t, err)
+	assert.Empty(t, approvers)
+
+	require.NoError(t, pr.SetApproval(bob, true))
+	require.NoError(t, pr.SetApproval(bob, false))
+	approvers, err = pr.Approvers()
+	require.NoError(t, err)
+	assert.Empty(t, approvers)
+}
+
+func TestGetAutoMergePullRequestsBySHA(t *testing.T) {
+	setupLegacyTestDB(t)
+
+	const sha = "2c0ab8f1e0b25f0b6d2c1b8a5f2ab3e3cbd4e1f6"
+	for _, pr := range []*PullRequest{
+		{IssueID: 1, BaseRepoID: 1, HeadCommitSHA: sha, AutoMergeStyle: MergeStyleRegular},
+		// Auto-merge is not scheduled.
+		{IssueID: 2, BaseRepoID: 1, HeadCommitSHA: sha},
+		// Merged already.
+		{IssueID: 3, BaseRepoID: 1, HeadCommitSHA: sha, AutoMergeStyle: MergeStyleRegular, HasMerged: true},
+		// Different head commit.
+		{IssueID: 4, BaseRepoID: 1, HeadCommitSHA: "0000000000000000000000000000000000000000", AutoMergeStyle: MergeStyleRegular},
+		// Different base repository.
+		{IssueID: 5, BaseRepoID: 2, HeadCommitSHA: sha, AutoMergeStyle: MergeStyleRebase},
+	} {
+		_, err := x.Insert(pr)
+		require.NoError(t, err)
+	}
+
+	prs, err := getAutoMergePullRequestsBySHA(x, 1, "2C0AB8F1E0B25F0B6D2C1B8A5F2AB3E3CBD4E1F6")
+	require.NoError(t, err)
+	require.Len(t, prs, 1)
+	assert.Equal(t, int64(1), prs[0].IssueID)
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/gogs/git-module"
+
+	"gogs.io/gogs/internal/conf"
+	"gogs.io/gogs/internal/gitutil"
+)
+
+// headRef returns the revision of the head of the pull request in the base
+// repository, which is the merged commit for merged pull requests.
+func (pr *PullRequest) headRef() string {
+	if pr.HasMerged {
+		return pr.MergedCommitID
+	}
+	return fmt.Sprintf("refs/pull/%d/head", pr.Index)
+}
+
+// Patch returns the stored patch of the pull request. The error satisfies
+// os.IsNotExist if the patch has not been generated.
+func (pr *PullRequest) Patch() ([]byte, error) {
+	patchPath, err := pr.BaseRepo.PatchPath(pr.Index)
+	if err != nil {
+		return nil, fmt.Errorf("get patch path: %v", err)
+	}
+	return os.ReadFile(patchPath)
+}
+
+// Diff returns the parsed diff of the stored patch of the pull request.
+func (pr *PullRequest) Diff() (*gitutil.Diff, error) {
+	patch, err := pr.Patch()
+	if err != nil {
+		return nil, err
+	}
+	return gitutil.ParseDiff(bytes.NewReader(patch), conf.Git.MaxDiffFiles, conf.Git.MaxDiffLines, conf.Git.MaxDiffLineChars)
+}
+
+// RawDiff returns the stored patch of the pull request for the diff format,
+// or its commits in the mailbox format for the patch format. The error
+// satisfies os.IsNotExist if the patch has not been generated.
+func (pr *PullRequest) RawDiff(format git.RawDiffFormat) ([]byte, error) {
+	if format != git.RawDiffPatch {
+		return pr.Patch()
+	}
+
+	buf := new(bytes.Buffer)
+	if err := pr.FormatPatch(buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// FormatPatch writes commits of the pull request in the mailbox format
+// produced by "git format-patch" to the writer.
+func (pr *PullRequest) FormatPatch(w io.Writer) error {
+	if pr.MergeBase == "" {
+		return fmt.Errorf("pull request [%d] has no merge base", pr.ID)
+	}
+
+	stderr := new(bytes.Buffer)
+	err := git.NewCommand("format-patch", "--full-index", "--no-signoff", "--no-signature", "--stdout", pr.MergeBase+".."+pr.headRef()).
+		RunInDirPipelineWithTimeout(time.Duration(conf.Git.Timeout.Diff)*time.Second, w, stderr, pr.BaseRepo.RepoPath())
+	if err != nil {
+		return fmt.Errorf("format patch: %v - %s", err, stderr)
+	}
+	return nil
+}
+
+// Commits returns commits requested to be merged by the pull request, or
+// commits that have been merged for merged pull requests.
+func (pr *PullRequest) Commits() ([]*git.Commit, error) {
+	if !pr.HasMerged && pr.HeadRepo != nil {
+		meta, err := gitutil.Module.PullRequestMeta(pr.HeadRepo.RepoPath(), pr.BaseRepo.RepoPath(), pr.HeadBranch, pr.BaseBranch)
+		if err != nil {
+			return nil, fmt.Errorf("get pull request meta: %v", err)
+		}
+		return meta.Commits, nil
+	}
+
+	if pr.MergeBase == "" {
+		return []*git.Commit{}, nil
+	}
+	baseGitRepo, err := git.Open(pr.BaseRepo.RepoPath())
+	if err != nil {
+		return nil, fmt.Errorf("open repository: %v", err)
+	}
+	commits, err := baseGitRepo.RevList([]string{pr.MergeBase + "..." + pr.headRef()})
+	if err != nil {
+		return nil, fmt.Errorf("list commits: %v", err)
+	}
+	return commits, nil
+}
@@ -0,0 +1,45 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"os"
+	"testing"
+
+	"github.com/gogs/git-module"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gogs.io/gogs/internal/conf"
+)
+
+func TestPullRequest_RawDiff(t *testing.T) {
+	setupLegacyTestDB(t)
+
+	oldRoot := conf.Repository.Root
+	conf.Repository.Root = t.TempDir()
+	t.Cleanup(func() {
+		conf.Repository.Root = oldRoot
+	})
+
+	pr := newTestPullRequest(t)
+	require.NoError(t, pr.LoadAttributes())
+
+	_, err := pr.RawDiff(git.RawDiffNormal)
+	assert.True(t, os.IsNotExist(err))
+
+	patch := []byte("diff --git a/README.md b/README.md\n")
+	require.NoError(t, pr.BaseRepo.SavePatch(pr.Index, patch))
+	got, err := pr.RawDiff(git.RawDiffNormal)
+	require.NoError(t, err)
+	assert.Equal(t, patch, got)
+
+	// Nothing is returned when the patch cannot be generated, so callers are
+	// able to respond with an error instead of a truncated body.
+	got, err = pr.RawDiff(git.RawDiffPatch)
+	assert.Error(t, err)
+	assert.False(t, os.IsNotExist(err))
+	assert.Nil(t, got)
+}
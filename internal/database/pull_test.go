@@ -0,0 +1,66 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestPullRequest inserts an open pull request of a public repository
+// owned by "alice" and returns it with its issue and attributes loaded.
+func newTestPullRequest(t *testing.T) *PullRequest {
+	t.Helper()
+
+	owner := &User{Name: "alice", LowerName: "alice", Email: "alice@example.com", IsActive: true}
+	_, err := x.Insert(owner)
+	require.NoError(t, err)
+
+	repo := &Repository{OwnerID: owner.ID, Name: "repo", LowerName: "repo", NumPulls: 1}
+	_, err = x.Insert(repo)
+	require.NoError(t, err)
+
+	issue := &Issue{RepoID: repo.ID, Index: 1, PosterID: owner.ID, Title: "Add feature", IsPull: true}
+	_, err = x.Insert(issue)
+	require.NoError(t, err)
+
+	pr := &PullRequest{
+		IssueID:      issue.ID,
+		Index:        issue.Index,
+		HeadRepoID:   repo.ID,
+		BaseRepoID:   repo.ID,
+		HeadUserName: owner.Name,
+		HeadBranch:   "feature",
+		BaseBranch:   "master",
+		Status:       PullRequestStatusMergeable,
+	}
+	_, err = x.Insert(pr)
+	require.NoError(t, err)
+
+	pr, err = GetPullRequestByIssueID(issue.ID)
+	require.NoError(t, err)
+	require.NoError(t, pr.LoadIssue())
+	require.NoError(t, pr.Issue.LoadAttributes())
+	return pr
+}
+
+func TestPullRequest_MarkAsDraft(t *testing.T) {
+	setupLegacyTestDB(t)
+
+	pr := newTestPullRequest(t)
+	doer := pr.Issue.Poster
+
+	require.NoError(t, pr.MarkAsDraft(doer))
+	got, err := GetPullRequestByIssueID(pr.IssueID)
+	require.NoError(t, err)
+	assert.True(t, got.IsDraft)
+
+	require.NoError(t, pr.MarkReadyForReview(doer))
+	got, err = GetPullRequestByIssueID(pr.IssueID)
+	require.NoError(t, err)
+	assert.False(t, got.IsDraft)
+}
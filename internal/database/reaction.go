@@ -0,0 +1,252 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"xorm.io/xorm"
+)
+
+// ReactionTypes is the fixed set of reactions in the order of display.
+var ReactionTypes = []string{"+1", "-1", "laugh", "hooray", "confused", "heart", "rocket", "eyes"}
+
+var reactionEmojis = map[string]string{
+	"+1":       "\U0001F44D",
+	"-1":       "\U0001F44E",
+	"laugh":    "\U0001F604",
+	"hooray":   "\U0001F389",
+	"confused": "\U0001F615",
+	"heart":    "\u2764\uFE0F",
+	"rocket":   "\U0001F680",
+	"eyes":     "\U0001F440",
+}
+
+// IsValidReactionType returns true if given type is one of ReactionTypes.
+func IsValidReactionType(typ string) bool {
+	_, ok := reactionEmojis[typ]
+	return ok
+}
+
+// ReactionEmoji returns the emoji of given reaction type.
+func ReactionEmoji(typ string) string {
+	return reactionEmojis[typ]
+}
+
+// Reaction represents a reaction of a user to an issue, or to a comment of the
+// issue when CommentID is not zero.
+type Reaction struct {
+	ID        int64
+	Type      string `xorm:"VARCHAR(16) UNIQUE(reaction) NOT NULL"`
+	IssueID   int64  `xorm:"UNIQUE(reaction) INDEX NOT NULL"`
+	CommentID int64  `xorm:"UNIQUE(reaction) INDEX NOT NULL DEFAULT 0"`
+	UserID    int64  `xorm:"UNIQUE(reaction) NOT NULL"`
+
+	Created     time.Time `xorm:"-" json:"-" gorm:"-"`
+	CreatedUnix int64
+}
+
+func (r *Reaction) BeforeInsert() {
+	r.CreatedUnix = time.Now().Unix()
+}
+
+func (r *Reaction) AfterSet(colName string, _ xorm.Cell) {
+	switch colName {
+	case "created_unix":
+		r.Created = time.Unix(r.CreatedUnix, 0).Local()
+	}
+}
+
+type ErrInvalidReactionType struct {
+	args map[string]any
+}
+
+func IsErrInvalidReactionType(err error) bool {
+	_, ok := err.(ErrInvalidReactionType)
+	return ok
+}
+
+func (err ErrInvalidReactionType) Error() string {
+	return fmt.Sprintf("invalid reaction type: %v", err.args)
+}
+
+// ReactionGroup is the reactions of the same type to an issue or a comment.
+type ReactionGroup struct {
+	Type    string
+	UserIDs []int64
+}
+
+// Emoji returns the emoji of the reaction type.
+func (g *ReactionGroup) Emoji() string {
+	return ReactionEmoji(g.Type)
+}
+
+// Count returns the number of users reacted.
+func (g *ReactionGroup) Count() int {
+	return len(g.UserIDs)
+}
+
+// HasUser returns true if the user of given ID is in the group.
+func (g *ReactionGroup) HasUser(userID int64) bool {
+	for _, id := range g.UserIDs {
+		if id == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// ReactionList is a list of reactions to the same issue or comment.
+type ReactionList []*Reaction
+
+// Groups returns reactions grouped by their types in the order of
+// ReactionTypes, types without any reaction are omitted.
+func (list ReactionList) Groups() []*ReactionGroup {
+	groups := make([]*ReactionGroup, 0, len(ReactionTypes))
+	for _, typ := range ReactionTypes {
+		var group *ReactionGroup
+		for _, r := range list {
+			if r.Type != typ {
+				continue
+			}
+			if group == nil {
+				group = &ReactionGroup{Type: typ}
+			}
+			group.UserIDs = append(group.UserIDs, r.UserID)
+		}
+		if group != nil {
+			groups = append(groups, group)
+		}
+	}
+	return groups
+}
+
+// Counts returns numbers of reactions by their types.
+func (list ReactionList) Counts() map[string]int {
+	counts := make(map[string]int, len(list))
+	for _, r := range list {
+		counts[r.Type]++
+	}
+	return counts
+}
+
+// Users returns users who reacted, keyed by their IDs. Users that no longer
+// exist are omitted.
+func (list ReactionList) Users() (map[int64]*User, error) {
+	users := make(map[int64]*User, len(list))
+	if len(list) == 0 {
+		return users, nil
+	}
+
+	userIDs := make([]int64, len(list))
+	for i := range list {
+		userIDs[i] = list[i].UserID
+	}
+	return users, x.In("id", userIDs).Find(&users)
+}
+
+// getReactionsByIssueID returns reactions to the issue itself.
+func getReactionsByIssueID(e Engine, issueID int64) (ReactionList, error) {
+	reactions := make(ReactionList, 0, 5)
+	return reactions, e.Where("issue_id = ? AND comment_id = 0", issueID).Asc("id").Find(&reactions)
+}
+
+// LoadCommentsReactions loads reactions to each of given comments.
+func LoadCommentsReactions(comments []*Comment) error {
+	if len(comments) == 0 {
+		return nil
+	}
+
+	commentIDs := make([]int64, len(comments))
+	for i := range comments {
+		commentIDs[i] = comments[i].ID
+	}
+	reactions := make([]*Reaction, 0, len(comments))
+	if err := x.In("comment_id", commentIDs).Asc("id").Find(&reactions); err != nil {
+		return err
+	}
+
+	byComment := make(map[int64]ReactionList, len(comments))
+	for _, r := range reactions {
+		byComment[r.CommentID] = append(byComment[r.CommentID], r)
+	}
+	for _, c := range comments {
+		c.Reactions = byComment[c.ID]
+	}
+	return nil
+}
+
+// reactionCond returns the condition of the reaction of the user of given type
+// to the issue or the comment. Conditions are explicit because a zero
+// CommentID is meaningful.
+func reactionCond(e Engine, doer *User, issueID, commentID int64, typ string) *xorm.Session {
+	return e.Where("type = ? AND issue_id = ? AND comment_id = ? AND user_id = ?", typ, issueID, commentID, doer.ID)
+}
+
+// ToggleReaction adds the reaction of the user to the issue, or to the comment
+// if commentID is not zero, and removes the reaction if it already exists. It
+// returns true if the reaction has been added.
+func ToggleReaction(doer *User, issueID, commentID int64, typ string) (added bool, err error) {
+	if !IsValidReactionType(typ) {
+		return false, ErrInvalidReactionType{args: map[string]any{"type": typ}}
+	}
+
+	has, err := reactionCond(x, doer, issueID, commentID, typ).Exist(new(Reaction))
+	if err != nil {
+		return false, err
+	} else if has {
+		return false, RemoveReaction(doer, issueID, commentID, typ)
+	}
+	_, err = AddReaction(doer, issueID, commentID, typ)
+	return err == nil, err
+}
+
+// AddReaction adds the reaction of the user to the issue, or to the comment if
+// commentID is not zero. It returns the existing reaction if any.
+func AddReaction(doer *User, issueID, commentID int64, typ string) (*Reaction, error) {
+	if !IsValidReactionType(typ) {
+		return nil, ErrInvalidReactionType{args: map[string]any{"type": typ}}
+	}
+
+	reaction := new(Reaction)
+	has, err := reactionCond(x, doer, issueID, commentID, typ).Get(reaction)
+	if err != nil {
+		return nil, err
+	} else if has {
+		return reaction, nil
+	}
+
+	reaction = &Reaction{
+		Type:      typ,
+		IssueID:   issueID,
+		CommentID: commentID,
+		UserID:    doer.ID,
+	}
+	if _, err = x.Insert(reaction); err != nil {
+		// The same reaction could have been added concurrently, which violates the
+		// unique constraint and means it is already added.
+		existing := new(Reaction)
+		if has, _ := reactionCond(x, doer, issueID, commentID, typ).Get(existing); has {
+			return existing, nil
+		}
+		return nil, err
+	}
+	reaction.Created = time.Unix(reaction.CreatedUnix, 0).Local()
+	return reaction, nil
+}
+
+// RemoveReaction removes the reaction of the user from the issue, or from the
+// comment if commentID is not zero.
+func RemoveReaction(doer *User, issueID, commentID int64, typ string) error {
+	_, err := reactionCond(x, doer, issueID, commentID, typ).Delete(new(Reaction))
+	return err
+}
+
+// deleteCommentReactions deletes all reactions to the comment.
+func deleteCommentReactions(e Engine, commentID int64) error {
+	_, err := e.Where("comment_id = ?", commentID).Delete(new(Reaction))
+	return err
+}
@@ -0,0 +1,86 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidReactionType(t *testing.T) {
+	for _, typ := range ReactionTypes {
+		assert.True(t, IsValidReactionType(typ), typ)
+		assert.NotEmpty(t, ReactionEmoji(typ), typ)
+	}
+	assert.False(t, IsValidReactionType(""))
+	assert.False(t, IsValidReactionType("smile"))
+}
+
+func TestReactionList(t *testing.T) {
+	list := ReactionList{
+		{Type: "heart", UserID: 1},
+		{Type: "+1", UserID: 2},
+		{Type: "heart", UserID: 3},
+		{Type: "+1", UserID: 1},
+	}
+
+	groups := list.Groups()
+	if assert.Len(t, groups, 2) {
+		assert.Equal(t, "+1", groups[0].Type)
+		assert.Equal(t, []int64{2, 1}, groups[0].UserIDs)
+		assert.Equal(t, "heart", groups[1].Type)
+		assert.Equal(t, 2, groups[1].Count())
+		assert.True(t, groups[1].HasUser(3))
+		assert.False(t, groups[1].HasUser(2))
+	}
+
+	assert.Equal(t, map[string]int{"+1": 2, "heart": 2}, list.Counts())
+	assert.Empty(t, ReactionList(nil).Groups())
+}
+
+func TestToggleReaction(t *testing.T) {
+	setupLegacyTestDB(t)
+
+	pr := newTestPullRequest(t)
+	issueID := pr.IssueID
+	alice := pr.Issue.Poster
+	bob := newTestUser(t, "bob", nil)
+
+	_, err := ToggleReaction(alice, issueID, 0, "unknown")
+	assert.True(t, IsErrInvalidReactionType(err))
+
+	added, err := ToggleReaction(alice, issueID, 0, "+1")
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	// Adding the same reaction again returns the existing one.
+	first, err := AddReaction(bob, issueID, 0, "heart")
+	require.NoError(t, err)
+	second, err := AddReaction(bob, issueID, 0, "heart")
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+
+	// Reactions to comments are separated from reactions to the issue.
+	_, err = AddReaction(bob, issueID, 1, "heart")
+	require.NoError(t, err)
+
+	reactions, err := getReactionsByIssueID(x, issueID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"+1": 1, "heart": 1}, reactions.Counts())
+
+	users, err := reactions.Users()
+	require.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, "bob", users[bob.ID].Name)
+
+	added, err = ToggleReaction(alice, issueID, 0, "+1")
+	require.NoError(t, err)
+	assert.False(t, added)
+	reactions, err = getReactionsByIssueID(x, issueID)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"heart": 1}, reactions.Counts())
+}
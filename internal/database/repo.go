@@ -178,6 +178,8 @@ type Repository struct {
 	NumOpenMilestones   int `xorm:"-" gorm:"-" json:"-"`
 	NumTags             int `xorm:"-" gorm:"-" json:"-"`
 
+	PrimaryLanguage string `xorm:"-" gorm:"-" json:"-"`
+
 	IsPrivate bool
 	// TODO: When migrate to GORM, make sure to do a loose migration with `HasColumn` and `AddColumn`,
 	// see docs in https://gorm.io/docs/migration.html.
@@ -203,6 +205,9 @@ type Repository struct {
 	PullsIgnoreWhitespace bool              `xorm:"NOT NULL DEFAULT false" gorm:"not null;default:FALSE"`
 	PullsAllowRebase      bool              `xorm:"NOT NULL DEFAULT false" gorm:"not null;default:FALSE"`
 
+	// Whether issues cannot be closed while they are blocked by open dependencies.
+	BlockCloseByDependencies bool `xorm:"NOT NULL DEFAULT false" gorm:"not null;default:FALSE"`
+
 	IsFork   bool `xorm:"NOT NULL DEFAULT false" gorm:"not null;default:FALSE"`
 	ForkID   int64
 	BaseRepo *Repository `xorm:"-" gorm:"-" json:"-"`
@@ -869,9 +874,11 @@ func MigrateRepository(doer, owner *User, opts MigrateRepoOptions) (*Repository,
 		}
 
 		repo.IsMirror = true
+		UpdateRepoIndexer(repo.ID)
 		return repo, UpdateRepository(repo, false)
 	}
 
+	UpdateRepoIndexer(repo.ID)
 	return CleanUpMigrateInfo(repo)
 }
 
@@ -1419,6 +1426,12 @@ func TransferOwnership(doer *User, newOwnerName string, repo *Repository) error
 		if err = owner.removeOrgRepo(sess, repo.ID); err != nil {
 			return fmt.Errorf("removeOrgRepo: %v", err)
 		}
+
+		// Labels of the organization are not available to the repository
+		// anymore.
+		if err = detachRepoOrgLabels(sess, repo.ID, owner.ID); err != nil {
+			return fmt.Errorf("detachRepoOrgLabels: %v", err)
+		}
 	}
 
 	if newOwner.IsOrganization() {
@@ -1683,13 +1696,20 @@ func DeleteRepository(ownerID, repoID int64) error {
 		&PullRequest{BaseRepoID: repoID},
 		&ProtectBranch{RepoID: repoID},
 		&ProtectBranchWhitelist{RepoID: repoID},
+		&CommitStatus{RepoID: repoID},
 		&Webhook{RepoID: repoID},
 		&HookTask{RepoID: repoID},
 		&LFSObject{RepoID: repoID},
+		&IssueRedirect{RepoID: repoID},
+		&LanguageStat{RepoID: repoID},
 	); err != nil {
 		return fmt.Errorf("deleteBeans: %v", err)
 	}
 
+	if err = deleteRepoProjects(sess, repoID); err != nil {
+		return fmt.Errorf("deleteRepoProjects: %v", err)
+	}
+
 	// Delete comments and attachments.
 	issues := make([]*Issue, 0, 25)
 	attachmentPaths := make([]string, 0, len(issues))
@@ -1700,6 +1720,26 @@ func DeleteRepository(ownerID, repoID int64) error {
 		if _, err = sess.Delete(&Comment{IssueID: issues[i].ID}); err != nil {
 			return err
 		}
+		if err = deleteIssueDependencies(sess, issues[i].ID); err != nil {
+			return fmt.Errorf("delete issue dependencies: %v", err)
+		}
+		if _, err = sess.Delete(&Reaction{IssueID: issues[i].ID}); err != nil {
+			return fmt.Errorf("delete reactions: %v", err)
+		}
+		if err = deleteIssueTrackedTimes(sess, issues[i].ID); err != nil {
+			return fmt.Errorf("delete issue tracked times: %v", err)
+		}
+		if err = deleteIssueProjectCards(sess, issues[i].ID); err != nil {
+			return fmt.Errorf("delete issue project cards: %v", err)
+		}
+		if err = deleteIssueRedirects(sess, issues[i].ID); err != nil {
+			return fmt.Errorf("delete issue redirects: %v", err)
+		}
+		if org.IsOrganization() {
+			if err = detachIssueOrgLabels(sess, issues[i], org.ID); err != nil {
+				return fmt.Errorf("detach issue organization labels: %v", err)
+			}
+		}
 
 		attachments := make([]*Attachment, 0, 5)
 		if err = sess.Where("issue_id=?", issues[i].ID).Find(&attachments); err != nil {
@@ -1749,6 +1789,8 @@ func DeleteRepository(ownerID, repoID int64) error {
 		}
 	}
 
+	deleteRepoIndex(repoID)
+	deleteIssueIndex(repoID)
 	return nil
 }
 
@@ -1860,7 +1902,8 @@ type SearchRepoOptions struct {
 	OwnerID  int64
 	UserID   int64 // When set results will contain all public/private repositories user has access to
 	OrderBy  string
-	Private  bool // Include private repositories in results
+	Private  bool   // Include private repositories in results
+	Language string // Only include repositories whose primary language is this one
 	Page     int
 	PageSize int // Can be smaller than or equal to setting.ExplorePagingNum
 }
@@ -1891,6 +1934,9 @@ func SearchRepositoryByName(opts *SearchRepoOptions) (repos []*Repository, count
 	if opts.OwnerID > 0 {
 		sess.And("repo.owner_id = ?", opts.OwnerID)
 	}
+	if opts.Language != "" {
+		sess.And("repo.id IN (SELECT repo_id FROM `language_stat` WHERE is_primary = ? AND LOWER(language) = ?)", true, strings.ToLower(opts.Language))
+	}
 
 	// We need all fields (repo.*) in final list but only ID (repo.id) is good enough for counting.
 	count, err = sess.Clone().Distinct("repo.id").Count(new(Repository))
@@ -2061,10 +2107,11 @@ func SyncRepositoryHooks() error {
 var taskStatusTable = sync.NewStatusTable()
 
 const (
-	taskNameMirrorUpdate     = "mirror_update"
-	taskNameGitFSCK          = "git_fsck"
-	taskNameCheckRepoStats   = "check_repos_stats"
-	taskNameCleanOldArchives = "clean_old_archives"
+	taskNameMirrorUpdate       = "mirror_update"
+	taskNameGitFSCK            = "git_fsck"
+	taskNameCheckRepoStats     = "check_repos_stats"
+	taskNameCleanOldArchives   = "clean_old_archives"
+	taskNameIssueOverdueDigest = "issue_overdue_digest"
 )
 
 // GitFsck calls 'git fsck' to check repository health.
@@ -2236,6 +2283,20 @@ func CheckRepoStats() {
 		}
 	}
 	// ***** END: Repository.NumForks *****
+
+	// ***** START: LanguageStat *****
+	if err = x.Where("is_bare = ?", false).Iterate(new(Repository),
+		func(idx int, bean any) error {
+			repo := bean.(*Repository)
+			if err := UpdateLanguageStats(repo); err != nil {
+				log.Error("Update language stats [repo_id: %d]: %v", repo.ID, err)
+			}
+			return nil
+		},
+	); err != nil {
+		log.Error("Iterate repositories for language stats: %v", err)
+	}
+	// ***** END: LanguageStat *****
 }
 
 type RepositoryList []*Repository
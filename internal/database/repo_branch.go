@@ -110,6 +110,27 @@ type ProtectBranch struct {
 	EnableWhitelist    bool
 	WhitelistUserIDs   string `xorm:"TEXT"`
 	WhitelistTeamIDs   string `xorm:"TEXT"`
+	// RequireStatusChecks requires commit statuses of the pull request head to
+	// pass before the pull request can be merged.
+	RequireStatusChecks bool
+	// StatusCheckContexts is a comma-separated list of status contexts that are
+	// required to pass. All statuses must pass when it is empty.
+	StatusCheckContexts string `xorm:"TEXT"`
+	// RequiredApprovals is the number of approvals required before the pull
+	// request can be merged, 0 means no approval is required.
+	RequiredApprovals int
+}
+
+// RequiredStatusContexts returns the list of status contexts required to pass.
+func (protectBranch *ProtectBranch) RequiredStatusContexts() []string {
+	contexts := make([]string, 0, 2)
+	for _, ctx := range strings.Split(protectBranch.StatusCheckContexts, ",") {
+		ctx = strings.TrimSpace(ctx)
+		if ctx != "" {
+			contexts = append(contexts, ctx)
+		}
+	}
+	return contexts
 }
 
 // GetProtectBranchOfRepoByName returns *ProtectBranch by branch name in given repository.
@@ -0,0 +1,358 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gogs/git-module"
+	"github.com/unknwon/com"
+	log "unknwon.dev/clog/v2"
+
+	"gogs.io/gogs/internal/conf"
+	"gogs.io/gogs/internal/gitutil"
+	"gogs.io/gogs/internal/indexer"
+	"gogs.io/gogs/internal/sync"
+	"gogs.io/gogs/internal/tool"
+)
+
+const (
+	// repoIndexerPoolSize is the number of code search indexes kept in memory.
+	repoIndexerPoolSize = 64
+	// repoIndexerMetaCommit is the metadata key of the commit ID indexed.
+	repoIndexerMetaCommit = "commit"
+	// codeSearchMaxLines is the maximum number of matched lines of a file in
+	// code search results.
+	codeSearchMaxLines = 3
+	// codeSearchMaxResults is the maximum number of files to be matched by a
+	// code search, the search stops once it is reached.
+	codeSearchMaxResults = 1000
+)
+
+// ErrCodeSearchBusy is returned when an anonymous search of all repositories
+// is rejected because too many of them are running.
+var ErrCodeSearchBusy = errors.New("too many code searches are running")
+
+// globalCodeSearchSlots limits the number of searches of all repositories
+// running concurrently, each of which may read many indexes from disk.
+var globalCodeSearchSlots = make(chan struct{}, runtime.NumCPU())
+
+var (
+	// repoIndexerPool manages code search indexes of repositories by their IDs,
+	// which is nil if the code search is disabled.
+	repoIndexerPool *indexer.Pool
+	// repoIndexerQueue is the queue of repositories whose code search indexes
+	// need to be updated.
+	repoIndexerQueue = sync.NewUniqueQueue(1000)
+)
+
+// InitRepoIndexer starts updating code search indexes of repositories in the
+// background, and checks every repository once at start.
+func InitRepoIndexer() {
+	if !conf.Indexer.RepoIndexerEnabled {
+		return
+	}
+
+	repoIndexerPool = indexer.NewPool(conf.Indexer.RepoIndexerPath, repoIndexerPoolSize)
+	go processRepoIndexerQueue()
+	go func() {
+		repoIDs := make([]int64, 0, 100)
+		if err := x.Table("repository").Cols("id").Find(&repoIDs); err != nil {
+			log.Error("Failed to list repositories for code search index: %v", err)
+			return
+		}
+		for _, repoID := range repoIDs {
+			repoIndexerQueue.Add(repoID)
+		}
+	}()
+}
+
+// UpdateRepoIndexer queues the repository to have its code search index
+// updated with the latest commit of its default branch.
+func UpdateRepoIndexer(repoID int64) {
+	if repoIndexerPool == nil {
+		return
+	}
+	go repoIndexerQueue.Add(repoID)
+}
+
+func processRepoIndexerQueue() {
+	for repoID := range repoIndexerQueue.Queue() {
+		log.Trace("RepoIndexer [repo_id: %v]", repoID)
+		repoIndexerQueue.Remove(repoID)
+
+		if err := updateRepoIndex(com.StrTo(repoID).MustInt64()); err != nil {
+			log.Error("Failed to update code search index [repo_id: %v]: %v", repoID, err)
+		}
+	}
+}
+
+// deleteRepoIndex deletes the code search index of the repository.
+func deleteRepoIndex(repoID int64) {
+	if repoIndexerPool == nil {
+		return
+	}
+	if err := repoIndexerPool.Remove(com.ToStr(repoID)); err != nil {
+		log.Error("Failed to delete code search index [repo_id: %d]: %v", repoID, err)
+	}
+}
+
+// updateRepoIndex rebuilds the code search index of the repository if its
+// default branch has changed since last time.
+func updateRepoIndex(repoID int64) error {
+	name := com.ToStr(repoID)
+	repo, err := GetRepositoryByID(repoID)
+	if err != nil {
+		if IsErrRepoNotExist(err) {
+			return repoIndexerPool.Remove(name)
+		}
+		return fmt.Errorf("get repository by ID: %v", err)
+	} else if repo.IsBare {
+		return repoIndexerPool.Remove(name)
+	}
+
+	gitRepo, err := git.Open(repo.RepoPath())
+	if err != nil {
+		return fmt.Errorf("open repository: %v", err)
+	}
+	commitID, err := gitRepo.BranchCommitID(repo.DefaultBranch)
+	if err != nil {
+		if err == git.ErrReferenceNotExist {
+			return repoIndexerPool.Remove(name)
+		}
+		return fmt.Errorf("get commit ID of branch %q: %v", repo.DefaultBranch, err)
+	}
+
+	meta, err := repoIndexerPool.ReadMeta(name)
+	if err != nil {
+		log.Warn("Failed to read code search index [repo_id: %d], rebuilding: %v", repoID, err)
+	} else if meta[repoIndexerMetaCommit] == commitID {
+		return nil
+	}
+
+	idx := indexer.New("")
+	if err = indexRepoFiles(idx, repo.RepoPath(), commitID); err != nil {
+		return fmt.Errorf("index files: %v", err)
+	}
+	idx.SetMeta(repoIndexerMetaCommit, commitID)
+	return repoIndexerPool.Put(name, idx)
+}
+
+// listRepoIndexerBlobs returns blobs of the commit that are small enough to
+// be indexed.
+func listRepoIndexerBlobs(repoPath, commitID string) ([]*gitutil.TreeBlob, error) {
+	all, err := gitutil.ListTreeBlobs(repoPath, commitID, time.Duration(conf.Git.Timeout.Index)*time.Second)
+	if err != nil {
+		return nil, err
+	}
+
+	blobs := all[:0]
+	for _, blob := range all {
+		if blob.Size <= conf.Indexer.MaxFileSize {
+			blobs = append(blobs, blob)
+		}
+	}
+	return blobs, nil
+}
+
+// indexRepoFiles adds text files of the commit to the index.
+func indexRepoFiles(idx *indexer.Index, repoPath, commitID string) error {
+	blobs, err := listRepoIndexerBlobs(repoPath, commitID)
+	if err != nil {
+		return fmt.Errorf("list blobs: %v", err)
+	} else if len(blobs) == 0 {
+		return nil
+	}
+
+	stdin := new(bytes.Buffer)
+	for _, blob := range blobs {
+		stdin.WriteString(blob.ID + "\n")
+	}
+
+	// Contents of blobs are streamed through a single process, the output is
+	// in format of "<object> SP <type> SP <size> LF <contents> LF" for each.
+	r, w := io.Pipe()
+	errc := make(chan error, 1)
+	go func() {
+		err := git.NewCommandWithContext(context.Background(), "cat-file", "--batch").
+			WithTimeout(time.Duration(conf.Git.Timeout.Index)*time.Second).
+			RunInDirWithOptions(repoPath, git.RunInDirOptions{
+				Stdin:  stdin,
+				Stdout: w,
+			})
+		_ = w.CloseWithError(err)
+		errc <- err
+	}()
+
+	br := bufio.NewReader(r)
+	for _, blob := range blobs {
+		header, err := br.ReadString('\n')
+		if err != nil {
+			_ = r.CloseWithError(err)
+			return fmt.Errorf("read header of %q: %v", blob.Path, err)
+		}
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			_ = r.CloseWithError(io.ErrUnexpectedEOF)
+			return fmt.Errorf("unexpected header of %q: %q", blob.Path, header)
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			_ = r.CloseWithError(err)
+			return fmt.Errorf("parse size of %q: %v", blob.Path, err)
+		}
+
+		data := make([]byte, size+1) // Including the trailing LF
+		if _, err = io.ReadFull(br, data); err != nil {
+			_ = r.CloseWithError(err)
+			return fmt.Errorf("read contents of %q: %v", blob.Path, err)
+		}
+		data = data[:size]
+
+		if !tool.IsTextFile(data) || !utf8.Valid(data) {
+			continue
+		}
+		idx.Put(blob.Path, string(data))
+	}
+	return <-errc
+}
+
+// CodeSearchResult is a file of a repository that matches a code search.
+type CodeSearchResult struct {
+	Repo     *Repository
+	Path     string
+	Lines    []indexer.Line
+	NumLines int // The total number of matched lines
+}
+
+// IsCodeSearchEnabled returns true if code search of repositories is enabled.
+func IsCodeSearchEnabled() bool {
+	return repoIndexerPool != nil
+}
+
+// GetCodeSearchRepositories returns indexed repositories that the user can
+// read code of. Unlisted repositories are only included when the user has
+// explicit access to them.
+func GetCodeSearchRepositories(ctx context.Context, userID int64) ([]*Repository, error) {
+	if repoIndexerPool == nil {
+		return nil, nil
+	}
+
+	names, err := repoIndexerPool.Names()
+	if err != nil {
+		return nil, fmt.Errorf("list indexes: %v", err)
+	}
+	repoIDs := make([]int64, 0, len(names))
+	for _, name := range names {
+		if id := com.StrTo(name).MustInt64(); id > 0 {
+			repoIDs = append(repoIDs, id)
+		}
+	}
+	if len(repoIDs) == 0 {
+		return nil, nil
+	}
+
+	repos := make([]*Repository, 0, len(repoIDs))
+	if err = x.In("id", repoIDs).Asc("lower_name").Find(&repos); err != nil {
+		return nil, fmt.Errorf("find repositories: %v", err)
+	}
+
+	readable := repos[:0]
+	for _, repo := range repos {
+		if Handle.Permissions().Authorize(ctx, userID, repo.ID, AccessModeRead,
+			AccessModeOptions{
+				OwnerID: repo.OwnerID,
+				Private: repo.IsPrivate || repo.IsUnlisted,
+			},
+		) {
+			readable = append(readable, repo)
+		}
+	}
+	return readable, RepositoryList(readable).LoadAttributes()
+}
+
+// SearchAllCode returns files of all repositories the user can read that
+// contain the keyword in given page, and the total number of files matched.
+// Signed-in users wait for other searches of all repositories to finish, while
+// anonymous users get ErrCodeSearchBusy.
+func SearchAllCode(ctx context.Context, userID int64, keyword string, page, pageSize int) ([]*CodeSearchResult, int, error) {
+	if userID > 0 {
+		select {
+		case globalCodeSearchSlots <- struct{}{}:
+		case <-ctx.Done():
+			return nil, 0, ctx.Err()
+		}
+	} else {
+		select {
+		case globalCodeSearchSlots <- struct{}{}:
+		default:
+			return nil, 0, ErrCodeSearchBusy
+		}
+	}
+	defer func() { <-globalCodeSearchSlots }()
+
+	repos, err := GetCodeSearchRepositories(ctx, userID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("get code search repositories: %v", err)
+	}
+	return SearchCode(repos, keyword, page, pageSize)
+}
+
+// SearchCode returns files of the repositories that contain the keyword in
+// given page, and the total number of files matched, which is at most
+// codeSearchMaxResults.
+func SearchCode(repos []*Repository, keyword string, page, pageSize int) ([]*CodeSearchResult, int, error) {
+	if repoIndexerPool == nil {
+		return nil, 0, nil
+	}
+
+	// Searching many repositories at once should not evict indexes of
+	// repositories that are searched individually.
+	get := repoIndexerPool.Get
+	if len(repos) > 1 {
+		get = repoIndexerPool.Load
+	}
+
+	var results []*CodeSearchResult
+	for _, repo := range repos {
+		if len(results) >= codeSearchMaxResults {
+			break
+		}
+
+		idx, err := get(com.ToStr(repo.ID))
+		if err != nil {
+			return nil, 0, fmt.Errorf("get index of repository %d: %v", repo.ID, err)
+		}
+
+		for _, m := range idx.Search(keyword, codeSearchMaxLines, codeSearchMaxResults-len(results)) {
+			results = append(results, &CodeSearchResult{
+				Repo:     repo,
+				Path:     m.ID,
+				Lines:    m.Lines,
+				NumLines: m.NumLines,
+			})
+		}
+	}
+
+	total := len(results)
+	if page <= 0 {
+		page = 1
+	}
+	start := (page - 1) * pageSize
+	if start >= total {
+		return []*CodeSearchResult{}, total, nil
+	}
+	return results[start:min(start+pageSize, total)], total, nil
+}
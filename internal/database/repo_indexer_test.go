@@ -0,0 +1,32 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchAllCode_Busy(t *testing.T) {
+	for i := 0; i < cap(globalCodeSearchSlots); i++ {
+		globalCodeSearchSlots <- struct{}{}
+	}
+	t.Cleanup(func() {
+		for i := 0; i < cap(globalCodeSearchSlots); i++ {
+			<-globalCodeSearchSlots
+		}
+	})
+
+	_, _, err := SearchAllCode(context.Background(), 0, "main", 1, 10)
+	assert.Equal(t, ErrCodeSearchBusy, err)
+
+	// Signed-in users wait until the context is done.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, _, err = SearchAllCode(ctx, 1, "main", 1, 10)
+	assert.Equal(t, context.Canceled, err)
+}
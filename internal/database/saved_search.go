@@ -0,0 +1,82 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"xorm.io/xorm"
+)
+
+// SavedSearch is a named issue search query saved by a user, which can be
+// applied to issue and pull request lists of repositories and the dashboard.
+type SavedSearch struct {
+	ID     int64
+	UserID int64  `xorm:"UNIQUE(saved_search) NOT NULL"`
+	Name   string `xorm:"UNIQUE(saved_search) NOT NULL"`
+	Query  string `xorm:"TEXT NOT NULL"`
+
+	Created     time.Time `xorm:"-" json:"-" gorm:"-"`
+	CreatedUnix int64
+}
+
+func (s *SavedSearch) BeforeInsert() {
+	s.CreatedUnix = time.Now().Unix()
+}
+
+func (s *SavedSearch) AfterSet(colName string, _ xorm.Cell) {
+	switch colName {
+	case "created_unix":
+		s.Created = time.Unix(s.CreatedUnix, 0).Local()
+	}
+}
+
+type ErrSavedSearchAlreadyExist struct {
+	args map[string]any
+}
+
+func IsErrSavedSearchAlreadyExist(err error) bool {
+	_, ok := err.(ErrSavedSearchAlreadyExist)
+	return ok
+}
+
+func (err ErrSavedSearchAlreadyExist) Error() string {
+	return fmt.Sprintf("saved search already exists: %v", err.args)
+}
+
+// NewSavedSearch saves the query with given name for the user. It returns
+// ErrSavedSearchAlreadyExist when the user already has a saved search with the
+// same name.
+func NewSavedSearch(userID int64, name, query string) (*SavedSearch, error) {
+	has, err := x.Where("user_id = ? AND name = ?", userID, name).Get(new(SavedSearch))
+	if err != nil {
+		return nil, err
+	} else if has {
+		return nil, ErrSavedSearchAlreadyExist{args: map[string]any{"userID": userID, "name": name}}
+	}
+
+	s := &SavedSearch{
+		UserID: userID,
+		Name:   name,
+		Query:  query,
+	}
+	if _, err = x.Insert(s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// GetSavedSearchesByUserID returns all saved searches of the user.
+func GetSavedSearchesByUserID(userID int64) ([]*SavedSearch, error) {
+	searches := make([]*SavedSearch, 0, 5)
+	return searches, x.Where("user_id = ?", userID).Asc("name").Find(&searches)
+}
+
+// DeleteSavedSearch deletes a saved search of the user by given ID.
+func DeleteSavedSearch(userID, id int64) error {
+	_, err := x.Where("id = ? AND user_id = ?", id, userID).Delete(new(SavedSearch))
+	return err
+}
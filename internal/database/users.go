@@ -532,6 +532,7 @@ func (s *UsersStore) DeleteByID(ctx context.Context, userID int64, skipRewriteAu
 			{&Access{}, "user_id = @userID"},
 			{&Action{}, "user_id = @userID"},
 			{&IssueUser{}, "uid = @userID"},
+			{&SavedSearch{}, "user_id = @userID"},
 			{&EmailAddress{}, "uid = @userID"},
 			{&User{}, "id = @userID"},
 		} {
@@ -731,6 +732,72 @@ func (s *UsersStore) GetByEmail(ctx context.Context, email string) (*User, error
 	return user, nil
 }
 
+// GetByEmails returns users of given emails in batch, keyed by the lowercased
+// emails, which has the same semantics as GetByEmail for each email. Emails
+// without users are not present in the returned map.
+func (s *UsersStore) GetByEmails(ctx context.Context, emails []string) (map[string]*User, error) {
+	lowered := make([]string, 0, len(emails))
+	for _, email := range emails {
+		if email != "" {
+			lowered = append(lowered, strings.ToLower(email))
+		}
+	}
+	users := make(map[string]*User, len(lowered))
+	if len(lowered) == 0 {
+		return users, nil
+	}
+
+	var primaries []*User
+	err := s.db.WithContext(ctx).
+		Where("type = ? AND is_active = ? AND email IN (?)", UserTypeIndividual, true, lowered).
+		Find(&primaries).
+		Error
+	if err != nil {
+		return nil, errors.Wrap(err, "find users by primary emails")
+	}
+	for _, u := range primaries {
+		users[strings.ToLower(u.Email)] = u
+	}
+
+	var secondaries []*EmailAddress
+	err = s.db.WithContext(ctx).
+		Where("email IN (?) AND is_activated = ?", lowered, true).
+		Find(&secondaries).
+		Error
+	if err != nil {
+		return nil, errors.Wrap(err, "find secondary emails")
+	}
+	userIDs := make([]int64, 0, len(secondaries))
+	for _, e := range secondaries {
+		if users[strings.ToLower(e.Email)] == nil {
+			userIDs = append(userIDs, e.UserID)
+		}
+	}
+	if len(userIDs) == 0 {
+		return users, nil
+	}
+
+	var owners []*User
+	err = s.db.WithContext(ctx).
+		Where("id IN (?) AND type = ?", userIDs, UserTypeIndividual).
+		Find(&owners).
+		Error
+	if err != nil {
+		return nil, errors.Wrap(err, "find users of secondary emails")
+	}
+	byID := make(map[int64]*User, len(owners))
+	for _, u := range owners {
+		byID[u.ID] = u
+	}
+	for _, e := range secondaries {
+		email := strings.ToLower(e.Email)
+		if users[email] == nil && byID[e.UserID] != nil {
+			users[email] = byID[e.UserID]
+		}
+	}
+	return users, nil
+}
+
 // GetByID returns the user with given ID. It returns ErrUserNotExist when not
 // found.
 func (s *UsersStore) GetByID(ctx context.Context, id int64) (*User, error) {
@@ -1470,33 +1537,34 @@ func NewGhostUser() *User {
 
 var (
 	reservedUsernames = map[string]struct{}{
-		"-":        {},
-		"explore":  {},
-		"create":   {},
-		"assets":   {},
-		"css":      {},
-		"img":      {},
-		"js":       {},
-		"less":     {},
-		"plugins":  {},
-		"debug":    {},
-		"raw":      {},
-		"install":  {},
-		"api":      {},
-		"avatar":   {},
-		"user":     {},
-		"org":      {},
-		"help":     {},
-		"stars":    {},
-		"issues":   {},
-		"pulls":    {},
-		"commits":  {},
-		"repo":     {},
-		"template": {},
-		"admin":    {},
-		"new":      {},
-		".":        {},
-		"..":       {},
+		"-":         {},
+		"explore":   {},
+		"create":    {},
+		"assets":    {},
+		"css":       {},
+		"img":       {},
+		"js":        {},
+		"less":      {},
+		"plugins":   {},
+		"debug":     {},
+		"raw":       {},
+		"install":   {},
+		"api":       {},
+		"avatar":    {},
+		"user":      {},
+		"org":       {},
+		"help":      {},
+		"stars":     {},
+		"issues":    {},
+		"pulls":     {},
+		"timesheet": {},
+		"commits":   {},
+		"repo":      {},
+		"template":  {},
+		"admin":     {},
+		"new":       {},
+		".":         {},
+		"..":        {},
 	}
 	reservedUsernamePatterns = []string{"*.keys"}
 )
@@ -100,6 +100,7 @@ func TestUsers(t *testing.T) {
 		{"DeleteByID", usersDeleteByID},
 		{"DeleteInactivated", usersDeleteInactivated},
 		{"GetByEmail", usersGetByEmail},
+		{"GetByEmails", usersGetByEmails},
 		{"GetByID", usersGetByID},
 		{"GetByUsername", usersGetByUsername},
 		{"GetByKeyID", usersGetByKeyID},
@@ -782,6 +783,34 @@ func usersGetByEmail(t *testing.T, ctx context.Context, s *UsersStore) {
 	})
 }
 
+func usersGetByEmails(t *testing.T, ctx context.Context, s *UsersStore) {
+	alice, err := s.Create(ctx, "alice", "alice@exmaple.com", CreateUserOptions{Activated: true})
+	require.NoError(t, err)
+	bob, err := s.Create(ctx, "bob", "bob@exmaple.com", CreateUserOptions{Activated: true})
+	require.NoError(t, err)
+	_, err = s.Create(ctx, "cindy", "cindy@exmaple.com", CreateUserOptions{})
+	require.NoError(t, err)
+
+	// TODO: Use UserEmails.Create to replace SQL hack when the method is available.
+	err = s.db.Exec(`INSERT INTO email_address (uid, email, is_activated) VALUES (?, ?, ?), (?, ?, ?)`,
+		bob.ID, "bob2@exmaple.com", true,
+		bob.ID, "bob3@exmaple.com", false,
+	).Error
+	require.NoError(t, err)
+
+	users, err := s.GetByEmails(ctx, []string{"", "Alice@exmaple.com", "bob2@exmaple.com", "bob3@exmaple.com", "cindy@exmaple.com", "404@exmaple.com"})
+	require.NoError(t, err)
+	got := make(map[string]string, len(users))
+	for email, u := range users {
+		got[email] = u.Name
+	}
+	want := map[string]string{
+		"alice@exmaple.com": alice.Name,
+		"bob2@exmaple.com":  bob.Name,
+	}
+	assert.Equal(t, want, got)
+}
+
 func usersGetByID(t *testing.T, ctx context.Context, s *UsersStore) {
 	alice, err := s.Create(ctx, "alice", "alice@exmaple.com", CreateUserOptions{})
 	require.NoError(t, err)
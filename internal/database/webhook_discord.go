@@ -324,6 +324,10 @@ func getDiscordPullRequestPayload(p *api.PullRequestPayload, slack *SlackMeta) *
 		title = "Pull request labels cleared: " + title
 	case api.HOOK_ISSUE_SYNCHRONIZED:
 		title = "Pull request synchronized: " + title
+	case HookIssueReadyForReview:
+		title = "Pull request ready for review: " + title
+	case HookIssueConvertedToDraft:
+		title = "Pull request converted to draft: " + title
 	case api.HOOK_ISSUE_MILESTONED:
 		title = "Pull request milestoned: " + title
 		fields = []*DiscordEmbedFieldObject{{
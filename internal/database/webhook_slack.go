@@ -260,6 +260,10 @@ func getSlackPullRequestPayload(p *api.PullRequestPayload, slack *SlackMeta) *Sl
 		text = fmt.Sprintf("[%s] Pull request labels cleared: %s by %s", p.Repository.FullName, titleLink, senderLink)
 	case api.HOOK_ISSUE_SYNCHRONIZED:
 		text = fmt.Sprintf("[%s] Pull request synchronized: %s by %s", p.Repository.FullName, titleLink, senderLink)
+	case HookIssueReadyForReview:
+		text = fmt.Sprintf("[%s] Pull request ready for review: %s by %s", p.Repository.FullName, titleLink, senderLink)
+	case HookIssueConvertedToDraft:
+		text = fmt.Sprintf("[%s] Pull request converted to draft: %s by %s", p.Repository.FullName, titleLink, senderLink)
 	case api.HOOK_ISSUE_MILESTONED:
 		text = fmt.Sprintf("[%s] Pull request milestoned: %s by %s", p.Repository.FullName, titleLink, senderLink)
 	case api.HOOK_ISSUE_DEMILESTONED:
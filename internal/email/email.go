@@ -26,8 +26,11 @@ const (
 	tmplAuthResetPassword  = "auth/reset_passwd"
 	tmplAuthRegisterNotify = "auth/register_notify"
 
-	tmplIssueComment = "issue/comment"
-	tmplIssueMention = "issue/mention"
+	tmplIssueComment         = "issue/comment"
+	tmplIssueMention         = "issue/mention"
+	tmplIssueAssigned        = "issue/assigned"
+	tmplIssueReviewRequested = "issue/review_requested"
+	tmplIssueOverdueDigest   = "issue/overdue_digest"
 
 	tmplNotifyCollaborator = "notify/collaborator"
 )
@@ -100,6 +103,7 @@ type Issue interface {
 	MailSubject() string
 	Content() string
 	HTMLURL() string
+	Deadline() time.Time
 }
 
 func SendUserMail(_ *macaron.Context, u User, tpl, code, subject, info string) {
@@ -226,3 +230,44 @@ func SendIssueMentionMail(issue Issue, repo Repository, doer User, tos []string)
 	}
 	Send(composeIssueMessage(issue, repo, doer, tmplIssueMention, tos, "issue mention"))
 }
+
+// SendIssueAssignedMail composes and sends emails to users newly assigned to an issue.
+func SendIssueAssignedMail(issue Issue, repo Repository, doer User, tos []string) {
+	if len(tos) == 0 {
+		return
+	}
+	Send(composeIssueMessage(issue, repo, doer, tmplIssueAssigned, tos, "issue assigned"))
+}
+
+// SendReviewRequestedMail composes and sends emails to users newly requested to
+// review a pull request.
+func SendReviewRequestedMail(issue Issue, repo Repository, doer User, tos []string) {
+	if len(tos) == 0 {
+		return
+	}
+	Send(composeIssueMessage(issue, repo, doer, tmplIssueReviewRequested, tos, "review requested"))
+}
+
+// SendIssueOverdueDigestMail sends the user a digest of overdue issues.
+func SendIssueOverdueDigestMail(u User, issues []Issue) {
+	if len(issues) == 0 {
+		return
+	}
+
+	subject := fmt.Sprintf("%d overdue issue(s) need your attention", len(issues))
+	data := map[string]any{
+		"Subject":  subject,
+		"Username": u.DisplayName(),
+		"Issues":   issues,
+	}
+	body, err := render(tmplIssueOverdueDigest, data)
+	if err != nil {
+		log.Error("HTMLString: %v", err)
+		return
+	}
+
+	msg := NewMessage([]string{u.Email()}, subject, body)
+	msg.Info = fmt.Sprintf("UID: %d, issue overdue digest", u.ID())
+
+	Send(msg)
+}
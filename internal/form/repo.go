@@ -105,19 +105,20 @@ type RepoSetting struct {
 	EnablePrune   bool
 
 	// Advanced settings
-	EnableWiki            bool
-	AllowPublicWiki       bool
-	EnableExternalWiki    bool
-	ExternalWikiURL       string
-	EnableIssues          bool
-	AllowPublicIssues     bool
-	EnableExternalTracker bool
-	ExternalTrackerURL    string
-	TrackerURLFormat      string
-	TrackerIssueStyle     string
-	EnablePulls           bool
-	PullsIgnoreWhitespace bool
-	PullsAllowRebase      bool
+	EnableWiki               bool
+	AllowPublicWiki          bool
+	EnableExternalWiki       bool
+	ExternalWikiURL          string
+	EnableIssues             bool
+	AllowPublicIssues        bool
+	EnableExternalTracker    bool
+	ExternalTrackerURL       string
+	TrackerURLFormat         string
+	TrackerIssueStyle        string
+	BlockCloseByDependencies bool
+	EnablePulls              bool
+	PullsIgnoreWhitespace    bool
+	PullsAllowRebase         bool
 }
 
 func (f *RepoSetting) Validate(ctx *macaron.Context, errs binding.Errors) binding.Errors {
@@ -132,11 +133,14 @@ func (f *RepoSetting) Validate(ctx *macaron.Context, errs binding.Errors) bindin
 //         \/             \/     \/     \/     \/
 
 type ProtectBranch struct {
-	Protected          bool
-	RequirePullRequest bool
-	EnableWhitelist    bool
-	WhitelistUsers     string
-	WhitelistTeams     string
+	Protected           bool
+	RequirePullRequest  bool
+	RequireStatusChecks bool
+	StatusCheckContexts string
+	RequiredApprovals   int
+	EnableWhitelist     bool
+	WhitelistUsers      string
+	WhitelistTeams      string
 }
 
 func (f *ProtectBranch) Validate(ctx *macaron.Context, errs binding.Errors) binding.Errors {
@@ -231,9 +235,11 @@ type NewIssue struct {
 	Title       string `binding:"Required;MaxSize(255)"`
 	LabelIDs    string `form:"label_ids"`
 	MilestoneID int64
-	AssigneeID  int64
+	AssigneeIDs string `form:"assignee_ids"`
+	ReviewerIDs string `form:"reviewer_ids"`
 	Content     string
 	Files       []string
+	IsDraft     bool
 }
 
 func (f *NewIssue) Validate(ctx *macaron.Context, errs binding.Errors) binding.Errors {
@@ -267,6 +273,15 @@ func (f *CreateMilestone) Validate(ctx *macaron.Context, errs binding.Errors) bi
 	return validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+type CreateProject struct {
+	Title       string `binding:"Required;MaxSize(100)"`
+	Description string
+}
+
+func (f *CreateProject) Validate(ctx *macaron.Context, errs binding.Errors) binding.Errors {
+	return validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // .____          ___.          .__
 // |    |   _____ \_ |__   ____ |  |
 // |    |   \__  \ | __ \_/ __ \|  |
@@ -155,3 +155,12 @@ type NewAccessToken struct {
 func (f *NewAccessToken) Validate(ctx *macaron.Context, errs binding.Errors) binding.Errors {
 	return validate(errs, ctx.Data, f, ctx.Locale)
 }
+
+type SavedSearch struct {
+	Name  string `binding:"Required;MaxSize(50)" locale:"settings.search_name"`
+	Query string `binding:"Required;MaxSize(255)" locale:"settings.search_query"`
+}
+
+func (f *SavedSearch) Validate(ctx *macaron.Context, errs binding.Errors) binding.Errors {
+	return validate(errs, ctx.Data, f, ctx.Locale)
+}
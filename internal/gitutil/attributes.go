@@ -0,0 +1,65 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package gitutil
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/gogs/git-module"
+
+	"gogs.io/gogs/internal/linguist"
+)
+
+// ReadAttributes returns the parsed .gitattributes files in the root tree of
+// the commit and in all parent directories of given paths. Files that do not
+// exist or cannot be read are skipped.
+func ReadAttributes(commit *git.Commit, paths ...string) *linguist.Attributes {
+	dirs := map[string]struct{}{"": {}}
+	for _, p := range paths {
+		for dir := path.Dir(strings.Trim(p, "/")); dir != "." && dir != "/"; dir = path.Dir(dir) {
+			dirs[dir] = struct{}{}
+		}
+	}
+
+	sorted := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		sorted = append(sorted, dir)
+	}
+	sortDirsByDepth(sorted)
+
+	attrs := new(linguist.Attributes)
+	for _, dir := range sorted {
+		blob, err := commit.Blob(path.Join(dir, ".gitattributes"))
+		if err != nil {
+			continue
+		}
+		data, err := blob.Bytes()
+		if err != nil {
+			continue
+		}
+		attrs.AddFile(dir, data)
+	}
+	return attrs
+}
+
+// sortDirsByDepth sorts directories so that parents come before their
+// children, as .gitattributes files in deeper directories take precedence.
+func sortDirsByDepth(dirs []string) {
+	depth := func(dir string) int {
+		if dir == "" {
+			return 0
+		}
+		return strings.Count(dir, "/") + 1
+	}
+	sort.Slice(dirs, func(i, j int) bool {
+		di, dj := depth(dirs[i]), depth(dirs[j])
+		if di != dj {
+			return di < dj
+		}
+		return dirs[i] < dirs[j]
+	})
+}
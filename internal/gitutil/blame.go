@@ -0,0 +1,146 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package gitutil
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogs/git-module"
+	"github.com/pkg/errors"
+
+	"gogs.io/gogs/internal/tool"
+)
+
+var (
+	// ErrBlameFileTooLarge is returned when a file is too large to be blamed.
+	ErrBlameFileTooLarge = errors.New("file is too large to blame")
+	// ErrBlameBinaryFile is returned when a file to be blamed is not a text file.
+	ErrBlameBinaryFile = errors.New("binary file cannot be blamed")
+)
+
+// ReadBlameBlob returns contents of the blob to be blamed. The blob is not read
+// at all if its size is maxSize or more.
+func ReadBlameBlob(blob *git.Blob, maxSize int64) ([]byte, error) {
+	if blob.Size() >= maxSize {
+		return nil, ErrBlameFileTooLarge
+	}
+
+	p, err := blob.Bytes()
+	if err != nil {
+		return nil, err
+	} else if !tool.IsTextFile(p) {
+		return nil, ErrBlameBinaryFile
+	}
+	return p, nil
+}
+
+// BlameCommit contains information of a commit that last changed some lines of
+// a file.
+type BlameCommit struct {
+	ID          string
+	Author      string
+	AuthorEmail string
+	AuthorTime  time.Time
+	Summary     string
+	// PreviousID is the parent commit that the lines are blamed prior to this
+	// change, and PreviousPath is the path of the file in it. Both are empty if
+	// the commit added the lines without any parent having the file.
+	PreviousID   string
+	PreviousPath string
+}
+
+// BlamePart is a group of consecutive lines of a file that were last changed by
+// the same commit.
+type BlamePart struct {
+	Commit *BlameCommit
+	// StartLine is the 1-based line number of the first line of the part.
+	StartLine int
+	Lines     []string
+}
+
+// BlameFile returns blame results of the file with the given revision of the
+// repository in given path, lines are grouped by commits that last changed
+// them.
+func BlameFile(repoPath, rev, file string, timeout time.Duration) ([]*BlamePart, error) {
+	stdout, err := git.NewCommand("blame", "--porcelain", rev, "--", file).RunInDirWithTimeout(timeout, repoPath)
+	if err != nil {
+		return nil, err
+	}
+	return parseBlamePorcelain(bytes.NewReader(stdout))
+}
+
+// parseBlamePorcelain parses output of "git blame --porcelain". Each line of
+// the file is preceded by a header of "<commit> <original line> <final line>
+// [<lines of group>]", and information of a commit only follows its first
+// header.
+func parseBlamePorcelain(r io.Reader) ([]*BlamePart, error) {
+	commits := make(map[string]*BlameCommit)
+	var parts []*BlamePart
+	var current *BlameCommit
+	lineNum := 0
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if current == nil {
+			fields := strings.Fields(line)
+			if len(fields) < 3 {
+				return nil, errors.Errorf("unexpected header %q", line)
+			}
+			lineNum, _ = strconv.Atoi(fields[2])
+
+			id := fields[0]
+			current = commits[id]
+			if current == nil {
+				current = &BlameCommit{ID: id}
+				commits[id] = current
+			}
+			continue
+		}
+
+		// The content of the line ends information of the commit.
+		if strings.HasPrefix(line, "\t") {
+			content := line[1:]
+			last := len(parts) - 1
+			if last >= 0 && parts[last].Commit == current && parts[last].StartLine+len(parts[last].Lines) == lineNum {
+				parts[last].Lines = append(parts[last].Lines, content)
+			} else {
+				parts = append(parts, &BlamePart{
+					Commit:    current,
+					StartLine: lineNum,
+					Lines:     []string{content},
+				})
+			}
+			current = nil
+			continue
+		}
+
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "author":
+			current.Author = value
+		case "author-mail":
+			current.AuthorEmail = strings.TrimSuffix(strings.TrimPrefix(value, "<"), ">")
+		case "author-time":
+			unix, _ := strconv.ParseInt(value, 10, 64)
+			current.AuthorTime = time.Unix(unix, 0)
+		case "summary":
+			current.Summary = value
+		case "previous":
+			current.PreviousID, current.PreviousPath, _ = strings.Cut(value, " ")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "scan")
+	}
+	return parts, nil
+}
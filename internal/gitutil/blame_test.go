@@ -0,0 +1,118 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package gitutil
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gogs/git-module"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBlamePorcelain(t *testing.T) {
+	output := `aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa 1 1 2
+author Alice
+author-mail <alice@example.com>
+author-time 1600000000
+author-tz +0000
+committer Alice
+committer-mail <alice@example.com>
+committer-time 1600000000
+committer-tz +0000
+summary Add README
+boundary
+filename README.md
+	# Gogs
+aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa 2 2
+	
+bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb 3 3 1
+author Bob
+author-mail <bob@example.com>
+author-time 1700000000
+author-tz +0800
+committer Bob
+committer-mail <bob@example.com>
+committer-time 1700000000
+committer-tz +0800
+summary Update docs
+previous cccccccccccccccccccccccccccccccccccccccc docs/README.md
+filename README.md
+	A painless self-hosted Git service.
+aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa 3 4 1
+filename README.md
+	Enjoy!
+`
+
+	parts, err := parseBlamePorcelain(strings.NewReader(output))
+	require.NoError(t, err)
+
+	alice := &BlameCommit{
+		ID:          "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		Author:      "Alice",
+		AuthorEmail: "alice@example.com",
+		AuthorTime:  time.Unix(1600000000, 0),
+		Summary:     "Add README",
+	}
+	bob := &BlameCommit{
+		ID:           "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+		Author:       "Bob",
+		AuthorEmail:  "bob@example.com",
+		AuthorTime:   time.Unix(1700000000, 0),
+		Summary:      "Update docs",
+		PreviousID:   "cccccccccccccccccccccccccccccccccccccccc",
+		PreviousPath: "docs/README.md",
+	}
+	want := []*BlamePart{
+		{Commit: alice, StartLine: 1, Lines: []string{"# Gogs", ""}},
+		{Commit: bob, StartLine: 3, Lines: []string{"A painless self-hosted Git service."}},
+		{Commit: alice, StartLine: 4, Lines: []string{"Enjoy!"}},
+	}
+	assert.Equal(t, want, parts)
+}
+
+func TestReadBlameBlob(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# Gogs\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "logo.bin"), []byte{0x89, 'P', 'N', 'G', 0, 0, 0, 0}, 0o644))
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"add", "."},
+		{"-c", "user.name=gogs", "-c", "user.email=gogs@example.com", "commit", "-q", "-m", "init"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+
+	repo, err := git.Open(dir)
+	require.NoError(t, err)
+	commit, err := repo.CatFileCommit("HEAD")
+	require.NoError(t, err)
+	blob := func(path string) *git.Blob {
+		entry, err := commit.TreeEntry(path)
+		require.NoError(t, err)
+		return entry.Blob()
+	}
+
+	p, err := ReadBlameBlob(blob("README.md"), 1024)
+	require.NoError(t, err)
+	assert.Equal(t, "# Gogs\n", string(p))
+
+	_, err = ReadBlameBlob(blob("README.md"), 7)
+	assert.Equal(t, ErrBlameFileTooLarge, err)
+	_, err = ReadBlameBlob(blob("logo.bin"), 1024)
+	assert.Equal(t, ErrBlameBinaryFile, err)
+}
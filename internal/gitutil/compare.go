@@ -0,0 +1,182 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package gitutil
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gogs/git-module"
+
+	"gogs.io/gogs/internal/lazyregexp"
+)
+
+// CompareRange is a range of revisions to compare in the form of
+// "<base>...<head>" or "<base>..<head>".
+type CompareRange struct {
+	Base string
+	Head string
+	// Direct is true for the two-dot form, which compares the head with the base
+	// directly instead of with their merge base.
+	Direct bool
+}
+
+// Separator returns the separator between the base and the head.
+func (r *CompareRange) Separator() string {
+	if r.Direct {
+		return ".."
+	}
+	return "..."
+}
+
+// String returns the range in its textual form.
+func (r *CompareRange) String() string {
+	return r.Base + r.Separator() + r.Head
+}
+
+// commitIDPattern matches full commit IDs.
+var commitIDPattern = lazyregexp.New(`^[0-9a-f]{40}$`)
+
+// ParseCompareRange parses the range of revisions to compare. It returns false
+// if the range is malformed.
+//
+// A three-dot range of two full commit IDs is compared directly, because such
+// links are generated for pushes, where the old commit may not be an ancestor
+// of the new commit after a force push.
+func ParseCompareRange(s string) (*CompareRange, bool) {
+	r := new(CompareRange)
+	var found bool
+	if r.Base, r.Head, found = strings.Cut(s, "..."); !found {
+		if r.Base, r.Head, found = strings.Cut(s, ".."); !found {
+			return nil, false
+		}
+		r.Direct = true
+	}
+
+	// Revisions are passed to Git commands, so they must not look like options.
+	for _, rev := range []string{r.Base, r.Head} {
+		if rev == "" || strings.HasPrefix(rev, "-") || strings.Contains(rev, "..") {
+			return nil, false
+		}
+	}
+
+	if commitIDPattern.MatchString(r.Base) && commitIDPattern.MatchString(r.Head) {
+		r.Direct = true
+	}
+	return r, true
+}
+
+// Comparison contains resolved commits of a range of revisions.
+type Comparison struct {
+	*CompareRange
+	BaseCommitID string
+	HeadCommitID string
+	// MergeBase is the best common ancestor of the base and the head, which is
+	// empty for direct comparisons.
+	MergeBase string
+}
+
+// DiffBase returns the commit to compute the diff against the head.
+func (c *Comparison) DiffBase() string {
+	if c.Direct {
+		return c.BaseCommitID
+	}
+	return c.MergeBase
+}
+
+// Compare resolves the base and the head of the range to commits, which can be
+// branches, tags or (short) commit IDs. It returns git.ErrRevisionNotExist if
+// either of them does not exist, or git.ErrNoMergeBase if they have no common
+// ancestor for a three-dot comparison.
+func Compare(gitRepo *git.Repository, r *CompareRange, timeout time.Duration) (*Comparison, error) {
+	c := &Comparison{CompareRange: r}
+
+	var err error
+	c.BaseCommitID, err = gitRepo.RevParse(r.Base+"^{commit}", git.RevParseOptions{Timeout: timeout})
+	if err != nil {
+		return nil, err
+	}
+	c.HeadCommitID, err = gitRepo.RevParse(r.Head+"^{commit}", git.RevParseOptions{Timeout: timeout})
+	if err != nil {
+		return nil, err
+	}
+
+	if !r.Direct {
+		c.MergeBase, err = gitRepo.MergeBase(c.BaseCommitID, c.HeadCommitID, git.MergeBaseOptions{Timeout: timeout})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Limits of a comparison to be responded.
+const (
+	// CompareMaxCommits is the maximum number of commits to be listed.
+	CompareMaxCommits = 250
+	// CompareMaxRawDiffSize is the maximum size in bytes of the raw patch or
+	// diff.
+	CompareMaxRawDiffSize = 32 << 20
+)
+
+// Commits returns up to maxCount commits that are reachable from the head but
+// not from the base, in reverse chronological order.
+func (c *Comparison) Commits(gitRepo *git.Repository, maxCount int, timeout time.Duration) ([]*git.Commit, error) {
+	return gitRepo.Log(c.BaseCommitID+".."+c.HeadCommitID, git.LogOptions{MaxCount: maxCount, Timeout: timeout})
+}
+
+// NumCommits returns the total number of commits that are reachable from the
+// head but not from the base.
+func (c *Comparison) NumCommits(gitRepo *git.Repository, timeout time.Duration) (int64, error) {
+	return gitRepo.RevListCount([]string{c.BaseCommitID + ".." + c.HeadCommitID}, git.RevListCountOptions{Timeout: timeout})
+}
+
+// ErrRawDiffTooLarge is returned when the raw comparison exceeds the maximum
+// size.
+var ErrRawDiffTooLarge = errors.New("raw diff is too large")
+
+// limitedBuffer is a buffer that accepts at most max bytes. The buffer is not
+// embedded, otherwise its ReadFrom would be used by io.Copy to bypass the
+// limit.
+type limitedBuffer struct {
+	buf      bytes.Buffer
+	max      int64
+	exceeded bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if int64(b.buf.Len()+len(p)) > b.max {
+		b.exceeded = true
+		return 0, ErrRawDiffTooLarge
+	}
+	return b.buf.Write(p)
+}
+
+// RawDiff returns the comparison in the given format. The patch format
+// contains a mailbox of each commit of the comparison, and the diff format is
+// a single diff against the diff base. It returns ErrRawDiffTooLarge when the
+// output exceeds maxSize bytes, which stops the command early.
+func (c *Comparison) RawDiff(repoPath string, format git.RawDiffFormat, maxSize int64, timeout time.Duration) ([]byte, error) {
+	var cmd *git.Command
+	switch format {
+	case git.RawDiffPatch:
+		cmd = git.NewCommand("format-patch", "--no-signature", "--stdout", c.BaseCommitID+".."+c.HeadCommitID)
+	default:
+		cmd = git.NewCommand("diff", "--full-index", c.DiffBase(), c.HeadCommitID)
+	}
+
+	stdout := &limitedBuffer{max: maxSize}
+	stderr := new(bytes.Buffer)
+	err := cmd.RunInDirPipelineWithTimeout(timeout, stdout, stderr, repoPath)
+	if stdout.exceeded {
+		return nil, ErrRawDiffTooLarge
+	} else if err != nil {
+		return nil, fmt.Errorf("%v - %s", err, stderr)
+	}
+	return stdout.buf.Bytes(), nil
+}
@@ -0,0 +1,104 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package gitutil
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gogs/git-module"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCompareRange(t *testing.T) {
+	tests := []struct {
+		spec   string
+		want   *CompareRange
+		wantOK bool
+	}{
+		{spec: "master...feature", want: &CompareRange{Base: "master", Head: "feature"}, wantOK: true},
+		{spec: "v1.0..a1b2c3d", want: &CompareRange{Base: "v1.0", Head: "a1b2c3d", Direct: true}, wantOK: true},
+		{spec: "release/1.x...user:fix/bug", want: &CompareRange{Base: "release/1.x", Head: "user:fix/bug"}, wantOK: true},
+		{
+			spec:   "1111111111111111111111111111111111111111...2222222222222222222222222222222222222222",
+			want:   &CompareRange{Base: "1111111111111111111111111111111111111111", Head: "2222222222222222222222222222222222222222", Direct: true},
+			wantOK: true,
+		},
+		{spec: "master"},
+		{spec: "...feature"},
+		{spec: "master.."},
+		{spec: "--output=x...master"},
+		{spec: "a...b...c"},
+	}
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			got, ok := ParseCompareRange(test.spec)
+			assert.Equal(t, test.wantOK, ok)
+			assert.Equal(t, test.want, got)
+			if ok {
+				assert.Equal(t, test.want.Base+test.want.Separator()+test.want.Head, got.String())
+			}
+		})
+	}
+}
+
+func TestComparison_DiffBase(t *testing.T) {
+	c := &Comparison{
+		CompareRange: &CompareRange{Base: "master", Head: "feature"},
+		BaseCommitID: "base",
+		HeadCommitID: "head",
+		MergeBase:    "merge-base",
+	}
+	assert.Equal(t, "merge-base", c.DiffBase())
+
+	c.Direct = true
+	assert.Equal(t, "base", c.DiffBase())
+}
+
+func TestComparison_Limits(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not available")
+	}
+
+	dir := t.TempDir()
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(out))
+	}
+	run("init", "-q")
+	for i := 1; i <= 3; i++ {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte(fmt.Sprintf("Version %d\n", i)), 0o644))
+		run("add", ".")
+		run("-c", "user.name=gogs", "-c", "user.email=gogs@example.com", "commit", "-q", "-m", fmt.Sprintf("v%d", i))
+	}
+
+	repo, err := git.Open(dir)
+	require.NoError(t, err)
+	r, ok := ParseCompareRange("HEAD~2...HEAD")
+	require.True(t, ok)
+	cmp, err := Compare(repo, r, time.Minute)
+	require.NoError(t, err)
+
+	commits, err := cmp.Commits(repo, 1, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	assert.Equal(t, cmp.HeadCommitID, commits[0].ID.String())
+	total, err := cmp.NumCommits(repo, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+
+	p, err := cmp.RawDiff(dir, git.RawDiffNormal, 1<<20, time.Minute)
+	require.NoError(t, err)
+	assert.Contains(t, string(p), "+Version 3")
+	_, err = cmp.RawDiff(dir, git.RawDiffPatch, 16, time.Minute)
+	assert.Equal(t, ErrRawDiffTooLarge, err)
+}
@@ -10,8 +10,10 @@ import (
 	"html"
 	"html/template"
 	"io"
+	"strings"
 	"sync"
 
+	"github.com/alecthomas/chroma/v2"
 	"github.com/sergi/go-diff/diffmatchpatch"
 	"golang.org/x/net/html/charset"
 	"golang.org/x/text/transform"
@@ -19,6 +21,7 @@ import (
 	"github.com/gogs/git-module"
 
 	"gogs.io/gogs/internal/conf"
+	"gogs.io/gogs/internal/linguist"
 	"gogs.io/gogs/internal/template/highlight"
 	"gogs.io/gogs/internal/tool"
 )
@@ -29,11 +32,82 @@ type DiffSection struct {
 
 	initOnce sync.Once
 	dmp      *diffmatchpatch.DiffMatchPatch
+
+	lexer         chroma.Lexer
+	highlightOnce sync.Once
+	spans         map[*git.DiffLine][]highlight.Span
+}
+
+// highlight tokenises the old and the new code of the section as a whole, so
+// that tokens spanning lines (e.g. block comments) are highlighted properly.
+// Plain lines take spans of the new code.
+func (s *DiffSection) highlight() {
+	s.spans = make(map[*git.DiffLine][]highlight.Span, len(s.Lines))
+	for _, side := range []git.DiffLineType{git.DiffLineDelete, git.DiffLineAdd} {
+		var lines []*git.DiffLine
+		var code strings.Builder
+		for _, line := range s.Lines {
+			if line.Type != git.DiffLinePlain && line.Type != side {
+				continue
+			}
+			if len(lines) > 0 {
+				code.WriteByte('\n')
+			}
+			lines = append(lines, line)
+			if len(line.Content) > 0 {
+				code.WriteString(line.Content[1:])
+			}
+		}
+		if len(lines) == 0 {
+			continue
+		}
+
+		for i, spans := range highlight.LineSpans(s.lexer, code.String()) {
+			s.spans[lines[i]] = spans
+		}
+	}
+}
+
+// lineSpans returns highlighted spans of the line without the leading sign. It
+// returns a single plain span if the line is not highlighted.
+func (s *DiffSection) lineSpans(line *git.DiffLine) []highlight.Span {
+	s.highlightOnce.Do(s.highlight)
+
+	var content string
+	if len(line.Content) > 0 {
+		content = line.Content[1:]
+	}
+
+	// Spans must cover the content exactly to be merged with the inline diff.
+	spans, ok := s.spans[line]
+	if ok {
+		var n int
+		for _, span := range spans {
+			n += len(span.Text)
+		}
+		ok = n == len(content)
+	}
+	if !ok {
+		return []highlight.Span{{Text: content}}
+	}
+	return spans
+}
+
+// highlightLine returns the line with its content highlighted by the lexer
+// of the file, the leading sign is kept as is.
+func (s *DiffSection) highlightLine(line *git.DiffLine) template.HTML {
+	switch line.Type {
+	case git.DiffLineAdd, git.DiffLineDelete, git.DiffLinePlain:
+		if len(line.Content) > 0 {
+			return diffsToHTML(s.lineSpans(line), nil, line)
+		}
+	}
+	return template.HTML(html.EscapeString(line.Content))
 }
 
 // ComputedInlineDiffFor computes inline diff for the given line.
 func (s *DiffSection) ComputedInlineDiffFor(line *git.DiffLine) template.HTML {
-	fallback := template.HTML(html.EscapeString(line.Content))
+	fallback := s.highlightLine(line)
 	if conf.Git.DisableDiffHighlight {
 		return fallback
 	}
@@ -71,19 +145,16 @@ func (s *DiffSection) ComputedInlineDiffFor(line *git.DiffLine) template.HTML {
 	diffs := s.dmp.DiffMain(diff1[1:], diff2[1:], true)
 	diffs = s.dmp.DiffCleanupEfficiency(diffs)
 
-	return diffsToHTML(diffs, line.Type)
+	return diffsToHTML(s.lineSpans(line), diffs, line)
 }
 
-func diffsToHTML(diffs []diffmatchpatch.Diff, lineType git.DiffLineType) template.HTML {
+// diffsToHTML renders highlighted spans of the line, where parts that are
+// inserted or deleted by the inline diffs are marked as added or removed code.
+func diffsToHTML(spans []highlight.Span, diffs []diffmatchpatch.Diff, line *git.DiffLine) template.HTML {
 	buf := bytes.NewBuffer(nil)
 
 	// Reproduce signs which are cutted for inline diff before.
-	switch lineType {
-	case git.DiffLineAdd:
-		buf.WriteByte('+')
-	case git.DiffLineDelete:
-		buf.WriteByte('-')
-	}
+	buf.WriteString(html.EscapeString(line.Content[:1]))
 
 	const (
 		addedCodePrefix   = `<span class="added-code">`
@@ -91,18 +162,53 @@ func diffsToHTML(diffs []diffmatchpatch.Diff, lineType git.DiffLineType) templat
 		codeTagSuffix     = `</span>`
 	)
 
+	// Collect parts of the line that are changed, in the order of the line.
+	type change struct {
+		text   string
+		prefix string
+	}
+	changes := make([]change, 0, len(diffs))
 	for i := range diffs {
 		switch {
-		case diffs[i].Type == diffmatchpatch.DiffInsert && lineType == git.DiffLineAdd:
-			buf.WriteString(addedCodePrefix)
-			buf.WriteString(html.EscapeString(diffs[i].Text))
-			buf.WriteString(codeTagSuffix)
-		case diffs[i].Type == diffmatchpatch.DiffDelete && lineType == git.DiffLineDelete:
-			buf.WriteString(removedCodePrefix)
-			buf.WriteString(html.EscapeString(diffs[i].Text))
-			buf.WriteString(codeTagSuffix)
+		case diffs[i].Type == diffmatchpatch.DiffInsert && line.Type == git.DiffLineAdd:
+			changes = append(changes, change{text: diffs[i].Text, prefix: addedCodePrefix})
+		case diffs[i].Type == diffmatchpatch.DiffDelete && line.Type == git.DiffLineDelete:
+			changes = append(changes, change{text: diffs[i].Text, prefix: removedCodePrefix})
 		case diffs[i].Type == diffmatchpatch.DiffEqual:
-			buf.WriteString(html.EscapeString(diffs[i].Text))
+			changes = append(changes, change{text: diffs[i].Text})
+		}
+	}
+	if len(changes) == 0 {
+		changes = append(changes, change{text: line.Content[1:]})
+	}
+
+	// Split spans at boundaries of changes, both cover the same content.
+	var c int
+	for _, span := range spans {
+		text := span.Text
+		for text != "" && c < len(changes) {
+			n := len(changes[c].text)
+			if n > len(text) {
+				n = len(text)
+			}
+
+			part := highlight.Span{Class: span.Class, Text: text[:n]}
+			if changes[c].prefix != "" {
+				buf.WriteString(changes[c].prefix)
+				buf.WriteString(part.HTML())
+				buf.WriteString(codeTagSuffix)
+			} else {
+				buf.WriteString(part.HTML())
+			}
+
+			text = text[n:]
+			changes[c].text = changes[c].text[n:]
+			if changes[c].text == "" {
+				c++
+			}
+		}
+		if text != "" {
+			buf.WriteString(html.EscapeString(text))
 		}
 	}
 
@@ -115,19 +221,33 @@ type DiffFile struct {
 	Sections []*DiffSection
 }
 
-// HighlightClass returns the detected highlight class for the file.
-func (diffFile *DiffFile) HighlightClass() string {
-	return highlight.FileNameToHighlightClass(diffFile.Name)
-}
-
 // Diff is a wrapper to git.Diff with helper methods.
 type Diff struct {
 	*git.Diff
 	Files []*DiffFile
 }
 
-// NewDiff returns a new wrapper of given git.Diff.
-func NewDiff(oldDiff *git.Diff) *Diff {
+// firstLine returns the first line of the file if it is in the diff, which
+// may contain a shebang to detect the language of the file.
+func firstLine(file *git.DiffFile) []byte {
+	if len(file.Sections) == 0 {
+		return nil
+	}
+	for _, line := range file.Sections[0].Lines {
+		if len(line.Content) == 0 {
+			continue
+		}
+		if (line.Type != git.DiffLineDelete && line.RightLine == 1) ||
+			(line.Type == git.DiffLineDelete && line.LeftLine == 1 && file.IsDeleted()) {
+			return []byte(line.Content[1:])
+		}
+	}
+	return nil
+}
+
+// NewDiff returns a new wrapper of given git.Diff. Lexers of files for syntax
+// highlighting respect the linguist-language attribute when attrs is not nil.
+func NewDiff(oldDiff *git.Diff, attrs *linguist.Attributes) *Diff {
 	newDiff := &Diff{
 		Diff:  oldDiff,
 		Files: make([]*DiffFile, oldDiff.NumFiles()),
@@ -143,9 +263,15 @@ func NewDiff(oldDiff *git.Diff) *Diff {
 			Sections: make([]*DiffSection, oldDiff.Files[i].NumSections()),
 		}
 
+		var lexer chroma.Lexer
+		if !oldDiff.Files[i].IsBinary() {
+			name := oldDiff.Files[i].Name
+			lexer = highlight.Lexer(name, attrs.Language(name), firstLine(oldDiff.Files[i]))
+		}
 		for j := range oldDiff.Files[i].Sections {
 			newDiff.Files[i].Sections[j] = &DiffSection{
 				DiffSection: oldDiff.Files[i].Sections[j],
+				lexer:       lexer,
 			}
 
 			for k := range newDiff.Files[i].Sections[j].Lines {
@@ -182,14 +308,24 @@ func ParseDiff(r io.Reader, maxFiles, maxFileLines, maxLineChars int) (*Diff, er
 	if result.Err != nil {
 		return nil, fmt.Errorf("stream parse diff: %v", result.Err)
 	}
-	return NewDiff(result.Diff), nil
+	return NewDiff(result.Diff, nil), nil
 }
 
-// RepoDiff parses the diff on given revisions of given repository.
+// RepoDiff parses the diff on given revisions of given repository. The
+// .gitattributes files of the revision are respected for syntax highlighting.
 func RepoDiff(repo *git.Repository, rev string, maxFiles, maxFileLines, maxLineChars int, opts ...git.DiffOptions) (*Diff, error) {
 	diff, err := repo.Diff(rev, maxFiles, maxFileLines, maxLineChars, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("get diff: %v", err)
 	}
-	return NewDiff(diff), nil
+
+	var attrs *linguist.Attributes
+	if commit, err := repo.CatFileCommit(rev); err == nil {
+		paths := make([]string, 0, len(diff.Files))
+		for _, f := range diff.Files {
+			paths = append(paths, f.Name)
+		}
+		attrs = ReadAttributes(commit, paths...)
+	}
+	return NewDiff(diff, attrs), nil
 }
@@ -12,38 +12,110 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/gogs/git-module"
+
+	"gogs.io/gogs/internal/template/highlight"
 )
 
 func Test_diffsToHTML(t *testing.T) {
 	tests := []struct {
-		diffs    []dmp.Diff
-		lineType git.DiffLineType
-		expHTML  template.HTML
+		name    string
+		spans   []highlight.Span
+		diffs   []dmp.Diff
+		line    *git.DiffLine
+		expHTML template.HTML
 	}{
 		{
+			name: "added",
+			spans: []highlight.Span{
+				{Text: "foo bar biz"},
+			},
 			diffs: []dmp.Diff{
 				{Type: dmp.DiffEqual, Text: "foo "},
 				{Type: dmp.DiffInsert, Text: "bar"},
 				{Type: dmp.DiffDelete, Text: " baz"},
 				{Type: dmp.DiffEqual, Text: " biz"},
 			},
-			lineType: git.DiffLineAdd,
-			expHTML:  template.HTML(`+foo <span class="added-code">bar</span> biz`),
+			line:    &git.DiffLine{Type: git.DiffLineAdd, Content: "+foo bar biz"},
+			expHTML: template.HTML(`+foo <span class="added-code">bar</span> biz`),
 		},
 		{
+			name: "removed",
+			spans: []highlight.Span{
+				{Text: "foo bar biz"},
+			},
 			diffs: []dmp.Diff{
 				{Type: dmp.DiffEqual, Text: "foo "},
 				{Type: dmp.DiffDelete, Text: "bar"},
 				{Type: dmp.DiffInsert, Text: " baz"},
 				{Type: dmp.DiffEqual, Text: " biz"},
 			},
-			lineType: git.DiffLineDelete,
-			expHTML:  template.HTML(`-foo <span class="removed-code">bar</span> biz`),
+			line:    &git.DiffLine{Type: git.DiffLineDelete, Content: "-foo bar biz"},
+			expHTML: template.HTML(`-foo <span class="removed-code">bar</span> biz`),
+		},
+		{
+			name: "highlighted",
+			spans: []highlight.Span{
+				{Class: "kd", Text: "var"},
+				{Text: " "},
+				{Class: "nx", Text: "ab"},
+				{Text: " "},
+				{Class: "o", Text: "<"},
+			},
+			diffs: []dmp.Diff{
+				{Type: dmp.DiffEqual, Text: "var a"},
+				{Type: dmp.DiffInsert, Text: "b <"},
+			},
+			line: &git.DiffLine{Type: git.DiffLineAdd, Content: "+var ab <"},
+			expHTML: template.HTML(`+<span class="kd">var</span> <span class="nx">a</span>` +
+				`<span class="added-code"><span class="nx">b</span></span><span class="added-code"> </span>` +
+				`<span class="added-code"><span class="o">&lt;</span></span>`),
+		},
+		{
+			name: "no diffs",
+			spans: []highlight.Span{
+				{Class: "k", Text: "return"},
+			},
+			line:    &git.DiffLine{Type: git.DiffLinePlain, Content: " return"},
+			expHTML: template.HTML(` <span class="k">return</span>`),
 		},
 	}
 	for _, test := range tests {
-		t.Run("", func(t *testing.T) {
-			assert.Equal(t, test.expHTML, diffsToHTML(test.diffs, test.lineType))
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expHTML, diffsToHTML(test.spans, test.diffs, test.line))
 		})
 	}
 }
+
+func TestDiffSection_highlightLine(t *testing.T) {
+	lines := []*git.DiffLine{
+		{Type: git.DiffLinePlain, Content: " /* Start of"},
+		{Type: git.DiffLineDelete, Content: "-   old comment */"},
+		{Type: git.DiffLineAdd, Content: "+   new comment */"},
+		{Type: git.DiffLinePlain, Content: " func"},
+	}
+	s := &DiffSection{
+		DiffSection: &git.DiffSection{Lines: lines},
+		lexer:       highlight.Lexer("main.go", "", nil),
+	}
+	assert.Equal(t, template.HTML(`-<span class="cm">   old comment */</span>`), s.highlightLine(lines[1]))
+	assert.Equal(t, template.HTML(`+<span class="cm">   new comment */</span>`), s.highlightLine(lines[2]))
+	assert.Equal(t, template.HTML(` <span class="kd">func</span>`), s.highlightLine(lines[3]))
+}
+
+func Test_firstLine(t *testing.T) {
+	file := &git.DiffFile{
+		Sections: []*git.DiffSection{
+			{
+				Lines: []*git.DiffLine{
+					{Type: git.DiffLineSection, Content: "@@ -1,1 +1,1 @@"},
+					{Type: git.DiffLineDelete, Content: "-#!/bin/sh", LeftLine: 1},
+					{Type: git.DiffLineAdd, Content: "+#!/usr/bin/env python3", RightLine: 1},
+				},
+			},
+		},
+	}
+	assert.Equal(t, "#!/usr/bin/env python3", string(firstLine(file)))
+
+	file.Sections[0].Lines[2].RightLine = 10
+	assert.Nil(t, firstLine(file))
+}
@@ -0,0 +1,219 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package gitutil
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogs/git-module"
+	"github.com/pkg/errors"
+)
+
+// GraphCommit contains information of a commit in the commit graph.
+type GraphCommit struct {
+	ID          string
+	ParentIDs   []string
+	Author      string
+	AuthorEmail string
+	AuthorTime  time.Time
+	Subject     string
+	// Branches and Tags are short names of refs pointing to the commit.
+	Branches []string
+	Tags     []string
+}
+
+// Levels of an end of a graph edge within a row.
+const (
+	GraphLevelTop    = 0
+	GraphLevelMiddle = 1
+	GraphLevelBottom = 2
+)
+
+// GraphEdge is a line segment of the commit graph within a row, which connects
+// a column at one level to another.
+type GraphEdge struct {
+	FromColumn int
+	FromLevel  int
+	ToColumn   int
+	ToLevel    int
+	// Lane is the column that the edge belongs to, which is used to pick colors.
+	Lane int
+}
+
+// GraphRow is a row of the commit graph, the commit is drawn at the middle of
+// its column.
+type GraphRow struct {
+	Commit *GraphCommit
+	Column int
+	Edges  []*GraphEdge
+	// Width is the number of columns used by the row.
+	Width int
+}
+
+// graphSeparator separates fields of a commit in the output of "git log".
+const graphSeparator = "\x1f"
+
+// CommitGraph returns rows of the commit graph of the branch in date order, or
+// of all branches if the branch is empty. At most maxCount commits are returned.
+func CommitGraph(repoPath, branch string, maxCount int, timeout time.Duration) ([]*GraphRow, error) {
+	args := []string{
+		"log",
+		"--date-order",
+		"--decorate=short",
+		"--max-count=" + strconv.Itoa(maxCount),
+		"--format=" + strings.Join([]string{"%H", "%P", "%an", "%ae", "%at", "%D", "%s"}, graphSeparator),
+	}
+	if branch == "" {
+		args = append(args, "--branches")
+	} else {
+		args = append(args, "refs/heads/"+branch)
+	}
+	args = append(args, "--")
+
+	stdout, err := git.NewCommand(args...).RunInDirWithTimeout(timeout, repoPath)
+	if err != nil {
+		return nil, err
+	}
+	commits, err := parseGraphLog(bytes.NewReader(stdout))
+	if err != nil {
+		return nil, err
+	}
+	return layoutGraph(commits), nil
+}
+
+// parseGraphLog parses output of "git log" with fields of each commit separated
+// by graphSeparator in a single line.
+func parseGraphLog(r io.Reader) ([]*GraphCommit, error) {
+	var commits []*GraphCommit
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		fields := strings.SplitN(line, graphSeparator, 7)
+		if len(fields) != 7 {
+			return nil, errors.Errorf("unexpected line %q", line)
+		}
+		unix, err := strconv.ParseInt(fields[4], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse author time %q", fields[4])
+		}
+
+		c := &GraphCommit{
+			ID:          fields[0],
+			ParentIDs:   strings.Fields(fields[1]),
+			Author:      fields[2],
+			AuthorEmail: fields[3],
+			AuthorTime:  time.Unix(unix, 0),
+			Subject:     fields[6],
+		}
+		for _, ref := range strings.Split(fields[5], ", ") {
+			ref = strings.TrimPrefix(ref, "HEAD -> ")
+			switch {
+			case ref == "" || ref == "HEAD":
+			case strings.HasPrefix(ref, "tag: "):
+				c.Tags = append(c.Tags, strings.TrimPrefix(ref, "tag: "))
+			default:
+				c.Branches = append(c.Branches, ref)
+			}
+		}
+		commits = append(commits, c)
+	}
+	return commits, scanner.Err()
+}
+
+// layoutGraph assigns columns to commits and computes edges between them. Each
+// column is a lane that is waiting for a commit, children must come before
+// their parents in the list.
+func layoutGraph(commits []*GraphCommit) []*GraphRow {
+	indexOf := func(lanes []string, id string) int {
+		for i := range lanes {
+			if lanes[i] == id {
+				return i
+			}
+		}
+		return -1
+	}
+
+	var lanes []string
+	rows := make([]*GraphRow, 0, len(commits))
+	for _, c := range commits {
+		col := indexOf(lanes, c.ID)
+		if col == -1 {
+			col = indexOf(lanes, "")
+			if col == -1 {
+				lanes = append(lanes, "")
+				col = len(lanes) - 1
+			}
+		}
+
+		row := &GraphRow{
+			Commit: c,
+			Column: col,
+			Width:  len(lanes),
+		}
+		for i, id := range lanes {
+			switch id {
+			case "":
+			case c.ID:
+				// All lanes waiting for the commit end at it.
+				row.Edges = append(row.Edges, &GraphEdge{
+					FromColumn: i,
+					FromLevel:  GraphLevelTop,
+					ToColumn:   col,
+					ToLevel:    GraphLevelMiddle,
+					Lane:       i,
+				})
+				lanes[i] = ""
+			default:
+				row.Edges = append(row.Edges, &GraphEdge{
+					FromColumn: i,
+					FromLevel:  GraphLevelTop,
+					ToColumn:   i,
+					ToLevel:    GraphLevelBottom,
+					Lane:       i,
+				})
+			}
+		}
+
+		for i, parentID := range c.ParentIDs {
+			next := indexOf(lanes, parentID)
+			if next == -1 {
+				// The first parent continues the lane of the commit whenever possible.
+				if i == 0 && lanes[col] == "" {
+					next = col
+				} else if next = indexOf(lanes, ""); next == -1 {
+					lanes = append(lanes, "")
+					next = len(lanes) - 1
+				}
+				lanes[next] = parentID
+			}
+			row.Edges = append(row.Edges, &GraphEdge{
+				FromColumn: col,
+				FromLevel:  GraphLevelMiddle,
+				ToColumn:   next,
+				ToLevel:    GraphLevelBottom,
+				Lane:       next,
+			})
+		}
+
+		if len(lanes) > row.Width {
+			row.Width = len(lanes)
+		}
+		for len(lanes) > 0 && lanes[len(lanes)-1] == "" {
+			lanes = lanes[:len(lanes)-1]
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
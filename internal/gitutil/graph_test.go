@@ -0,0 +1,76 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package gitutil
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGraphLog(t *testing.T) {
+	output := strings.Join([]string{
+		"c\x1fa b\x1fAlice\x1falice@example.com\x1f1700000000\x1fHEAD -> master, tag: v1.0, feature\x1fMerge branch 'feature'",
+		"b\x1fa\x1fBob\x1fbob@example.com\x1f1600000000\x1f\x1fAdd feature",
+		"",
+	}, "\n")
+
+	commits, err := parseGraphLog(strings.NewReader(output))
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+
+	assert.Equal(t, &GraphCommit{
+		ID:          "c",
+		ParentIDs:   []string{"a", "b"},
+		Author:      "Alice",
+		AuthorEmail: "alice@example.com",
+		AuthorTime:  time.Unix(1700000000, 0),
+		Subject:     "Merge branch 'feature'",
+		Branches:    []string{"master", "feature"},
+		Tags:        []string{"v1.0"},
+	}, commits[0])
+	assert.Empty(t, commits[1].Branches)
+	assert.Empty(t, commits[1].Tags)
+
+	_, err = parseGraphLog(strings.NewReader("bad line"))
+	assert.Error(t, err)
+}
+
+func TestLayoutGraph(t *testing.T) {
+	// d is a merge of c (first parent) and b, both of which are based on a.
+	rows := layoutGraph([]*GraphCommit{
+		{ID: "d", ParentIDs: []string{"c", "b"}},
+		{ID: "c", ParentIDs: []string{"a"}},
+		{ID: "b", ParentIDs: []string{"a"}},
+		{ID: "a"},
+	})
+	require.Len(t, rows, 4)
+
+	columns := make([]int, len(rows))
+	widths := make([]int, len(rows))
+	for i := range rows {
+		columns[i] = rows[i].Column
+		widths[i] = rows[i].Width
+	}
+	assert.Equal(t, []int{0, 0, 1, 0}, columns)
+	assert.Equal(t, []int{2, 2, 2, 1}, widths)
+
+	assert.Equal(t, []*GraphEdge{
+		{FromColumn: 0, FromLevel: GraphLevelMiddle, ToColumn: 0, ToLevel: GraphLevelBottom, Lane: 0},
+		{FromColumn: 0, FromLevel: GraphLevelMiddle, ToColumn: 1, ToLevel: GraphLevelBottom, Lane: 1},
+	}, rows[0].Edges)
+	// b joins the lane of a that continues the lane of c.
+	assert.Equal(t, []*GraphEdge{
+		{FromColumn: 0, FromLevel: GraphLevelTop, ToColumn: 0, ToLevel: GraphLevelBottom, Lane: 0},
+		{FromColumn: 1, FromLevel: GraphLevelTop, ToColumn: 1, ToLevel: GraphLevelMiddle, Lane: 1},
+		{FromColumn: 1, FromLevel: GraphLevelMiddle, ToColumn: 0, ToLevel: GraphLevelBottom, Lane: 0},
+	}, rows[2].Edges)
+	assert.Equal(t, []*GraphEdge{
+		{FromColumn: 0, FromLevel: GraphLevelTop, ToColumn: 0, ToLevel: GraphLevelMiddle, Lane: 0},
+	}, rows[3].Edges)
+}
@@ -0,0 +1,91 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package gitutil
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogs/git-module"
+	"github.com/pkg/errors"
+)
+
+// FileHistoryEntry is a commit that changed a file, along with the path of the
+// file in the commit.
+type FileHistoryEntry struct {
+	CommitID string
+	// Status is the first letter of the change status, e.g. "A" for added, "M"
+	// for modified and "R" for renamed.
+	Status string
+	Path   string
+	// OldPath is the path of the file before the commit, which is only set when
+	// the file was renamed or copied.
+	OldPath string
+}
+
+// FollowFileHistory returns commits that changed the file starting from given
+// revision, following renames of the file. It skips the number of commits
+// before returning at most maxCount commits.
+func FollowFileHistory(repoPath, rev, file string, skip, maxCount int, timeout time.Duration) ([]*FileHistoryEntry, error) {
+	stdout, err := git.NewCommand(
+		"log",
+		"--follow",
+		"--name-status",
+		"--format=%x1e%H",
+		"--skip="+strconv.Itoa(skip),
+		"--max-count="+strconv.Itoa(maxCount),
+		rev,
+		"--",
+		file,
+	).RunInDirWithTimeout(timeout, repoPath)
+	if err != nil {
+		return nil, err
+	}
+	return parseFollowLog(bytes.NewReader(stdout))
+}
+
+// parseFollowLog parses output of "git log --name-status" where each commit
+// starts with a record separator followed by its ID, and changes of the file
+// are in the form of "<status>\t<path>" or "<status><score>\t<old>\t<new>".
+func parseFollowLog(r io.Reader) ([]*FileHistoryEntry, error) {
+	var entries []*FileHistoryEntry
+	var current *FileHistoryEntry
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "\x1e"):
+			current = &FileHistoryEntry{CommitID: strings.TrimPrefix(line, "\x1e")}
+			entries = append(entries, current)
+		default:
+			if current == nil {
+				return nil, errors.Errorf("unexpected line %q", line)
+			} else if current.Status != "" {
+				// Only the first change is taken when the commit touched the file
+				// in more than one way, e.g. it has multiple parents.
+				continue
+			}
+
+			fields := strings.Split(line, "\t")
+			if len(fields) < 2 || fields[0] == "" {
+				return nil, errors.Errorf("unexpected status line %q", line)
+			}
+			current.Status = fields[0][:1]
+			if len(fields) >= 3 {
+				current.OldPath = fields[1]
+				current.Path = fields[2]
+			} else {
+				current.Path = fields[1]
+			}
+		}
+	}
+	return entries, scanner.Err()
+}
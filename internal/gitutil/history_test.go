@@ -0,0 +1,30 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package gitutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFollowLog(t *testing.T) {
+	output := "\x1ecccc\n\nM\tdocs/guide.md\n" +
+		"\x1ebbbb\n\nR087\tREADME.md\tdocs/guide.md\n" +
+		"\x1eaaaa\n\nA\tREADME.md\n"
+
+	entries, err := parseFollowLog(strings.NewReader(output))
+	require.NoError(t, err)
+	assert.Equal(t, []*FileHistoryEntry{
+		{CommitID: "cccc", Status: "M", Path: "docs/guide.md"},
+		{CommitID: "bbbb", Status: "R", Path: "docs/guide.md", OldPath: "README.md"},
+		{CommitID: "aaaa", Status: "A", Path: "README.md"},
+	}, entries)
+
+	_, err = parseFollowLog(strings.NewReader("M\tREADME.md\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package gitutil
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gogs/git-module"
+)
+
+// TreeBlob is a regular file of a tree.
+type TreeBlob struct {
+	ID   string
+	Path string
+	Size int64
+}
+
+// ListTreeBlobs returns all regular files of the tree of the revision
+// recursively, symbolic links and submodules are excluded.
+func ListTreeBlobs(repoPath, rev string, timeout time.Duration) ([]*TreeBlob, error) {
+	stdout, err := git.NewCommand("ls-tree", "-r", "-l", "-z", rev).RunInDirWithTimeout(timeout, repoPath)
+	if err != nil {
+		return nil, err
+	}
+	return parseTreeBlobs(stdout), nil
+}
+
+// parseTreeBlobs parses output of "git ls-tree -r -l -z", each entry is in
+// the format of "<mode> SP <type> SP <object> SP+ <size> TAB <path>".
+func parseTreeBlobs(stdout []byte) []*TreeBlob {
+	var blobs []*TreeBlob
+	for _, entry := range bytes.Split(stdout, []byte{0}) {
+		info, path, ok := bytes.Cut(entry, []byte{'\t'})
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(string(info))
+		if len(fields) != 4 || fields[1] != "blob" || fields[0] == "120000" {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[3], 10, 64)
+		if err != nil {
+			continue
+		}
+		blobs = append(blobs, &TreeBlob{ID: fields[2], Path: string(path), Size: size})
+	}
+	return blobs
+}
@@ -0,0 +1,24 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package gitutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTreeBlobs(t *testing.T) {
+	stdout := "100644 blob 1111111111111111111111111111111111111111     120\tREADME.md\x00" +
+		"120000 blob 2222222222222222222222222222222222222222      9\tlink\x00" +
+		"160000 commit 3333333333333333333333333333333333333333       -\tsubmodule\x00" +
+		"100755 blob 4444444444444444444444444444444444444444    3047\tscripts/with\ttab.sh\x00"
+
+	want := []*TreeBlob{
+		{ID: "1111111111111111111111111111111111111111", Path: "README.md", Size: 120},
+		{ID: "4444444444444444444444444444444444444444", Path: "scripts/with\ttab.sh", Size: 3047},
+	}
+	assert.Equal(t, want, parseTreeBlobs([]byte(stdout)))
+}
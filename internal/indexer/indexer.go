@@ -0,0 +1,456 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package indexer implements an embedded full-text index that is stored on
+// disk. Documents are looked up by trigrams of their lowercased contents and
+// then verified line by line, so a query matches any substring of a line
+// regardless of case.
+package indexer
+
+import (
+	"compress/gzip"
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// fileVersion is the version of the on-disk format, an index file of a
+// different version is treated as empty and is expected to be rebuilt.
+const fileVersion = 2
+
+// MaxLineLength is the maximum number of bytes of a matched line, longer lines
+// are truncated.
+const MaxLineLength = 256
+
+// Line is a line of a document that matches a query.
+type Line struct {
+	Num     int // 1-based line number
+	Content string
+}
+
+// Match is a document that matches a query.
+type Match struct {
+	ID string
+	// Lines contains up to the maximum number of matched lines requested.
+	Lines []Line
+	// NumLines is the total number of matched lines of the document.
+	NumLines int
+}
+
+type trigram uint32
+
+// Index is a full-text index of documents that is stored as a single file. It
+// is safe for concurrent use.
+type Index struct {
+	path string
+
+	lock sync.RWMutex
+	meta map[string]string
+	docs map[string]string // Document ID -> content
+	// ids and postings are derived from docs, and are built lazily by the next
+	// search after any document is changed. They are saved along with docs, so
+	// that an index read from disk can be searched right away.
+	ids      []string
+	postings map[trigram][]int
+}
+
+// New returns a new empty index that will be saved to the path.
+func New(path string) *Index {
+	return &Index{
+		path: path,
+		meta: make(map[string]string),
+		docs: make(map[string]string),
+	}
+}
+
+// header is the first value of an index file, which can be read without
+// decoding documents.
+type header struct {
+	Version int
+	Meta    map[string]string
+}
+
+// postingsBlock is the last value of an index file, which is empty if the
+// postings were outdated when the index was saved.
+type postingsBlock struct {
+	IDs      []string
+	Postings map[trigram][]int
+}
+
+// readFile reads the index file of the path, documents and postings are only
+// read when the idx is not nil. It returns nil metadata if the file is of a
+// different version.
+func readFile(path string, idx *Index) (meta map[string]string, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, errors.Wrap(err, "new gzip reader")
+	}
+	dec := gob.NewDecoder(r)
+
+	var h header
+	if err = dec.Decode(&h); err != nil {
+		return nil, errors.Wrap(err, "decode header")
+	} else if h.Version != fileVersion {
+		return nil, nil
+	} else if idx == nil {
+		return h.Meta, nil
+	}
+
+	var docs map[string]string
+	if err = dec.Decode(&docs); err != nil {
+		return nil, errors.Wrap(err, "decode documents")
+	}
+	var block postingsBlock
+	if err = dec.Decode(&block); err != nil {
+		return nil, errors.Wrap(err, "decode postings")
+	}
+
+	if h.Meta != nil {
+		idx.meta = h.Meta
+	}
+	if docs != nil {
+		idx.docs = docs
+	}
+	if block.Postings != nil && len(block.IDs) == len(idx.docs) {
+		idx.ids = block.IDs
+		idx.postings = block.Postings
+	}
+	return h.Meta, nil
+}
+
+// Open loads the index from the path, it returns an empty index if the file
+// does not exist or is of a different version.
+func Open(path string) (*Index, error) {
+	idx := New(path)
+	if _, err := readFile(path, idx); err != nil {
+		if os.IsNotExist(err) {
+			return idx, nil
+		}
+		return nil, err
+	}
+	return idx, nil
+}
+
+// ReadMeta returns the metadata of the index file of the path without loading
+// documents. It returns nil if the file does not exist.
+func ReadMeta(path string) (map[string]string, error) {
+	meta, err := readFile(path, nil)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return meta, nil
+}
+
+// Meta returns the metadata value of the key.
+func (idx *Index) Meta(key string) string {
+	idx.lock.RLock()
+	defer idx.lock.RUnlock()
+	return idx.meta[key]
+}
+
+// SetMeta sets the metadata value of the key, metadata is saved along with
+// documents.
+func (idx *Index) SetMeta(key, value string) {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+	idx.meta[key] = value
+}
+
+// Len returns the number of documents in the index.
+func (idx *Index) Len() int {
+	idx.lock.RLock()
+	defer idx.lock.RUnlock()
+	return len(idx.docs)
+}
+
+// Put adds the document with given ID or replaces its content.
+func (idx *Index) Put(id, content string) {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+	idx.docs[id] = content
+	idx.postings = nil
+}
+
+// Delete removes the document with given ID.
+func (idx *Index) Delete(id string) {
+	idx.lock.Lock()
+	defer idx.lock.Unlock()
+	delete(idx.docs, id)
+	idx.postings = nil
+}
+
+// Save writes the index to its file atomically, along with postings that are
+// built if outdated.
+func (idx *Index) Save() (err error) {
+	idx.prepare()
+	defer idx.lock.RUnlock()
+
+	if err = os.MkdirAll(filepath.Dir(idx.path), os.ModePerm); err != nil {
+		return errors.Wrap(err, "create directory")
+	}
+
+	tmpPath := idx.path + ".tmp"
+	f, err := os.Create(tmpPath)
+	if err != nil {
+		return errors.Wrap(err, "create temporary file")
+	}
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(tmpPath)
+		}
+	}()
+
+	w, err := gzip.NewWriterLevel(f, gzip.BestSpeed)
+	if err != nil {
+		return errors.Wrap(err, "new gzip writer")
+	}
+	enc := gob.NewEncoder(w)
+	if err = enc.Encode(header{Version: fileVersion, Meta: idx.meta}); err != nil {
+		return errors.Wrap(err, "encode header")
+	} else if err = enc.Encode(idx.docs); err != nil {
+		return errors.Wrap(err, "encode documents")
+	} else if err = enc.Encode(postingsBlock{IDs: idx.ids, Postings: idx.postings}); err != nil {
+		return errors.Wrap(err, "encode postings")
+	} else if err = w.Close(); err != nil {
+		return errors.Wrap(err, "close gzip writer")
+	} else if err = f.Close(); err != nil {
+		return errors.Wrap(err, "close temporary file")
+	}
+	return os.Rename(tmpPath, idx.path)
+}
+
+// trigrams calls fn with each trigram of s.
+func trigrams(s string, fn func(t trigram)) {
+	for i := 0; i+3 <= len(s); i++ {
+		fn(trigram(s[i])<<16 | trigram(s[i+1])<<8 | trigram(s[i+2]))
+	}
+}
+
+// buildPostings builds postings of documents if they are outdated. It must be
+// called with the write lock held.
+func (idx *Index) buildPostings() {
+	if idx.postings != nil {
+		return
+	}
+
+	idx.ids = make([]string, 0, len(idx.docs))
+	for id := range idx.docs {
+		idx.ids = append(idx.ids, id)
+	}
+	sort.Strings(idx.ids)
+
+	idx.postings = make(map[trigram][]int)
+	for i, id := range idx.ids {
+		trigrams(strings.ToLower(idx.docs[id]), func(t trigram) {
+			list := idx.postings[t]
+			if len(list) > 0 && list[len(list)-1] == i {
+				return
+			}
+			idx.postings[t] = append(list, i)
+		})
+	}
+}
+
+// intersect returns numbers that exist in both sorted lists.
+func intersect(a, b []int) []int {
+	result := make([]int, 0, min(len(a), len(b)))
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// candidates returns numbers of documents that contain all trigrams of the
+// query, or nil if the query is too short to be looked up by trigrams.
+func (idx *Index) candidates(query string) (nums []int, ok bool) {
+	if len(query) < 3 {
+		return nil, false
+	}
+
+	seen := make(map[trigram]bool)
+	var lists [][]int
+	trigrams(query, func(t trigram) {
+		if !seen[t] {
+			seen[t] = true
+			lists = append(lists, idx.postings[t])
+		}
+	})
+	sort.Slice(lists, func(i, j int) bool { return len(lists[i]) < len(lists[j]) })
+
+	nums = lists[0]
+	for _, list := range lists[1:] {
+		if len(nums) == 0 {
+			break
+		}
+		nums = intersect(nums, list)
+	}
+	return nums, true
+}
+
+// matchLines returns lines of the content that contain the lowercased query.
+func matchLines(content, query string, maxLines int) (lines []Line, numLines int) {
+	for i, line := range strings.Split(content, "\n") {
+		if !strings.Contains(strings.ToLower(line), query) {
+			continue
+		}
+
+		numLines++
+		if len(lines) >= maxLines {
+			continue
+		}
+		line = strings.TrimRight(line, "\r")
+		if len(line) > MaxLineLength {
+			line = strings.ToValidUTF8(line[:MaxLineLength], "")
+		}
+		lines = append(lines, Line{Num: i + 1, Content: line})
+	}
+	return lines, numLines
+}
+
+// lookup returns IDs of documents and numbers of those that may contain all
+// the lowercased terms. It must be called with the read lock held.
+func (idx *Index) lookup(terms []string) (ids []string, nums []int) {
+	// The postings may have been invalidated by a write in between, in which
+	// case documents are all verified anyway.
+	ids = idx.ids
+	found := false
+	if idx.postings != nil {
+		for _, term := range terms {
+			list, ok := idx.candidates(term)
+			if !ok {
+				continue
+			} else if !found {
+				nums, found = list, true
+			} else {
+				nums = intersect(nums, list)
+			}
+		}
+	}
+	if !found {
+		nums = make([]int, len(ids))
+		for i := range ids {
+			nums[i] = i
+		}
+	}
+	return ids, nums
+}
+
+// prepare builds postings if they are outdated and acquires the read lock,
+// which is to be released by the caller.
+func (idx *Index) prepare() {
+	idx.lock.Lock()
+	idx.buildPostings()
+	idx.lock.Unlock()
+	idx.lock.RLock()
+}
+
+// Search returns documents that contain the query, ordered by their IDs. Up
+// to maxLines matched lines are returned for each document, and it stops after
+// limit documents are found if limit is positive.
+func (idx *Index) Search(query string, maxLines, limit int) []*Match {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+
+	idx.prepare()
+	defer idx.lock.RUnlock()
+
+	ids, nums := idx.lookup([]string{query})
+	var matches []*Match
+	for _, i := range nums {
+		content, exists := idx.docs[ids[i]]
+		if !exists {
+			continue
+		}
+		lines, numLines := matchLines(content, query, maxLines)
+		if numLines == 0 {
+			continue
+		}
+		matches = append(matches, &Match{
+			ID:       ids[i],
+			Lines:    lines,
+			NumLines: numLines,
+		})
+		if limit > 0 && len(matches) >= limit {
+			break
+		}
+	}
+	return matches
+}
+
+// Hit is a document that matches a ranked search.
+type Hit struct {
+	ID    string
+	Score int
+}
+
+// Rank returns documents that contain all the terms regardless of case,
+// ordered by their scores in descending order and then by their IDs. The score
+// of a document is the number of occurrences of the terms, where each
+// occurrence in the first line (e.g. a title) counts as firstLineWeight.
+func (idx *Index) Rank(terms []string, firstLineWeight int) []*Hit {
+	normalized := make([]string, 0, len(terms))
+	for _, term := range terms {
+		term = strings.ToLower(strings.TrimSpace(term))
+		if term != "" {
+			normalized = append(normalized, term)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil
+	}
+
+	idx.prepare()
+	defer idx.lock.RUnlock()
+
+	ids, nums := idx.lookup(normalized)
+	var hits []*Hit
+	for _, i := range nums {
+		content, exists := idx.docs[ids[i]]
+		if !exists {
+			continue
+		}
+
+		content = strings.ToLower(content)
+		firstLine, rest, _ := strings.Cut(content, "\n")
+		score := 0
+		for _, term := range normalized {
+			if !strings.Contains(content, term) {
+				score = 0
+				break
+			}
+			score += strings.Count(firstLine, term)*firstLineWeight + strings.Count(rest, term)
+		}
+		if score > 0 {
+			hits = append(hits, &Hit{ID: ids[i], Score: score})
+		}
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].Score > hits[j].Score })
+	return hits
+}
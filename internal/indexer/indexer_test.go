@@ -0,0 +1,202 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package indexer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndex_Search(t *testing.T) {
+	idx := New(filepath.Join(t.TempDir(), "test.idx"))
+	idx.Put("main.go", "package main\n\nfunc main() {\n\tGetRepositoryByName()\n}\n")
+	idx.Put("repo.go", "package database\r\n\r\n// GetRepositoryByName returns the repository.\r\nfunc GetRepositoryByName() {}\r\n")
+	idx.Put("README.md", "# Gogs\n")
+
+	tests := []struct {
+		name  string
+		query string
+		want  []*Match
+	}{
+		{
+			name:  "empty query",
+			query: " ",
+			want:  nil,
+		},
+		{
+			name:  "no match",
+			query: "GetUserByName",
+			want:  nil,
+		},
+		{
+			name:  "case insensitive",
+			query: "getrepositorybyname",
+			want: []*Match{
+				{ID: "main.go", Lines: []Line{{Num: 4, Content: "\tGetRepositoryByName()"}}, NumLines: 1},
+				{ID: "repo.go", Lines: []Line{{Num: 3, Content: "// GetRepositoryByName returns the repository."}}, NumLines: 2},
+			},
+		},
+		{
+			name:  "short query",
+			query: "Go",
+			want: []*Match{
+				{ID: "README.md", Lines: []Line{{Num: 1, Content: "# Gogs"}}, NumLines: 1},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, idx.Search(test.query, 1, 0))
+		})
+	}
+
+	idx.Delete("repo.go")
+	got := idx.Search("GetRepositoryByName", 1, 0)
+	require.Len(t, got, 1)
+	assert.Equal(t, "main.go", got[0].ID)
+}
+
+func TestIndex_Search_Limit(t *testing.T) {
+	idx := New(filepath.Join(t.TempDir(), "test.idx"))
+	idx.Put("a.go", "package a")
+	idx.Put("b.go", "package b")
+	idx.Put("c.go", "package c")
+
+	got := idx.Search("package", 1, 2)
+	require.Len(t, got, 2)
+	assert.Equal(t, "a.go", got[0].ID)
+	assert.Equal(t, "b.go", got[1].ID)
+}
+
+func TestIndex_Rank(t *testing.T) {
+	idx := New("")
+	idx.Put("1", "Crash on startup\nThe server crashes when the database is down.")
+	idx.Put("2", "Improve documentation\nMention the crash of the server.")
+	idx.Put("3", "Server crash\nIt crashes.\nSame crash here.")
+
+	tests := []struct {
+		name  string
+		terms []string
+		want  []*Hit
+	}{
+		{
+			name:  "empty terms",
+			terms: []string{" ", ""},
+			want:  nil,
+		},
+		{
+			name:  "no match",
+			terms: []string{"panic"},
+			want:  nil,
+		},
+		{
+			name:  "title weighs more",
+			terms: []string{"CRASH"},
+			want: []*Hit{
+				{ID: "3", Score: 5},
+				{ID: "1", Score: 4},
+				{ID: "2", Score: 1},
+			},
+		},
+		{
+			name:  "all terms",
+			terms: []string{"crash", "database"},
+			want: []*Hit{
+				{ID: "1", Score: 5},
+			},
+		},
+		{
+			name:  "short term",
+			terms: []string{"It"},
+			want: []*Hit{
+				{ID: "3", Score: 1},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, idx.Rank(test.terms, 3))
+		})
+	}
+}
+
+func TestIndex_Save(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "indexes", "test.idx")
+	idx := New(path)
+	idx.SetMeta("commit", "abc")
+	idx.Put("main.go", "package main")
+	require.NoError(t, idx.Save())
+
+	meta, err := ReadMeta(path)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"commit": "abc"}, meta)
+
+	idx, err = Open(path)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", idx.Meta("commit"))
+	assert.Equal(t, 1, idx.Len())
+	assert.NotNil(t, idx.postings, "postings are saved along with documents")
+	assert.Len(t, idx.Search("main", 1, 0), 1)
+
+	// A file that does not exist is an empty index.
+	idx, err = Open(filepath.Join(t.TempDir(), "404.idx"))
+	require.NoError(t, err)
+	assert.Equal(t, 0, idx.Len())
+	meta, err = ReadMeta(filepath.Join(t.TempDir(), "404.idx"))
+	require.NoError(t, err)
+	assert.Nil(t, meta)
+}
+
+func TestPool(t *testing.T) {
+	p := NewPool(t.TempDir(), 1)
+
+	idx := New("")
+	idx.Put("a", "hello")
+	require.NoError(t, p.Put("1", idx))
+	idx = New("")
+	idx.Put("b", "world")
+	require.NoError(t, p.Put("2", idx))
+
+	names, err := p.Names()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"1", "2"}, names)
+
+	// The first index has been evicted from memory and is loaded from disk.
+	idx, err = p.Get("1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, idx.Len())
+
+	require.NoError(t, p.Remove("1"))
+	idx, err = p.Get("1")
+	require.NoError(t, err)
+	assert.Equal(t, 0, idx.Len())
+}
+
+func TestPool_Load(t *testing.T) {
+	p := NewPool(t.TempDir(), 1)
+
+	idx := New("")
+	idx.Put("a", "hello")
+	require.NoError(t, p.Put("1", idx))
+	idx = New("")
+	idx.Put("b", "world")
+	require.NoError(t, p.Put("2", idx))
+	cached, err := p.Get("2")
+	require.NoError(t, err)
+
+	// Loading an index that is not in memory must not evict the cached one.
+	idx, err = p.Load("1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, idx.Len())
+	_, ok := p.entries["1"]
+	assert.False(t, ok)
+
+	idx, err = p.Load("2")
+	require.NoError(t, err)
+	assert.Same(t, cached, idx)
+}
@@ -0,0 +1,190 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package indexer
+
+import (
+	"container/list"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const fileExt = ".idx"
+
+// Pool manages index files of a directory by their names, and keeps a bounded
+// number of recently used indexes in memory.
+type Pool struct {
+	dir  string
+	size int
+
+	lock    sync.Mutex
+	recent  *list.List // Most recently used goes first
+	entries map[string]*list.Element
+	gen     uint64 // Increased whenever an index file is saved or removed
+}
+
+type poolEntry struct {
+	name  string
+	index *Index
+}
+
+// NewPool returns a new pool of index files in the directory, which keeps up
+// to size indexes in memory.
+func NewPool(dir string, size int) *Pool {
+	if size <= 0 {
+		size = 1
+	}
+	return &Pool{
+		dir:     dir,
+		size:    size,
+		recent:  list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Path returns the path of the index file with given name.
+func (p *Pool) Path(name string) string {
+	return filepath.Join(p.dir, name+fileExt)
+}
+
+// put adds the index to the memory, evicting the least recently used one if
+// the pool is full. It must be called with the lock held.
+func (p *Pool) put(name string, idx *Index) {
+	if e, ok := p.entries[name]; ok {
+		e.Value.(*poolEntry).index = idx
+		p.recent.MoveToFront(e)
+		return
+	}
+
+	p.entries[name] = p.recent.PushFront(&poolEntry{name: name, index: idx})
+	for p.recent.Len() > p.size {
+		e := p.recent.Back()
+		p.recent.Remove(e)
+		delete(p.entries, e.Value.(*poolEntry).name)
+	}
+}
+
+// Get returns the index with given name, which is loaded from disk and kept
+// in memory if it is not in memory. An empty index is returned if the file
+// does not exist.
+func (p *Pool) Get(name string) (*Index, error) {
+	p.lock.Lock()
+	if e, ok := p.entries[name]; ok {
+		p.recent.MoveToFront(e)
+		idx := e.Value.(*poolEntry).index
+		p.lock.Unlock()
+		return idx, nil
+	}
+	gen := p.gen
+	p.lock.Unlock()
+
+	// Reading from disk can take a while, do not block other indexes.
+	idx, err := Open(p.Path(name))
+	if err != nil {
+		return nil, err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if e, ok := p.entries[name]; ok {
+		// Someone else has loaded or saved the index in the meantime.
+		p.recent.MoveToFront(e)
+		return e.Value.(*poolEntry).index, nil
+	} else if p.gen != gen {
+		// The index has been saved or removed in the meantime, what we read
+		// may be stale and must not be kept.
+		return idx, nil
+	}
+	p.put(name, idx)
+	return idx, nil
+}
+
+// Load returns the index with given name from memory if present, otherwise it
+// is read from disk without being kept in memory. It is meant for reading many
+// indexes at once without evicting the ones that are frequently used.
+func (p *Pool) Load(name string) (*Index, error) {
+	p.lock.Lock()
+	var idx *Index
+	if e, ok := p.entries[name]; ok {
+		idx = e.Value.(*poolEntry).index
+	}
+	p.lock.Unlock()
+	if idx != nil {
+		return idx, nil
+	}
+	return Open(p.Path(name))
+}
+
+// Put saves the index as the one with given name, which replaces the index
+// in memory if any.
+func (p *Pool) Put(name string, idx *Index) error {
+	idx.path = p.Path(name)
+	if err := idx.Save(); err != nil {
+		return err
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.gen++
+	p.put(name, idx)
+	return nil
+}
+
+// ReadMeta returns the metadata of the index with given name without loading
+// its documents, or nil if the index does not exist.
+func (p *Pool) ReadMeta(name string) (map[string]string, error) {
+	p.lock.Lock()
+	if e, ok := p.entries[name]; ok {
+		idx := e.Value.(*poolEntry).index
+		p.lock.Unlock()
+		idx.lock.RLock()
+		defer idx.lock.RUnlock()
+		meta := make(map[string]string, len(idx.meta))
+		for k, v := range idx.meta {
+			meta[k] = v
+		}
+		return meta, nil
+	}
+	p.lock.Unlock()
+	return ReadMeta(p.Path(name))
+}
+
+// Remove deletes the index with given name from both memory and disk.
+func (p *Pool) Remove(name string) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	p.gen++
+	if e, ok := p.entries[name]; ok {
+		p.recent.Remove(e)
+		delete(p.entries, name)
+	}
+
+	err := os.Remove(p.Path(name))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// Names returns names of all index files in the directory.
+func (p *Pool) Names() ([]string, error) {
+	entries, err := os.ReadDir(p.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), fileExt) {
+			names = append(names, strings.TrimSuffix(e.Name(), fileExt))
+		}
+	}
+	return names, nil
+}
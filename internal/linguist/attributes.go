@@ -0,0 +1,141 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package linguist
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// Names of Git attributes that override language statistics.
+const (
+	AttrVendored      = "linguist-vendored"
+	AttrGenerated     = "linguist-generated"
+	AttrDocumentation = "linguist-documentation"
+	AttrLanguage      = "linguist-language"
+)
+
+type attributeRule struct {
+	pattern *regexp.Regexp
+	// attrs maps names of attributes to their values, which are "true" for set,
+	// "false" for unset and the value otherwise.
+	attrs map[string]string
+}
+
+// Attributes contains rules of .gitattributes files.
+type Attributes struct {
+	rules []attributeRule
+}
+
+// ParseAttributes parses contents of the .gitattributes file in the root
+// directory, only attributes of linguist are kept.
+func ParseAttributes(data []byte) *Attributes {
+	a := new(Attributes)
+	a.AddFile("", data)
+	return a
+}
+
+// AddFile parses contents of the .gitattributes file in the directory, whose
+// patterns are relative to the directory. Files in deeper directories must be
+// added later to take precedence.
+func (a *Attributes) AddFile(dir string, data []byte) {
+	dir = strings.Trim(dir, "/")
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		attrs := make(map[string]string)
+		for _, field := range fields[1:] {
+			var name, value string
+			switch {
+			case strings.HasPrefix(field, "-"), strings.HasPrefix(field, "!"):
+				name, value = field[1:], "false"
+			case strings.Contains(field, "="):
+				name, value, _ = strings.Cut(field, "=")
+			default:
+				name, value = field, "true"
+			}
+			if strings.HasPrefix(name, "linguist-") {
+				attrs[name] = value
+			}
+		}
+		if len(attrs) == 0 {
+			continue
+		}
+
+		pattern, err := compilePattern(dir, fields[0])
+		if err != nil {
+			continue
+		}
+		a.rules = append(a.rules, attributeRule{pattern: pattern, attrs: attrs})
+	}
+}
+
+// compilePattern converts a pattern of the .gitattributes file in the
+// directory to a regular expression. A pattern without a slash matches the
+// file name at any level, otherwise it matches the path relative to the
+// directory.
+func compilePattern(dir, pattern string) (*regexp.Regexp, error) {
+	var buf strings.Builder
+	buf.WriteString("^")
+	if dir != "" {
+		buf.WriteString(regexp.QuoteMeta(dir + "/"))
+	}
+	if !strings.Contains(strings.TrimSuffix(pattern, "/"), "/") {
+		buf.WriteString("(?:.*/)?")
+	}
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; c {
+		case '*':
+			if strings.HasPrefix(pattern[i:], "**/") {
+				buf.WriteString("(?:.*/)?")
+				i += 2
+			} else if pattern[i:] == "**" {
+				buf.WriteString(".*")
+				i++
+			} else {
+				buf.WriteString("[^/]*")
+			}
+		case '?':
+			buf.WriteString("[^/]")
+		default:
+			buf.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	buf.WriteString("$")
+	return regexp.Compile(buf.String())
+}
+
+// Get returns the value of the attribute of the path, which is "true" for set,
+// "false" for unset, or empty if unspecified. Later rules take precedence.
+func (a *Attributes) Get(filePath, name string) string {
+	if a == nil {
+		return ""
+	}
+	for i := len(a.rules) - 1; i >= 0; i-- {
+		value, ok := a.rules[i].attrs[name]
+		if ok && a.rules[i].pattern.MatchString(filePath) {
+			return value
+		}
+	}
+	return ""
+}
+
+// Language returns the name of the language of the path overridden by the
+// linguist-language attribute, or empty if unspecified.
+func (a *Attributes) Language(filePath string) string {
+	name := a.Get(filePath, AttrLanguage)
+	if name == "true" || name == "false" {
+		return ""
+	}
+	return name
+}
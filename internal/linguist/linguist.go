@@ -0,0 +1,129 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+// Package linguist detects programming languages of files in a repository by
+// their names, and computes language statistics of a tree.
+package linguist
+
+import (
+	"path"
+	"strings"
+)
+
+// Language is a programming or markup language that is counted in language
+// statistics. Data formats and prose (e.g. JSON and Markdown) are not counted.
+type Language struct {
+	Name  string
+	Color string
+	// Extensions are lowercased file extensions including the leading dot.
+	Extensions []string
+	// Filenames are exact file names without extensions.
+	Filenames []string
+}
+
+// Languages is the list of languages that can be detected.
+var Languages = []*Language{
+	{Name: "Assembly", Color: "#6E4C13", Extensions: []string{".asm", ".s"}},
+	{Name: "Batchfile", Color: "#C1F12E", Extensions: []string{".bat", ".cmd"}},
+	{Name: "C", Color: "#555555", Extensions: []string{".c", ".h"}},
+	{Name: "C#", Color: "#178600", Extensions: []string{".cs"}},
+	{Name: "C++", Color: "#f34b7d", Extensions: []string{".cpp", ".cc", ".cxx", ".hpp", ".hh", ".hxx"}},
+	{Name: "CMake", Color: "#DA3434", Extensions: []string{".cmake"}, Filenames: []string{"CMakeLists.txt"}},
+	{Name: "CSS", Color: "#563d7c", Extensions: []string{".css"}},
+	{Name: "Clojure", Color: "#db5855", Extensions: []string{".clj", ".cljs", ".cljc"}},
+	{Name: "CoffeeScript", Color: "#244776", Extensions: []string{".coffee"}},
+	{Name: "Crystal", Color: "#000100", Extensions: []string{".cr"}},
+	{Name: "D", Color: "#ba595e", Extensions: []string{".d"}},
+	{Name: "Dart", Color: "#00B4AB", Extensions: []string{".dart"}},
+	{Name: "Dockerfile", Color: "#384d54", Extensions: []string{".dockerfile"}, Filenames: []string{"Dockerfile"}},
+	{Name: "Elixir", Color: "#6e4a7e", Extensions: []string{".ex", ".exs"}},
+	{Name: "Elm", Color: "#60B5CC", Extensions: []string{".elm"}},
+	{Name: "Emacs Lisp", Color: "#c065db", Extensions: []string{".el"}},
+	{Name: "Erlang", Color: "#B83998", Extensions: []string{".erl", ".hrl"}},
+	{Name: "F#", Color: "#b845fc", Extensions: []string{".fs", ".fsx"}},
+	{Name: "Fortran", Color: "#4d41b1", Extensions: []string{".f", ".f90", ".for"}},
+	{Name: "Go", Color: "#00ADD8", Extensions: []string{".go"}},
+	{Name: "Groovy", Color: "#4298b8", Extensions: []string{".groovy", ".gradle"}},
+	{Name: "HCL", Color: "#844FBA", Extensions: []string{".hcl", ".tf"}},
+	{Name: "HTML", Color: "#e34c26", Extensions: []string{".html", ".htm", ".xhtml"}},
+	{Name: "Haskell", Color: "#5e5086", Extensions: []string{".hs"}},
+	{Name: "Java", Color: "#b07219", Extensions: []string{".java"}},
+	{Name: "JavaScript", Color: "#f1e05a", Extensions: []string{".js", ".cjs", ".mjs", ".jsx"}},
+	{Name: "Julia", Color: "#a270ba", Extensions: []string{".jl"}},
+	{Name: "Jupyter Notebook", Color: "#DA5B0B", Extensions: []string{".ipynb"}},
+	{Name: "Kotlin", Color: "#A97BFF", Extensions: []string{".kt", ".kts"}},
+	{Name: "Less", Color: "#1d365d", Extensions: []string{".less"}},
+	{Name: "Lua", Color: "#000080", Extensions: []string{".lua"}},
+	{Name: "Makefile", Color: "#427819", Extensions: []string{".mk", ".mak"}, Filenames: []string{"Makefile", "GNUmakefile", "makefile"}},
+	{Name: "Nim", Color: "#ffc200", Extensions: []string{".nim"}},
+	{Name: "Nix", Color: "#7e7eff", Extensions: []string{".nix"}},
+	{Name: "OCaml", Color: "#ef7a08", Extensions: []string{".ml", ".mli"}},
+	{Name: "Objective-C", Color: "#438eff", Extensions: []string{".m"}},
+	{Name: "Objective-C++", Color: "#6866fb", Extensions: []string{".mm"}},
+	{Name: "PHP", Color: "#4F5D95", Extensions: []string{".php"}},
+	{Name: "Pascal", Color: "#E3F171", Extensions: []string{".pas"}},
+	{Name: "Perl", Color: "#0298c3", Extensions: []string{".pl", ".pm"}},
+	{Name: "PowerShell", Color: "#012456", Extensions: []string{".ps1", ".psm1"}},
+	{Name: "Python", Color: "#3572A5", Extensions: []string{".py", ".pyw"}},
+	{Name: "R", Color: "#198CE7", Extensions: []string{".r"}},
+	{Name: "Ruby", Color: "#701516", Extensions: []string{".rb", ".rake", ".gemspec"}, Filenames: []string{"Gemfile", "Rakefile"}},
+	{Name: "Rust", Color: "#dea584", Extensions: []string{".rs"}},
+	{Name: "SCSS", Color: "#c6538c", Extensions: []string{".scss"}},
+	{Name: "SQL", Color: "#e38c00", Extensions: []string{".sql"}},
+	{Name: "Sass", Color: "#a53b70", Extensions: []string{".sass"}},
+	{Name: "Scala", Color: "#c22d40", Extensions: []string{".scala", ".sc"}},
+	{Name: "Shell", Color: "#89e051", Extensions: []string{".sh", ".bash", ".zsh"}},
+	{Name: "Solidity", Color: "#AA6746", Extensions: []string{".sol"}},
+	{Name: "Svelte", Color: "#ff3e00", Extensions: []string{".svelte"}},
+	{Name: "Swift", Color: "#F05138", Extensions: []string{".swift"}},
+	{Name: "TeX", Color: "#3D6117", Extensions: []string{".tex"}},
+	{Name: "TypeScript", Color: "#3178c6", Extensions: []string{".ts", ".tsx", ".mts", ".cts"}},
+	{Name: "Vim Script", Color: "#199f4b", Extensions: []string{".vim"}},
+	{Name: "Visual Basic .NET", Color: "#945db7", Extensions: []string{".vb"}},
+	{Name: "Vue", Color: "#41b883", Extensions: []string{".vue"}},
+	{Name: "Zig", Color: "#ec915c", Extensions: []string{".zig"}},
+}
+
+var (
+	languagesByName      = make(map[string]*Language)
+	languagesByExtension = make(map[string]*Language)
+	languagesByFilename  = make(map[string]*Language)
+)
+
+func init() {
+	for _, lang := range Languages {
+		languagesByName[strings.ToLower(lang.Name)] = lang
+		for _, ext := range lang.Extensions {
+			languagesByExtension[ext] = lang
+		}
+		for _, name := range lang.Filenames {
+			languagesByFilename[name] = lang
+		}
+	}
+}
+
+// LanguageByName returns the language with given name regardless of case, or
+// nil if no such language.
+func LanguageByName(name string) *Language {
+	return languagesByName[strings.ToLower(name)]
+}
+
+// Color returns the color of the language with given name, or a gray color if
+// no such language.
+func Color(name string) string {
+	if lang := LanguageByName(name); lang != nil {
+		return lang.Color
+	}
+	return "#cccccc"
+}
+
+// Detect returns the language of the file by its path, or nil if the file is
+// not in any known language.
+func Detect(filePath string) *Language {
+	name := path.Base(filePath)
+	if lang, ok := languagesByFilename[name]; ok {
+		return lang
+	}
+	return languagesByExtension[strings.ToLower(path.Ext(name))]
+}
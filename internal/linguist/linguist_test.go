@@ -0,0 +1,124 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package linguist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "main.go", want: "Go"},
+		{path: "web/App.TSX", want: "TypeScript"},
+		{path: "build/Makefile", want: "Makefile"},
+		{path: "Dockerfile", want: "Dockerfile"},
+		{path: "README.md", want: ""},
+		{path: "LICENSE", want: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			got := ""
+			if lang := Detect(test.path); lang != nil {
+				got = lang.Name
+			}
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestAttributes_Get(t *testing.T) {
+	attrs := ParseAttributes([]byte(`# Comment
+*.js linguist-vendored
+/src/*.js -linguist-vendored
+docs/** linguist-documentation=false
+*.tmpl linguist-language=HTML text
+third_party/**/*.c !linguist-vendored
+`))
+
+	tests := []struct {
+		path string
+		name string
+		want string
+	}{
+		{path: "app.js", name: AttrVendored, want: "true"},
+		{path: "lib/app.js", name: AttrVendored, want: "true"},
+		{path: "src/app.js", name: AttrVendored, want: "false"},
+		{path: "src/lib/app.js", name: AttrVendored, want: "true"},
+		{path: "docs/a/b.go", name: AttrDocumentation, want: "false"},
+		{path: "templates/home.tmpl", name: AttrLanguage, want: "HTML"},
+		{path: "third_party/zlib/zlib.c", name: AttrVendored, want: "false"},
+		{path: "main.go", name: AttrVendored, want: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			assert.Equal(t, test.want, attrs.Get(test.path, test.name))
+		})
+	}
+}
+
+func TestAttributes_AddFile(t *testing.T) {
+	attrs := ParseAttributes([]byte(`*.js linguist-vendored`))
+	attrs.AddFile("web", []byte(`*.js -linguist-vendored
+/dist/*.js linguist-generated`))
+
+	tests := []struct {
+		path string
+		name string
+		want string
+	}{
+		{path: "app.js", name: AttrVendored, want: "true"},
+		{path: "web/app.js", name: AttrVendored, want: "false"},
+		{path: "web/src/app.js", name: AttrVendored, want: "false"},
+		{path: "web/dist/app.js", name: AttrGenerated, want: "true"},
+		{path: "dist/app.js", name: AttrGenerated, want: ""},
+		{path: "webapp/app.js", name: AttrVendored, want: "true"},
+	}
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			assert.Equal(t, test.want, attrs.Get(test.path, test.name))
+		})
+	}
+}
+
+func TestAttributes_Language(t *testing.T) {
+	attrs := ParseAttributes([]byte(`*.tmpl linguist-language=HTML
+*.inc linguist-language
+`))
+	assert.Equal(t, "HTML", attrs.Language("templates/home.tmpl"))
+	assert.Equal(t, "", attrs.Language("lib/common.inc"))
+	assert.Equal(t, "", attrs.Language("main.go"))
+
+	var nilAttrs *Attributes
+	assert.Equal(t, "", nilAttrs.Language("templates/home.tmpl"))
+}
+
+func TestStats(t *testing.T) {
+	files := []File{
+		{Path: "main.go", Size: 100},
+		{Path: "internal/db.go", Size: 50},
+		{Path: "internal/db.pb.go", Size: 1000},
+		{Path: "vendor/github.com/pkg/errors/errors.go", Size: 1000},
+		{Path: "public/js/jquery.min.js", Size: 1000},
+		{Path: "public/js/app.js", Size: 30},
+		{Path: "docs/example.py", Size: 1000},
+		{Path: "templates/home.tmpl", Size: 20},
+		{Path: "README.md", Size: 1000},
+	}
+	attrs := ParseAttributes([]byte(`
+*.tmpl linguist-language=HTML
+public/js/app.js linguist-vendored
+internal/db.pb.go -linguist-generated
+`))
+	want := map[string]int64{
+		"Go":   1150,
+		"HTML": 20,
+	}
+	assert.Equal(t, want, Stats(files, attrs))
+}
@@ -0,0 +1,80 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package linguist
+
+import (
+	"regexp"
+)
+
+var (
+	// vendoredPattern matches paths of third-party code, which is a subset of
+	// https://github.com/github-linguist/linguist/blob/master/lib/linguist/vendor.yml.
+	vendoredPattern = regexp.MustCompile(`(^|/)(vendor|vendors|node_modules|bower_components|third[-_]?party|3rdparty|external|extern|deps|Godeps/_workspace|dist)/` +
+		`|\.min\.(js|css)$` +
+		`|(^|/)(jquery|bootstrap|d3|angular|react|vue)([.-][^/]*)?\.js$`)
+	// generatedPattern matches paths of files that are usually generated.
+	generatedPattern = regexp.MustCompile(`\.pb\.go$|_pb2\.py$|\.pb\.(cc|h)$|\.designer\.cs$|(^|/)bindata\.go$|_generated\.go$|\.gen\.go$`)
+	// documentationPattern matches paths of documentation.
+	documentationPattern = regexp.MustCompile(`(?i)(^|/)(docs?|documentation|examples?|samples?)/`)
+)
+
+// IsVendored returns true if the path is usually third-party code.
+func IsVendored(filePath string) bool {
+	return vendoredPattern.MatchString(filePath)
+}
+
+// IsGenerated returns true if the path is usually generated.
+func IsGenerated(filePath string) bool {
+	return generatedPattern.MatchString(filePath)
+}
+
+// IsDocumentation returns true if the path is usually documentation.
+func IsDocumentation(filePath string) bool {
+	return documentationPattern.MatchString(filePath)
+}
+
+// File is a file of a tree to compute language statistics.
+type File struct {
+	Path string
+	Size int64
+}
+
+// isExcluded returns true if the file should not be counted. The attribute,
+// when specified, takes precedence over the default detection.
+func isExcluded(attrs *Attributes, filePath, name string, detect func(string) bool) bool {
+	switch attrs.Get(filePath, name) {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	return detect(filePath)
+}
+
+// Stats returns the number of bytes of each language in the files. Vendored,
+// generated and documentation files are excluded unless attributes say
+// otherwise, and the language of a file can be overridden by attributes.
+func Stats(files []File, attrs *Attributes) map[string]int64 {
+	stats := make(map[string]int64)
+	for _, f := range files {
+		if isExcluded(attrs, f.Path, AttrVendored, IsVendored) ||
+			isExcluded(attrs, f.Path, AttrGenerated, IsGenerated) ||
+			isExcluded(attrs, f.Path, AttrDocumentation, IsDocumentation) {
+			continue
+		}
+
+		var lang *Language
+		if name := attrs.Language(f.Path); name != "" {
+			lang = LanguageByName(name)
+		}
+		if lang == nil {
+			lang = Detect(f.Path)
+		}
+		if lang != nil {
+			stats[lang.Name] += f.Size
+		}
+	}
+	return stats
+}
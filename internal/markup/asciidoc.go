@@ -0,0 +1,41 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package markup
+
+import (
+	"path/filepath"
+	"strings"
+
+	log "unknwon.dev/clog/v2"
+
+	"gogs.io/gogs/internal/conf"
+)
+
+// IsAsciiDocFile reports whether name looks like an AsciiDoc file based on its extension.
+func IsAsciiDocFile(name string) bool {
+	extension := strings.ToLower(filepath.Ext(name))
+	for _, ext := range conf.Markup.AsciiDoc.FileExtensions {
+		if strings.ToLower(ext) == extension {
+			return true
+		}
+	}
+	return false
+}
+
+// RawAsciiDoc renders content in AsciiDoc syntax to HTML by the external
+// command without handling special links.
+func RawAsciiDoc(body []byte) []byte {
+	result, err := renderExternal(conf.Markup.AsciiDoc.RenderCommand, conf.Markup.AsciiDoc.RenderTimeout, body)
+	if err != nil {
+		log.Error("Failed to render AsciiDoc: %v", err)
+		return renderFailed(body)
+	}
+	return result
+}
+
+// AsciiDoc takes a string or []byte and renders to HTML in AsciiDoc syntax with special links.
+func AsciiDoc(input any, urlPrefix string, metas map[string]string) []byte {
+	return Render(TypeAsciiDoc, input, urlPrefix, metas)
+}
@@ -0,0 +1,85 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package markup
+
+import (
+	"bytes"
+	"encoding/csv"
+	"html"
+	"io"
+	"path/filepath"
+	"strings"
+)
+
+// IsCSVFile reports whether name looks like a CSV file based on its extension.
+func IsCSVFile(name string) bool {
+	return strings.ToLower(filepath.Ext(name)) == ".csv"
+}
+
+// IsTSVFile reports whether name looks like a TSV file based on its extension.
+func IsTSVFile(name string) bool {
+	return strings.ToLower(filepath.Ext(name)) == ".tsv"
+}
+
+// CSVComma returns the field delimiter of given type, which is either TypeCSV
+// or TypeTSV.
+func CSVComma(typ Type) rune {
+	if typ == TypeTSV {
+		return '\t'
+	}
+	return ','
+}
+
+// RawCSV renders content of delimiter-separated values to an HTML table, the
+// first record is used as the header. At most maxRows records are rendered
+// besides the header, and the second return value reports whether there are
+// more. All fields are escaped, so the result is safe to be displayed without
+// sanitizing.
+func RawCSV(body []byte, comma rune, maxRows int) ([]byte, bool, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(body, []byte("\xef\xbb\xbf"))))
+	r.Comma = comma
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+	r.ReuseRecord = true
+
+	var buf bytes.Buffer
+	buf.WriteString("<table>")
+	rows := 0
+	truncated := false
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, false, err
+		}
+
+		if rows == 0 {
+			buf.WriteString("<thead><tr>")
+			for _, field := range record {
+				buf.WriteString("<th>" + html.EscapeString(field) + "</th>")
+			}
+			buf.WriteString("</tr></thead><tbody>")
+			rows++
+			continue
+		}
+
+		if rows > maxRows {
+			truncated = true
+			break
+		}
+		buf.WriteString("<tr>")
+		for _, field := range record {
+			buf.WriteString("<td>" + html.EscapeString(field) + "</td>")
+		}
+		buf.WriteString("</tr>")
+		rows++
+	}
+	if rows > 0 {
+		buf.WriteString("</tbody>")
+	}
+	buf.WriteString("</table>")
+	return buf.Bytes(), truncated, nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package markup_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	. "gogs.io/gogs/internal/markup"
+)
+
+func Test_RawCSV(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		comma         rune
+		maxRows       int
+		wantHTML      string
+		wantTruncated bool
+	}{
+		{
+			name:     "empty",
+			body:     "",
+			comma:    ',',
+			maxRows:  10,
+			wantHTML: `<table></table>`,
+		},
+		{
+			name:     "header and rows",
+			body:     "\xef\xbb\xbfname,size\nfoo,1\n\"<b>, bar\",2\n",
+			comma:    ',',
+			maxRows:  10,
+			wantHTML: `<table><thead><tr><th>name</th><th>size</th></tr></thead><tbody><tr><td>foo</td><td>1</td></tr><tr><td>&lt;b&gt;, bar</td><td>2</td></tr></tbody></table>`,
+		},
+		{
+			name:     "tab separated with ragged rows",
+			body:     "a\tb\n1\n1\t2\t3\n",
+			comma:    '\t',
+			maxRows:  10,
+			wantHTML: `<table><thead><tr><th>a</th><th>b</th></tr></thead><tbody><tr><td>1</td></tr><tr><td>1</td><td>2</td><td>3</td></tr></tbody></table>`,
+		},
+		{
+			name:          "truncated",
+			body:          "a\n1\n2\n3\n",
+			comma:         ',',
+			maxRows:       2,
+			wantHTML:      `<table><thead><tr><th>a</th></tr></thead><tbody><tr><td>1</td></tr><tr><td>2</td></tr></tbody></table>`,
+			wantTruncated: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			html, truncated, err := RawCSV([]byte(test.body), test.comma, test.maxRows)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, test.wantHTML, string(html))
+			assert.Equal(t, test.wantTruncated, truncated)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package markup
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Limits of rendering by external commands and renderers that cannot be
+// interrupted.
+const (
+	// maxRenderOutputSize is the maximum size in bytes of the HTML produced by an
+	// external command.
+	maxRenderOutputSize = 16 << 20
+	// renderWaitDelay is the time to wait for output pipes of an external
+	// command to be closed after it is killed, e.g. by its child processes.
+	renderWaitDelay = time.Second
+)
+
+// renderSlots limits the number of renderings running concurrently, so that
+// slow renderings cannot exhaust processes or CPUs of the server.
+var renderSlots = make(chan struct{}, runtime.NumCPU())
+
+// acquireRenderSlot waits for a free slot of rendering until the context is
+// done. The slot must be released by releaseRenderSlot when it returns true.
+func acquireRenderSlot(ctx context.Context) bool {
+	select {
+	case renderSlots <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func releaseRenderSlot() {
+	<-renderSlots
+}
+
+// renderContext returns a context that is done after the timeout, or never if
+// the timeout is not positive.
+func renderContext(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout > 0 {
+		return context.WithTimeout(context.Background(), timeout)
+	}
+	return context.WithCancel(context.Background())
+}
+
+// limitedBuffer is a buffer that accepts at most max bytes, and calls exceed
+// when more are written. The buffer is not embedded, otherwise its ReadFrom
+// would be used by io.Copy to bypass the limit.
+type limitedBuffer struct {
+	buf    bytes.Buffer
+	max    int
+	exceed func()
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if b.buf.Len()+len(p) > b.max {
+		b.exceed()
+		return 0, fmt.Errorf("output exceeds %d bytes", b.max)
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) Bytes() []byte {
+	return b.buf.Bytes()
+}
+
+// renderExternal renders the body by the external command, which reads from
+// stdin and writes HTML to stdout. The command is killed if it does not finish
+// within the timeout or writes too much output.
+func renderExternal(command string, timeout time.Duration, body []byte) ([]byte, error) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("empty command")
+	}
+
+	ctx, cancel := renderContext(timeout)
+	defer cancel()
+	if !acquireRenderSlot(ctx) {
+		return nil, fmt.Errorf("%q timed out after %s waiting for other renderings", fields[0], timeout)
+	}
+	defer releaseRenderSlot()
+
+	var exceeded bool
+	stdout := &limitedBuffer{
+		max: maxRenderOutputSize,
+		exceed: func() {
+			exceeded = true
+			cancel()
+		},
+	}
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, fields[0], fields[1:]...)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Stdout = stdout
+	cmd.Stderr = &stderr
+	cmd.WaitDelay = renderWaitDelay
+	if err := cmd.Run(); err != nil {
+		if exceeded {
+			return nil, fmt.Errorf("output of %q exceeds %d bytes", fields[0], maxRenderOutputSize)
+		} else if ctx.Err() == context.DeadlineExceeded {
+			return nil, fmt.Errorf("%q timed out after %s", fields[0], timeout)
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("run %q: %v - %s", fields[0], err, msg)
+		}
+		return nil, fmt.Errorf("run %q: %v", fields[0], err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// renderFailed returns the body as preformatted text, used when the body cannot
+// be rendered.
+func renderFailed(body []byte) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("<pre>")
+	buf.WriteString(html.EscapeString(string(body)))
+	buf.WriteString("</pre>")
+	return buf.Bytes()
+}
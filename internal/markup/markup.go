@@ -314,13 +314,18 @@ outerLoop:
 type Type string
 
 const (
-	TypeUnrecognized    Type = "unrecognized"
-	TypeMarkdown        Type = "markdown"
-	TypeOrgMode         Type = "orgmode"
-	TypeIPythonNotebook Type = "ipynb"
+	TypeUnrecognized     Type = "unrecognized"
+	TypeMarkdown         Type = "markdown"
+	TypeOrgMode          Type = "orgmode"
+	TypeIPythonNotebook  Type = "ipynb"
+	TypeCSV              Type = "csv"
+	TypeTSV              Type = "tsv"
+	TypeAsciiDoc         Type = "asciidoc"
+	TypeReStructuredText Type = "rst"
 )
 
-// Detect returns best guess of a markup type based on file name.
+// Detect returns best guess of a markup type based on file name. AsciiDoc and
+// reStructuredText files are only detected when a renderer is configured.
 func Detect(filename string) Type {
 	switch {
 	case IsMarkdownFile(filename):
@@ -329,6 +334,15 @@ func Detect(filename string) Type {
 		return TypeOrgMode
 	case IsIPythonNotebook(filename):
 		return TypeIPythonNotebook
+	case IsCSVFile(filename):
+		return TypeCSV
+	case IsTSVFile(filename):
+		return TypeTSV
+	case IsAsciiDocFile(filename) && conf.Markup.AsciiDoc.RenderCommand != "":
+		return TypeAsciiDoc
+	case IsReStructuredTextFile(filename) &&
+		(conf.Markup.ReStructuredText.RenderCommand != "" || conf.Markup.ReStructuredText.EnableBuiltinRenderer):
+		return TypeReStructuredText
 	default:
 		return TypeUnrecognized
 	}
@@ -353,6 +367,10 @@ func Render(typ Type, input any, urlPrefix string, metas map[string]string) []by
 		rawHTML = RawMarkdown(rawBytes, urlPrefix)
 	case TypeOrgMode:
 		rawHTML = RawOrgMode(rawBytes, urlPrefix)
+	case TypeAsciiDoc:
+		rawHTML = RawAsciiDoc(rawBytes)
+	case TypeReStructuredText:
+		rawHTML = RawReStructuredText(rawBytes)
 	default:
 		return rawBytes // Do nothing if syntax type is not recognized
 	}
@@ -0,0 +1,156 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package markup
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	rst "github.com/hhatto/gorst"
+	log "unknwon.dev/clog/v2"
+
+	"gogs.io/gogs/internal/conf"
+)
+
+// IsReStructuredTextFile reports whether name looks like a reStructuredText file based on its extension.
+func IsReStructuredTextFile(name string) bool {
+	extension := strings.ToLower(filepath.Ext(name))
+	for _, ext := range conf.Markup.ReStructuredText.FileExtensions {
+		if strings.ToLower(ext) == extension {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// maxBuiltinReStructuredTextSize is the maximum size in bytes of content to
+	// be rendered by the builtin renderer, larger content is displayed as plain
+	// text.
+	maxBuiltinReStructuredTextSize = 512 << 10
+	// maxBuiltinReStructuredTextBrackets is the maximum number of unclosed
+	// brackets in a paragraph to be rendered by the builtin renderer, whose
+	// parse time doubles with about every one of them.
+	maxBuiltinReStructuredTextBrackets = 10
+)
+
+// RawReStructuredText renders content in reStructuredText syntax to HTML
+// without handling special links. The external command is used when
+// configured, otherwise the builtin renderer is used if enabled.
+func RawReStructuredText(body []byte) []byte {
+	var result []byte
+	var err error
+	switch {
+	case conf.Markup.ReStructuredText.RenderCommand != "":
+		result, err = renderExternal(conf.Markup.ReStructuredText.RenderCommand, conf.Markup.ReStructuredText.RenderTimeout, body)
+	case conf.Markup.ReStructuredText.EnableBuiltinRenderer:
+		result, err = renderBuiltinReStructuredText(body, conf.Markup.ReStructuredText.RenderTimeout)
+	default:
+		return renderFailed(body)
+	}
+	if err != nil {
+		log.Error("Failed to render reStructuredText: %v", err)
+		return renderFailed(body)
+	}
+	return result
+}
+
+// unclosedBrackets returns the maximum number of unclosed brackets in any
+// paragraph of the body.
+func unclosedBrackets(body []byte) int {
+	var most, depth int
+	for _, line := range bytes.Split(body, []byte("\n")) {
+		if len(bytes.TrimSpace(line)) == 0 {
+			depth = 0
+			continue
+		}
+		for _, b := range line {
+			switch b {
+			case '[':
+				depth++
+				if depth > most {
+					most = depth
+				}
+			case ']':
+				if depth > 0 {
+					depth--
+				}
+			}
+		}
+	}
+	return most
+}
+
+// abandonedBuiltinRenders is the number of builtin renderings that are still
+// running after being given up.
+var abandonedBuiltinRenders atomic.Int32
+
+// renderBuiltinReStructuredText renders the body by the builtin renderer.
+// Content that is known to take the renderer exponential time is rejected
+// before parsing. The renderer cannot be interrupted, so it is given up after
+// the timeout and releases its slot of rendering, but no more renderings are
+// started while as many given up ones as slots are still running.
+func renderBuiltinReStructuredText(body []byte, timeout time.Duration) ([]byte, error) {
+	if len(body) > maxBuiltinReStructuredTextSize {
+		return nil, fmt.Errorf("content exceeds %d bytes", maxBuiltinReStructuredTextSize)
+	} else if n := unclosedBrackets(body); n > maxBuiltinReStructuredTextBrackets {
+		return nil, fmt.Errorf("content has %d unclosed brackets in a paragraph, exceeds %d", n, maxBuiltinReStructuredTextBrackets)
+	} else if n := abandonedBuiltinRenders.Load(); int(n) >= cap(renderSlots) {
+		return nil, fmt.Errorf("%d given up renderings are still running", n)
+	}
+
+	ctx, cancel := renderContext(timeout)
+	defer cancel()
+	if !acquireRenderSlot(ctx) {
+		return nil, fmt.Errorf("timed out after %s waiting for other renderings", timeout)
+	}
+	defer releaseRenderSlot()
+
+	const (
+		stateRunning = iota
+		stateFinished
+		stateAbandoned
+	)
+	var state atomic.Int32
+
+	type result struct {
+		data []byte
+		err  error
+	}
+	done := make(chan result, 1)
+	go func() {
+		var buf bytes.Buffer
+		w := bufio.NewWriter(&buf)
+		rst.NewParser(nil).ReStructuredText(bytes.NewReader(body), rst.ToHTML(w))
+		err := w.Flush()
+		if !state.CompareAndSwap(stateRunning, stateFinished) {
+			abandonedBuiltinRenders.Add(-1)
+		}
+		done <- result{data: buf.Bytes(), err: err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.data, r.err
+	case <-ctx.Done():
+		abandonedBuiltinRenders.Add(1)
+		if !state.CompareAndSwap(stateRunning, stateAbandoned) {
+			// Finished right after the timeout
+			abandonedBuiltinRenders.Add(-1)
+		}
+		return nil, fmt.Errorf("timed out after %s", timeout)
+	}
+}
+
+// ReStructuredText takes a string or []byte and renders to HTML in
+// reStructuredText syntax with special links.
+func ReStructuredText(input any, urlPrefix string, metas map[string]string) []byte {
+	return Render(TypeReStructuredText, input, urlPrefix, metas)
+}
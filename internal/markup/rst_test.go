@@ -0,0 +1,112 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package markup_test
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"gogs.io/gogs/internal/conf"
+	. "gogs.io/gogs/internal/markup"
+)
+
+func Test_IsReStructuredTextFile(t *testing.T) {
+	conf.Markup.ReStructuredText.FileExtensions = strings.Split(".rst,.rest", ",")
+	assert.True(t, IsReStructuredTextFile("README.rst"))
+	assert.True(t, IsReStructuredTextFile("docs/index.REST"))
+	assert.False(t, IsReStructuredTextFile("README.md"))
+}
+
+func Test_RawReStructuredText(t *testing.T) {
+	before := conf.Markup.ReStructuredText
+	t.Cleanup(func() {
+		conf.Markup.ReStructuredText = before
+	})
+
+	conf.Markup.ReStructuredText.RenderCommand = ""
+	body := "Title\n=====\n\nHello *world* and ``code``.\n"
+
+	t.Run("builtin renderer disabled", func(t *testing.T) {
+		conf.Markup.ReStructuredText.EnableBuiltinRenderer = false
+		assert.Equal(t, "<pre>"+body+"</pre>", string(RawReStructuredText([]byte(body))))
+	})
+
+	t.Run("builtin renderer enabled", func(t *testing.T) {
+		conf.Markup.ReStructuredText.EnableBuiltinRenderer = true
+		assert.Equal(t, "<h1>Title</h1>\n\n<p>Hello <em>world</em> and <code>code</code>.</p>\n", string(RawReStructuredText([]byte(body))))
+	})
+}
+
+func Test_RawReStructuredText_Limits(t *testing.T) {
+	before := conf.Markup.ReStructuredText
+	t.Cleanup(func() {
+		conf.Markup.ReStructuredText = before
+	})
+
+	t.Run("external command output too large", func(t *testing.T) {
+		if _, err := exec.LookPath("yes"); err != nil {
+			t.Skip("yes not found")
+		}
+		conf.Markup.ReStructuredText.RenderCommand = "yes"
+		conf.Markup.ReStructuredText.RenderTimeout = time.Minute
+		assert.Equal(t, "<pre>body</pre>", string(RawReStructuredText([]byte("body"))))
+	})
+
+	t.Run("builtin renderer", func(t *testing.T) {
+		conf.Markup.ReStructuredText.RenderCommand = ""
+		conf.Markup.ReStructuredText.EnableBuiltinRenderer = true
+		conf.Markup.ReStructuredText.RenderTimeout = time.Minute
+
+		tests := []struct {
+			name   string
+			body   string
+			expect string
+		}{
+			{
+				name:   "too many unclosed brackets",
+				body:   strings.Repeat("[a ", 40),
+				expect: "<pre>" + strings.Repeat("[a ", 40) + "</pre>",
+			},
+			{
+				name:   "unclosed brackets in separate paragraphs",
+				body:   strings.Repeat("[a [b [c\n\n", 10),
+				expect: strings.TrimSuffix(strings.Repeat("<p>[a [b [c</p>\n\n", 10), "\n"),
+			},
+			{
+				name:   "closed brackets",
+				body:   strings.Repeat("[a] ", 40),
+				expect: "<p>" + strings.Repeat("[a] ", 40) + "</p>\n",
+			},
+		}
+		for _, test := range tests {
+			t.Run(test.name, func(t *testing.T) {
+				assert.Equal(t, test.expect, string(RawReStructuredText([]byte(test.body))))
+			})
+		}
+	})
+}
+
+func Test_Detect_ReStructuredText(t *testing.T) {
+	before := conf.Markup.ReStructuredText
+	t.Cleanup(func() {
+		conf.Markup.ReStructuredText = before
+	})
+
+	conf.Markup.ReStructuredText.FileExtensions = []string{".rst"}
+	conf.Markup.ReStructuredText.RenderCommand = ""
+	conf.Markup.ReStructuredText.EnableBuiltinRenderer = false
+	assert.Equal(t, TypeUnrecognized, Detect("README.rst"))
+
+	conf.Markup.ReStructuredText.EnableBuiltinRenderer = true
+	assert.Equal(t, TypeReStructuredText, Detect("README.rst"))
+
+	conf.Markup.ReStructuredText.EnableBuiltinRenderer = false
+	conf.Markup.ReStructuredText.RenderCommand = "rst2html5"
+	assert.Equal(t, TypeReStructuredText, Detect("README.rst"))
+}
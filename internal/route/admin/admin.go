@@ -137,6 +137,7 @@ const (
 	SyncSSHAuthorizedKey
 	SyncRepositoryHooks
 	ReinitMissingRepository
+	RebuildIssueIndexes
 )
 
 func Operation(c *context.Context) {
@@ -164,6 +165,9 @@ func Operation(c *context.Context) {
 	case ReinitMissingRepository:
 		success = c.Tr("admin.dashboard.reinit_missing_repos_success")
 		err = database.ReinitMissingRepositories()
+	case RebuildIssueIndexes:
+		success = c.Tr("admin.dashboard.rebuild_issue_indexes_success")
+		err = database.RebuildIssueIndexes()
 	}
 
 	if err != nil {
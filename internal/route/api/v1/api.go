@@ -158,6 +158,26 @@ func reqRepoAdmin() macaron.Handler {
 	}
 }
 
+// reqOrgMember makes sure the context user is a member of the organization.
+func reqOrgMember() macaron.Handler {
+	return func(c *context.APIContext) {
+		if !c.IsLogged || !database.IsOrganizationMember(c.Org.Organization.ID, c.User.ID) {
+			c.Status(http.StatusForbidden)
+			return
+		}
+	}
+}
+
+// reqOrgOwner makes sure the context user is an owner of the organization.
+func reqOrgOwner() macaron.Handler {
+	return func(c *context.APIContext) {
+		if !c.IsLogged || !c.Org.Organization.IsOwnedBy(c.User.ID) {
+			c.Status(http.StatusForbidden)
+			return
+		}
+	}
+}
+
 func mustEnableIssues(c *context.APIContext) {
 	if !c.Repo.Repository.EnableIssues || c.Repo.Repository.EnableExternalTracker {
 		c.NotFound()
@@ -165,6 +185,13 @@ func mustEnableIssues(c *context.APIContext) {
 	}
 }
 
+func mustAllowPulls(c *context.APIContext) {
+	if !c.Repo.Repository.AllowsPulls() {
+		c.NotFound()
+		return
+	}
+}
+
 // RegisterRoutes registers all route in API v1 to the web application.
 // FIXME: custom form error response
 func RegisterRoutes(m *macaron.Macaron) {
@@ -232,6 +259,8 @@ func RegisterRoutes(m *macaron.Macaron) {
 			})
 
 			m.Get("/issues", repo.ListUserIssues)
+			m.Get("/times", repo.ListMyTrackedTimes)
+			m.Get("/stopwatches", repo.ListMyStopwatches)
 		}, reqToken())
 
 		// Repositories
@@ -242,8 +271,11 @@ func RegisterRoutes(m *macaron.Macaron) {
 			Post(bind(api.CreateRepoOption{}), repo.Create)
 		m.Post("/org/:org/repos", reqToken(), bind(api.CreateRepoOption{}), repo.CreateOrgRepo)
 
+		m.Get("/search/code", repo.SearchAllCode)
+
 		m.Group("/repos", func() {
 			m.Get("/search", repo.Search)
+			m.Get("/:username/:reponame/search/code", repoAssignment(), repo.SearchCode)
 
 			m.Get("/:username/:reponame", repoAssignment(), repo.Get)
 			m.Get("/:username/:reponame/releases", repoAssignment(), repo.Releases)
@@ -272,6 +304,9 @@ func RegisterRoutes(m *macaron.Macaron) {
 				}, reqRepoAdmin())
 
 				m.Get("/raw/*", context.RepoRef(), repo.GetRawFile)
+				m.Get("/blame/*", context.RepoRef(), repo.GetBlame)
+				m.Get("/languages", repo.ListLanguages)
+				m.Get("/compare/*", repo.Compare)
 				m.Group("/contents", func() {
 					m.Get("", repo.GetContents)
 					m.Combo("/*").
@@ -296,8 +331,13 @@ func RegisterRoutes(m *macaron.Macaron) {
 				m.Group("/commits", func() {
 					m.Get("/:sha", repo.GetSingleCommit)
 					m.Get("", repo.GetAllCommits)
+					m.Get("/:ref/statuses", repo.ListCommitStatusesByRef)
+					m.Get("/:ref/status", repo.GetCombinedCommitStatus)
 					m.Get("/*", repo.GetReferenceSHA)
 				})
+				m.Combo("/statuses/:sha").
+					Get(repo.ListCommitStatuses).
+					Post(reqRepoWriter(), bind(repo.CreateCommitStatusRequest{}), repo.CreateCommitStatus)
 
 				m.Group("/keys", func() {
 					m.Combo("").
@@ -311,15 +351,23 @@ func RegisterRoutes(m *macaron.Macaron) {
 				m.Group("/issues", func() {
 					m.Combo("").
 						Get(repo.ListIssues).
-						Post(bind(api.CreateIssueOption{}), repo.CreateIssue)
+						Post(bind(repo.CreateIssueRequest{}), repo.CreateIssue)
 					m.Group("/comments", func() {
 						m.Get("", repo.ListRepoIssueComments)
 						m.Patch("/:id", bind(api.EditIssueCommentOption{}), repo.EditIssueComment)
+						m.Combo("/:id/reactions").
+							Get(repo.ListReactions).
+							Post(bind(repo.ReactionOption{}), repo.AddReaction).
+							Delete(bind(repo.ReactionOption{}), repo.RemoveReaction)
 					})
 					m.Group("/:index", func() {
 						m.Combo("").
 							Get(repo.GetIssue).
-							Patch(bind(api.EditIssueOption{}), repo.EditIssue)
+							Patch(bind(repo.EditIssueRequest{}), repo.EditIssue)
+						m.Post("/transfer", reqRepoWriter(), bind(repo.TransferIssueOption{}), repo.TransferIssue)
+						m.Combo("/lock", reqRepoWriter()).
+							Put(bind(repo.LockIssueOption{}), repo.LockIssue).
+							Delete(repo.UnlockIssue)
 
 						m.Group("/comments", func() {
 							m.Combo("").
@@ -338,8 +386,49 @@ func RegisterRoutes(m *macaron.Macaron) {
 								Delete(repo.ClearIssueLabels)
 							m.Delete("/:id", repo.DeleteIssueLabel)
 						}, reqRepoWriter())
+
+						m.Get("/blocks", repo.ListIssueBlocks)
+						m.Combo("/dependencies").
+							Get(repo.ListIssueDependencies).
+							Post(reqRepoWriter(), bind(repo.IssueDependencyOption{}), repo.AddIssueDependency).
+							Delete(reqRepoWriter(), bind(repo.IssueDependencyOption{}), repo.RemoveIssueDependency)
+
+						m.Combo("/reactions").
+							Get(repo.ListReactions).
+							Post(bind(repo.ReactionOption{}), repo.AddReaction).
+							Delete(bind(repo.ReactionOption{}), repo.RemoveReaction)
+
+						m.Group("/times", func() {
+							m.Combo("").
+								Get(repo.ListIssueTrackedTimes).
+								Post(reqRepoWriter(), bind(repo.AddTrackedTimeOption{}), repo.AddIssueTrackedTime)
+							m.Delete("/:id", reqRepoWriter(), repo.DeleteIssueTrackedTime)
+						})
+						m.Group("/stopwatch", func() {
+							m.Post("/start", repo.StartIssueStopwatch)
+							m.Post("/stop", repo.StopIssueStopwatch)
+							m.Delete("", repo.CancelIssueStopwatch)
+						}, reqRepoWriter())
 					})
 				}, mustEnableIssues)
+				m.Get("/times", mustEnableIssues, repo.ListRepoTrackedTimes)
+				m.Group("/projects", func() {
+					m.Get("", repo.ListProjects)
+					m.Get("/:id", repo.GetProject)
+					m.Group("/:id/cards", func() {
+						m.Post("", bind(repo.AddProjectCardOption{}), repo.AddProjectCard)
+						m.Post("/:card/move", bind(repo.MoveProjectCardOption{}), repo.MoveProjectCard)
+						m.Delete("/:card", repo.DeleteProjectCard)
+					}, reqRepoWriter())
+				}, mustEnableIssues)
+				m.Get("/issue_templates", mustEnableIssues, repo.ListIssueTemplates)
+				m.Get("/pull_request_template", mustAllowPulls, repo.GetPullRequestTemplate)
+
+				m.Group("/pulls", func() {
+					m.Get("/:index([0-9]+)\\.:ext(patch|diff)", repo.GetPullRequestDiff)
+					m.Get("/:index/files", repo.ListPullRequestFiles)
+					m.Get("/:index/commits", repo.ListPullRequestCommits)
+				}, mustAllowPulls)
 
 				m.Group("/labels", func() {
 					m.Get("", repo.ListLabels)
@@ -383,6 +472,25 @@ func RegisterRoutes(m *macaron.Macaron) {
 				Get(org.Get).
 				Patch(bind(api.EditOrgOption{}), org.Edit)
 			m.Get("/teams", org.ListTeams)
+
+			m.Group("/projects", func() {
+				m.Get("", repo.ListProjects)
+				m.Get("/:id", repo.GetProject)
+				m.Post("/:id/cards", bind(repo.AddProjectCardOption{}), repo.AddProjectCard)
+				m.Post("/:id/cards/:card/move", bind(repo.MoveProjectCardOption{}), repo.MoveProjectCard)
+				m.Delete("/:id/cards/:card", repo.DeleteProjectCard)
+			}, reqOrgMember())
+
+			m.Group("/labels", func() {
+				m.Combo("").
+					Get(org.ListLabels).
+					Post(reqOrgOwner(), bind(api.CreateLabelOption{}), org.CreateLabel)
+				m.Post("/merge", reqOrgOwner(), org.MergeLabels)
+				m.Combo("/:id").
+					Get(org.GetLabel).
+					Patch(reqOrgOwner(), bind(api.EditLabelOption{}), org.EditLabel).
+					Delete(reqOrgOwner(), org.DeleteLabel)
+			}, reqOrgMember())
 		}, orgAssignment(true))
 
 		m.Group("/admin", func() {
@@ -0,0 +1,94 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package org
+
+import (
+	"net/http"
+
+	api "github.com/gogs/go-gogs-client"
+
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/database"
+)
+
+func ListLabels(c *context.APIContext) {
+	labels, err := database.GetLabelsByOrgID(c.Org.Organization.ID)
+	if err != nil {
+		c.Error(err, "get labels by organization ID")
+		return
+	}
+
+	apiLabels := make([]*api.Label, len(labels))
+	for i := range labels {
+		apiLabels[i] = labels[i].APIFormat()
+	}
+	c.JSONSuccess(&apiLabels)
+}
+
+func GetLabel(c *context.APIContext) {
+	label, err := database.GetLabelOfOrgByID(c.Org.Organization.ID, c.ParamsInt64(":id"))
+	if err != nil {
+		c.NotFoundOrError(err, "get label of organization by ID")
+		return
+	}
+
+	c.JSONSuccess(label.APIFormat())
+}
+
+func CreateLabel(c *context.APIContext, form api.CreateLabelOption) {
+	label := &database.Label{
+		Name:  form.Name,
+		Color: form.Color,
+		OrgID: c.Org.Organization.ID,
+	}
+	if err := database.NewLabels(label); err != nil {
+		c.Error(err, "new labels")
+		return
+	}
+	c.JSON(http.StatusCreated, label.APIFormat())
+}
+
+func EditLabel(c *context.APIContext, form api.EditLabelOption) {
+	label, err := database.GetLabelOfOrgByID(c.Org.Organization.ID, c.ParamsInt64(":id"))
+	if err != nil {
+		c.NotFoundOrError(err, "get label of organization by ID")
+		return
+	}
+
+	if form.Name != nil {
+		label.Name = *form.Name
+	}
+	if form.Color != nil {
+		label.Color = *form.Color
+	}
+	if err := database.UpdateLabel(label); err != nil {
+		c.Error(err, "update label")
+		return
+	}
+	c.JSONSuccess(label.APIFormat())
+}
+
+func DeleteLabel(c *context.APIContext) {
+	if err := database.DeleteOrgLabel(c.Org.Organization.ID, c.ParamsInt64(":id")); err != nil {
+		c.Error(err, "delete label")
+		return
+	}
+
+	c.NoContent()
+}
+
+// MergeLabels replaces labels of repositories of the organization with the
+// labels of the organization that have identical names.
+func MergeLabels(c *context.APIContext) {
+	merged, err := database.MergeRepoLabelsIntoOrg(c.Org.Organization.ID)
+	if err != nil {
+		c.Error(err, "merge repository labels into organization")
+		return
+	}
+
+	c.JSONSuccess(map[string]int{
+		"merged": merged,
+	})
+}
@@ -0,0 +1,114 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+	"time"
+
+	api "github.com/gogs/go-gogs-client"
+
+	"gogs.io/gogs/internal/conf"
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/gitutil"
+)
+
+// BlameCommit is the API representation of a commit that last changed lines of
+// a file.
+type BlameCommit struct {
+	SHA     string          `json:"sha"`
+	HTMLURL string          `json:"html_url"`
+	Author  *api.CommitUser `json:"author"`
+	Summary string          `json:"summary"`
+	// Previous is the parent commit and the path of the file in it to blame
+	// prior to this change, which is nil if the commit added the lines.
+	Previous *BlamePrevious `json:"previous"`
+}
+
+// BlamePrevious is the API representation of the file prior to a change.
+type BlamePrevious struct {
+	SHA  string `json:"sha"`
+	Path string `json:"path"`
+}
+
+// BlameRange is the API representation of consecutive lines of a file that
+// were last changed by the same commit.
+type BlameRange struct {
+	Commit    *BlameCommit `json:"commit"`
+	StartLine int          `json:"start_line"`
+	EndLine   int          `json:"end_line"`
+	Lines     []string     `json:"lines"`
+}
+
+// GetBlame returns blame results of a file.
+func GetBlame(c *context.APIContext) {
+	if c.Repo.Repository.IsBare {
+		c.NotFound()
+		return
+	}
+
+	entry, err := c.Repo.Commit.TreeEntry(c.Repo.TreePath)
+	if err != nil {
+		c.NotFoundOrError(gitutil.NewError(err), "get tree entry")
+		return
+	} else if entry.IsTree() || entry.IsCommit() {
+		c.NotFound()
+		return
+	}
+
+	_, err = gitutil.ReadBlameBlob(entry.Blob(), conf.UI.MaxDisplayFileSize)
+	if err != nil {
+		if err == gitutil.ErrBlameFileTooLarge || err == gitutil.ErrBlameBinaryFile {
+			c.ErrorStatus(http.StatusUnprocessableEntity, err)
+		} else {
+			c.Error(err, "read blob")
+		}
+		return
+	}
+
+	parts, err := gitutil.BlameFile(
+		c.Repo.GitRepo.Path(),
+		c.Repo.CommitID,
+		c.Repo.TreePath,
+		time.Duration(conf.Git.Timeout.Blame)*time.Second,
+	)
+	if err != nil {
+		c.Error(err, "blame file")
+		return
+	}
+
+	commits := make(map[*gitutil.BlameCommit]*BlameCommit)
+	ranges := make([]*BlameRange, len(parts))
+	for i, part := range parts {
+		commit, ok := commits[part.Commit]
+		if !ok {
+			commit = &BlameCommit{
+				SHA:     part.Commit.ID,
+				HTMLURL: c.Repo.Repository.HTMLURL() + "/commit/" + part.Commit.ID,
+				Author: &api.CommitUser{
+					Name:  part.Commit.Author,
+					Email: part.Commit.AuthorEmail,
+					Date:  part.Commit.AuthorTime.Format(time.RFC3339),
+				},
+				Summary: part.Commit.Summary,
+			}
+			if part.Commit.PreviousID != "" {
+				commit.Previous = &BlamePrevious{
+					SHA:  part.Commit.PreviousID,
+					Path: part.Commit.PreviousPath,
+				}
+			}
+			commits[part.Commit] = commit
+		}
+
+		ranges[i] = &BlameRange{
+			Commit:    commit,
+			StartLine: part.StartLine,
+			EndLine:   part.StartLine + len(part.Lines) - 1,
+			Lines:     part.Lines,
+		}
+	}
+	c.JSONSuccess(ranges)
+}
@@ -18,7 +18,17 @@ import (
 	"gogs.io/gogs/internal/gitutil"
 )
 
-// GetAllCommits returns a slice of commits starting from HEAD.
+// FileCommit is a commit that changed a file, along with the path of the file
+// in the commit.
+type FileCommit struct {
+	*api.Commit
+	Path string `json:"path"`
+}
+
+// GetAllCommits returns a slice of commits starting from HEAD. When the "path"
+// is given, only commits that changed the path starting from the "sha" are
+// returned by the "page", and renames of the file are followed with the
+// "follow" set.
 func GetAllCommits(c *context.APIContext) {
 	// Get pagesize, set default if it is not specified.
 	pageSize := c.QueryInt("pageSize")
@@ -32,22 +42,82 @@ func GetAllCommits(c *context.APIContext) {
 		return
 	}
 
-	// The response object returned as JSON
-	result := make([]*api.Commit, 0, pageSize)
+	treePath := c.Query("path")
+	if treePath != "" {
+		getFileCommits(c, gitRepo, treePath, pageSize)
+		return
+	}
+
 	commits, err := gitRepo.Log("HEAD", git.LogOptions{MaxCount: pageSize})
 	if err != nil {
 		c.Error(err, "git log")
+		return
 	}
 
-	for _, commit := range commits {
-		apiCommit, err := gitCommitToAPICommit(commit, c)
+	result, err := gitCommitsToAPICommits(commits, c)
+	if err != nil {
+		c.Error(err, "convert git commits to api commits")
+		return
+	}
+	c.JSONSuccess(result)
+}
+
+func getFileCommits(c *context.APIContext, gitRepo *git.Repository, treePath string, pageSize int) {
+	rev := c.Query("sha")
+	if rev == "" {
+		rev = "HEAD"
+	}
+	start, err := gitRepo.CatFileCommit(rev)
+	if err != nil {
+		c.NotFoundOrError(gitutil.NewError(err), "get commit")
+		return
+	}
+	rev = start.ID.String()
+
+	page := c.QueryInt("page")
+	if page < 1 {
+		page = 1
+	}
+	skip := (page - 1) * pageSize
+
+	var commits []*git.Commit
+	paths := make(map[string]string)
+	if c.QueryBool("follow") {
+		entries, err := gitutil.FollowFileHistory(gitRepo.Path(), rev, treePath, skip, pageSize, time.Duration(conf.Git.Timeout.Log)*time.Second)
+		if err != nil {
+			c.Error(err, "follow file history")
+			return
+		}
+		for _, e := range entries {
+			commit, err := gitRepo.CatFileCommit(e.CommitID)
+			if err != nil {
+				c.Error(err, "get commit")
+				return
+			}
+			commits = append(commits, commit)
+			paths[e.CommitID] = e.Path
+		}
+	} else {
+		commits, err = gitRepo.Log(rev, git.LogOptions{MaxCount: pageSize, Skip: skip, Path: treePath})
 		if err != nil {
-			c.Error(err, "convert git commit to api commit")
+			c.Error(err, "git log")
 			return
 		}
-		result = append(result, apiCommit)
 	}
 
+	apiCommits, err := gitCommitsToAPICommits(commits, c)
+	if err != nil {
+		c.Error(err, "convert git commits to api commits")
+		return
+	}
+	result := make([]*FileCommit, 0, len(commits))
+	for i, commit := range commits {
+		path := paths[commit.ID.String()]
+		if path == "" {
+			path = treePath
+		}
+		result = append(result, &FileCommit{Commit: apiCommits[i], Path: path})
+	}
 	c.JSONSuccess(result)
 }
 
@@ -119,27 +189,42 @@ func GetReferenceSHA(c *context.APIContext) {
 
 // gitCommitToApiCommit is a helper function to convert git commit object to API commit.
 func gitCommitToAPICommit(commit *git.Commit, c *context.APIContext) (*api.Commit, error) {
-	// Retrieve author and committer information
-	var apiAuthor, apiCommitter *api.User
-	author, err := database.Handle.Users().GetByEmail(c.Req.Context(), commit.Author.Email)
-	if err != nil && !database.IsErrUserNotExist(err) {
+	apiCommits, err := gitCommitsToAPICommits([]*git.Commit{commit}, c)
+	if err != nil {
 		return nil, err
-	} else if err == nil {
-		apiAuthor = author.APIFormat()
 	}
+	return apiCommits[0], nil
+}
 
-	// Save one query if the author is also the committer
-	if commit.Committer.Email == commit.Author.Email {
-		apiCommitter = apiAuthor
-	} else {
-		committer, err := database.Handle.Users().GetByEmail(c.Req.Context(), commit.Committer.Email)
-		if err != nil && !database.IsErrUserNotExist(err) {
-			return nil, err
-		} else if err == nil {
-			apiCommitter = committer.APIFormat()
-		}
+// gitCommitsToAPICommits converts git commits to API commits, where authors
+// and committers of all commits are looked up in batch.
+func gitCommitsToAPICommits(commits []*git.Commit, c *context.APIContext) ([]*api.Commit, error) {
+	emails := make([]string, 0, len(commits)*2)
+	for _, commit := range commits {
+		emails = append(emails, commit.Author.Email, commit.Committer.Email)
+	}
+	users, err := database.Handle.Users().GetByEmails(c.Req.Context(), emails)
+	if err != nil {
+		return nil, err
 	}
 
+	apiUsers := make(map[string]*api.User, len(users))
+	for email, u := range users {
+		apiUsers[email] = u.APIFormat()
+	}
+	apiCommits := make([]*api.Commit, 0, len(commits))
+	for _, commit := range commits {
+		apiCommits = append(apiCommits, newAPICommit(commit, c, apiUsers))
+	}
+	return apiCommits, nil
+}
+
+// newAPICommit converts the git commit to API commit with users keyed by their
+// lowercased emails.
+func newAPICommit(commit *git.Commit, c *context.APIContext, users map[string]*api.User) *api.Commit {
+	apiAuthor := users[strings.ToLower(commit.Author.Email)]
+	apiCommitter := users[strings.ToLower(commit.Committer.Email)]
+
 	// Retrieve parent(s) of the commit
 	apiParents := make([]*api.CommitMeta, commit.ParentsCount())
 	for i := 0; i < commit.ParentsCount(); i++ {
@@ -177,5 +262,5 @@ func gitCommitToAPICommit(commit *git.Commit, c *context.APIContext) (*api.Commi
 		Author:    apiAuthor,
 		Committer: apiCommitter,
 		Parents:   apiParents,
-	}, nil
+	}
 }
@@ -0,0 +1,128 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gogs/git-module"
+	api "github.com/gogs/go-gogs-client"
+
+	"gogs.io/gogs/internal/conf"
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/gitutil"
+	"gogs.io/gogs/internal/route/repo"
+)
+
+// Comparison is the API representation of the comparison between two
+// revisions. Only the most recent commits of a large comparison are listed.
+type Comparison struct {
+	BaseCommitSHA string `json:"base_commit_sha"`
+	// MergeBaseCommitSHA is empty for direct comparisons.
+	MergeBaseCommitSHA string             `json:"merge_base_commit_sha"`
+	HeadCommitSHA      string             `json:"head_commit_sha"`
+	HTMLURL            string             `json:"html_url"`
+	PatchURL           string             `json:"patch_url"`
+	DiffURL            string             `json:"diff_url"`
+	TotalCommits       int                `json:"total_commits"`
+	Commits            []*api.Commit      `json:"commits"`
+	Additions          int                `json:"additions"`
+	Deletions          int                `json:"deletions"`
+	Files              []*PullRequestFile `json:"files"`
+}
+
+// Compare returns commits and changed files between two revisions in the form
+// of "<base>...<head>" or "<base>..<head>", or the raw patch or diff with the
+// ".patch" or ".diff" suffix.
+func Compare(c *context.APIContext) {
+	spec := c.Params("*")
+	var rawFormat git.RawDiffFormat
+	for _, format := range []git.RawDiffFormat{git.RawDiffPatch, git.RawDiffNormal} {
+		if strings.HasSuffix(spec, "."+string(format)) {
+			rawFormat = format
+			spec = strings.TrimSuffix(spec, "."+string(format))
+			break
+		}
+	}
+
+	r, ok := gitutil.ParseCompareRange(spec)
+	if !ok {
+		c.NotFound()
+		return
+	}
+
+	gitRepo, err := git.Open(c.Repo.Repository.RepoPath())
+	if err != nil {
+		c.Error(err, "open repository")
+		return
+	}
+
+	timeout := time.Duration(conf.Git.Timeout.Diff) * time.Second
+	cmp, err := gitutil.Compare(gitRepo, r, timeout)
+	if err != nil {
+		if gitutil.IsErrNoMergeBase(err) {
+			c.NotFound()
+			return
+		}
+		c.NotFoundOrError(gitutil.NewError(err), "compare revisions")
+		return
+	}
+
+	if rawFormat != "" {
+		data, err := cmp.RawDiff(gitRepo.Path(), rawFormat, gitutil.CompareMaxRawDiffSize, timeout)
+		if err != nil {
+			if err == gitutil.ErrRawDiffTooLarge {
+				c.ErrorStatus(http.StatusUnprocessableEntity, err)
+				return
+			}
+			c.Error(err, "get raw comparison")
+			return
+		}
+		repo.ServeRawDiff(c.Context, data)
+		return
+	}
+
+	commits, err := cmp.Commits(gitRepo, gitutil.CompareMaxCommits, timeout)
+	if err != nil {
+		c.Error(err, "list commits")
+		return
+	}
+	numCommits, err := cmp.NumCommits(gitRepo, timeout)
+	if err != nil {
+		c.Error(err, "count commits")
+		return
+	}
+	apiCommits, err := gitCommitsToAPICommits(commits, c)
+	if err != nil {
+		c.Error(err, "convert git commits to api commits")
+		return
+	}
+
+	diff, err := gitutil.RepoDiff(gitRepo,
+		cmp.HeadCommitID, conf.Git.MaxDiffFiles, conf.Git.MaxDiffLines, conf.Git.MaxDiffLineChars,
+		git.DiffOptions{Base: cmp.DiffBase(), Timeout: timeout},
+	)
+	if err != nil {
+		c.Error(err, "get diff")
+		return
+	}
+
+	htmlURL := c.Repo.Repository.HTMLURL() + "/compare/" + r.String()
+	c.JSONSuccess(&Comparison{
+		BaseCommitSHA:      cmp.BaseCommitID,
+		MergeBaseCommitSHA: cmp.MergeBase,
+		HeadCommitSHA:      cmp.HeadCommitID,
+		HTMLURL:            htmlURL,
+		PatchURL:           htmlURL + ".patch",
+		DiffURL:            htmlURL + ".diff",
+		TotalCommits:       int(numCommits),
+		Commits:            apiCommits,
+		Additions:          diff.TotalAdditions(),
+		Deletions:          diff.TotalDeletions(),
+		Files:              toPullRequestFiles(diff),
+	})
+}
@@ -7,7 +7,7 @@ package repo
 import (
 	"fmt"
 	"net/http"
-	"strings"
+	"time"
 
 	api "github.com/gogs/go-gogs-client"
 
@@ -16,7 +16,146 @@ import (
 	"gogs.io/gogs/internal/database"
 )
 
+// Issue is the API representation of an issue with all its assignees and,
+// for pull requests, requested reviewers.
+type Issue struct {
+	*api.Issue
+	Assignees          []*api.User    `json:"assignees"`
+	RequestedReviewers []*api.User    `json:"requested_reviewers,omitempty"`
+	DueDate            *time.Time     `json:"due_date"`
+	Reactions          map[string]int `json:"reactions"`
+	IsLocked           bool           `json:"is_locked"`
+	LockReason         string         `json:"lock_reason,omitempty"`
+}
+
+// LockIssueOption is the API message for locking the conversation of an
+// issue.
+type LockIssueOption struct {
+	Reason string `json:"lock_reason"`
+}
+
+// CreateIssueRequest is the API message for creating an issue, the Assignees
+// takes precedence over the Assignee of the embedded option.
+type CreateIssueRequest struct {
+	api.CreateIssueOption
+	Assignees []string   `json:"assignees"`
+	DueDate   *time.Time `json:"due_date"`
+}
+
+// EditIssueRequest is the API message for editing an issue, the Assignees
+// takes precedence over the Assignee of the embedded option. The due date is
+// removed when UnsetDueDate is true.
+type EditIssueRequest struct {
+	api.EditIssueOption
+	Assignees          *[]string  `json:"assignees"`
+	RequestedReviewers *[]string  `json:"requested_reviewers"`
+	DueDate            *time.Time `json:"due_date"`
+	UnsetDueDate       bool       `json:"unset_due_date"`
+}
+
+func toAPIUsers(users []*database.User) []*api.User {
+	apiUsers := make([]*api.User, len(users))
+	for i := range users {
+		apiUsers[i] = users[i].APIFormat()
+	}
+	return apiUsers
+}
+
+// toAPIIssue converts the issue to its API format. This function assumes
+// attributes of the issue have been loaded.
+func toAPIIssue(issue *database.Issue) *Issue {
+	apiIssue := &Issue{
+		Issue:      issue.APIFormat(),
+		Assignees:  toAPIUsers(issue.Assignees),
+		Reactions:  issue.Reactions.Counts(),
+		IsLocked:   issue.IsLocked,
+		LockReason: issue.LockReason,
+	}
+	if issue.IsPull {
+		apiIssue.RequestedReviewers = toAPIUsers(issue.RequestedReviewers)
+	}
+	if issue.HasDeadline() {
+		apiIssue.DueDate = &issue.Deadline
+	}
+	return apiIssue
+}
+
+// getUserIDsByNames returns IDs of users with given names, it responds with
+// 422 when any of users does not exist.
+func getUserIDsByNames(c *context.APIContext, names []string) ([]int64, bool) {
+	ids := make([]int64, 0, len(names))
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+
+		u, err := database.Handle.Users().GetByUsername(c.Req.Context(), name)
+		if err != nil {
+			if database.IsErrUserNotExist(err) {
+				c.ErrorStatus(http.StatusUnprocessableEntity, fmt.Errorf("user does not exist: [name: %s]", name))
+			} else {
+				c.Error(err, "get user by name")
+			}
+			return nil, false
+		}
+		ids = append(ids, u.ID)
+	}
+	return ids, true
+}
+
+// getAssignableUserIDsByNames is like getUserIDsByNames but also responds with
+// 422 listing users that cannot be assigned to issues of the repository.
+func getAssignableUserIDsByNames(c *context.APIContext, names []string) ([]int64, bool) {
+	ids, ok := getUserIDsByNames(c, names)
+	if !ok {
+		return nil, false
+	}
+
+	err := database.ValidateAssignableUsers(c.Repo.Repository, ids)
+	if err == nil {
+		return ids, true
+	} else if !database.IsErrUserNotAssignable(err) {
+		c.Error(err, "validate assignable users")
+		return nil, false
+	}
+
+	rejected := make(map[int64]bool, len(ids))
+	for _, id := range err.(database.ErrUserNotAssignable).UserIDs {
+		rejected[id] = true
+	}
+	// Empty names are skipped by getUserIDsByNames.
+	rejectedNames := make([]string, 0, len(rejected))
+	i := 0
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		if rejected[ids[i]] {
+			rejectedNames = append(rejectedNames, name)
+			rejected[ids[i]] = false
+		}
+		i++
+	}
+	c.ErrorStatus(http.StatusUnprocessableEntity, fmt.Errorf("users are not assignable: %v", rejectedNames))
+	return nil, false
+}
+
 func listIssues(c *context.APIContext, opts *database.IssuesOptions) {
+	q := database.ParseIssueQuery(c.Query("q"))
+	if q.State != "" {
+		opts.IsClosed = q.State == "closed"
+	}
+	if q.Sort != "" {
+		opts.SortType = q.Sort
+	}
+
+	var err error
+	opts.Filter, err = q.Filter(c.Req.Context(), c.User)
+	if err != nil {
+		c.Error(err, "get issue filter")
+		return
+	}
+
 	issues, err := database.Issues(opts)
 	if err != nil {
 		c.Error(err, "list issues")
@@ -30,13 +169,13 @@ func listIssues(c *context.APIContext, opts *database.IssuesOptions) {
 	}
 
 	// FIXME: use IssueList to improve performance.
-	apiIssues := make([]*api.Issue, len(issues))
+	apiIssues := make([]*Issue, len(issues))
 	for i := range issues {
 		if err = issues[i].LoadAttributes(); err != nil {
 			c.Error(err, "load attributes")
 			return
 		}
-		apiIssues[i] = issues[i].APIFormat()
+		apiIssues[i] = toAPIIssue(issues[i])
 	}
 
 	c.SetLinkHeader(int(count), conf.UI.IssuePagingNum)
@@ -48,6 +187,7 @@ func ListUserIssues(c *context.APIContext) {
 		AssigneeID: c.User.ID,
 		Page:       c.QueryInt("page"),
 		IsClosed:   api.StateType(c.Query("state")) == api.STATE_CLOSED,
+		Due:        database.ParseDueFilter(c.Query("due")),
 	}
 
 	listIssues(c, &opts)
@@ -58,21 +198,21 @@ func ListIssues(c *context.APIContext) {
 		RepoID:   c.Repo.Repository.ID,
 		Page:     c.QueryInt("page"),
 		IsClosed: api.StateType(c.Query("state")) == api.STATE_CLOSED,
+		Due:      database.ParseDueFilter(c.Query("due")),
 	}
 
 	listIssues(c, &opts)
 }
 
 func GetIssue(c *context.APIContext) {
-	issue, err := database.GetIssueByIndex(c.Repo.Repository.ID, c.ParamsInt64(":index"))
-	if err != nil {
-		c.NotFoundOrError(err, "get issue by index")
+	issue := getIssueByIndex(c, "")
+	if c.Written() {
 		return
 	}
-	c.JSONSuccess(issue.APIFormat())
+	c.JSONSuccess(toAPIIssue(issue))
 }
 
-func CreateIssue(c *context.APIContext, form api.CreateIssueOption) {
+func CreateIssue(c *context.APIContext, form CreateIssueRequest) {
 	issue := &database.Issue{
 		RepoID:   c.Repo.Repository.ID,
 		Title:    form.Title,
@@ -81,32 +221,42 @@ func CreateIssue(c *context.APIContext, form api.CreateIssueOption) {
 		Content:  form.Body,
 	}
 
+	var assigneeIDs []int64
 	if c.Repo.IsWriter() {
-		if len(form.Assignee) > 0 {
-			assignee, err := database.Handle.Users().GetByUsername(c.Req.Context(), form.Assignee)
-			if err != nil {
-				if database.IsErrUserNotExist(err) {
-					c.ErrorStatus(http.StatusUnprocessableEntity, fmt.Errorf("assignee does not exist: [name: %s]", form.Assignee))
-				} else {
-					c.Error(err, "get user by name")
-				}
-				return
-			}
-			issue.AssigneeID = assignee.ID
+		assignees := form.Assignees
+		if assignees == nil && len(form.Assignee) > 0 {
+			assignees = []string{form.Assignee}
+		}
+		var ok bool
+		assigneeIDs, ok = getAssignableUserIDsByNames(c, assignees)
+		if !ok {
+			return
 		}
 		issue.MilestoneID = form.Milestone
+		if form.DueDate != nil {
+			issue.Deadline = database.DeadlineOf(*form.DueDate)
+		}
 	} else {
 		form.Labels = nil
 	}
 
-	if err := database.NewIssue(c.Repo.Repository, issue, form.Labels, nil); err != nil {
+	if err := database.NewIssueWithOptions(database.NewIssueOptions{
+		Repo:        c.Repo.Repository,
+		Issue:       issue,
+		LableIDs:    form.Labels,
+		AssigneeIDs: assigneeIDs,
+	}); err != nil {
 		c.Error(err, "new issue")
 		return
 	}
 
 	if form.Closed {
 		if err := issue.ChangeStatus(c.User, c.Repo.Repository, true); err != nil {
-			c.Error(err, "change status to closed")
+			if database.IsErrIssueBlocked(err) {
+				c.ErrorStatus(http.StatusUnprocessableEntity, err)
+			} else {
+				c.Error(err, "change status to closed")
+			}
 			return
 		}
 	}
@@ -118,10 +268,10 @@ func CreateIssue(c *context.APIContext, form api.CreateIssueOption) {
 		c.Error(err, "get issue by ID")
 		return
 	}
-	c.JSON(http.StatusCreated, issue.APIFormat())
+	c.JSON(http.StatusCreated, toAPIIssue(issue))
 }
 
-func EditIssue(c *context.APIContext, form api.EditIssueOption) {
+func EditIssue(c *context.APIContext, form EditIssueRequest) {
 	issue, err := database.GetIssueByIndex(c.Repo.Repository.ID, c.ParamsInt64(":index"))
 	if err != nil {
 		c.NotFoundOrError(err, "get issue by index")
@@ -134,31 +284,49 @@ func EditIssue(c *context.APIContext, form api.EditIssueOption) {
 	}
 
 	if len(form.Title) > 0 {
-		issue.Title = form.Title
+		if err = issue.ChangeTitle(c.User, form.Title); err != nil {
+			c.Error(err, "change title")
+			return
+		}
 	}
 	if form.Body != nil {
 		issue.Content = *form.Body
 	}
 
-	if c.Repo.IsWriter() && form.Assignee != nil &&
-		(issue.Assignee == nil || issue.Assignee.LowerName != strings.ToLower(*form.Assignee)) {
-		if *form.Assignee == "" {
-			issue.AssigneeID = 0
-		} else {
-			assignee, err := database.Handle.Users().GetByUsername(c.Req.Context(), *form.Assignee)
-			if err != nil {
-				if database.IsErrUserNotExist(err) {
-					c.ErrorStatus(http.StatusUnprocessableEntity, fmt.Errorf("assignee does not exist: [name: %s]", *form.Assignee))
-				} else {
-					c.Error(err, "get user by name")
-				}
+	if c.Repo.IsWriter() {
+		assignees := form.Assignees
+		if assignees == nil && form.Assignee != nil {
+			assignees = &[]string{*form.Assignee}
+		}
+		if assignees != nil {
+			assigneeIDs, ok := getAssignableUserIDsByNames(c, *assignees)
+			if !ok {
+				return
+			}
+			if err = issue.ChangeAssignees(c.User, assigneeIDs); err != nil {
+				c.Error(err, "change assignees")
 				return
 			}
-			issue.AssigneeID = assignee.ID
 		}
 
-		if err = database.UpdateIssueUserByAssignee(issue); err != nil {
-			c.Error(err, "update issue user by assignee")
+		if issue.IsPull && form.RequestedReviewers != nil {
+			reviewerIDs, ok := getAssignableUserIDsByNames(c, *form.RequestedReviewers)
+			if !ok {
+				return
+			}
+			if err = issue.ChangeRequestedReviewers(c.User, reviewerIDs); err != nil {
+				c.Error(err, "change requested reviewers")
+				return
+			}
+		}
+	}
+	if c.Repo.IsWriter() && (form.DueDate != nil || form.UnsetDueDate) {
+		var deadline time.Time
+		if !form.UnsetDueDate {
+			deadline = *form.DueDate
+		}
+		if err = issue.ChangeDeadline(c.User, deadline); err != nil {
+			c.Error(err, "change deadline")
 			return
 		}
 	}
@@ -178,7 +346,11 @@ func EditIssue(c *context.APIContext, form api.EditIssueOption) {
 	}
 	if form.State != nil {
 		if err = issue.ChangeStatus(c.User, c.Repo.Repository, api.STATE_CLOSED == api.StateType(*form.State)); err != nil {
-			c.Error(err, "change status")
+			if database.IsErrIssueBlocked(err) {
+				c.ErrorStatus(http.StatusUnprocessableEntity, err)
+			} else {
+				c.Error(err, "change status")
+			}
 			return
 		}
 	}
@@ -189,5 +361,34 @@ func EditIssue(c *context.APIContext, form api.EditIssueOption) {
 		c.Error(err, "get issue by ID")
 		return
 	}
-	c.JSON(http.StatusCreated, issue.APIFormat())
+	c.JSON(http.StatusCreated, toAPIIssue(issue))
+}
+
+// LockIssue locks the conversation of the issue, only writers of the
+// repository are allowed to comment on a locked issue.
+func LockIssue(c *context.APIContext, form LockIssueOption) {
+	changeIssueLock(c, true, form.Reason)
+}
+
+// UnlockIssue unlocks the conversation of the issue.
+func UnlockIssue(c *context.APIContext) {
+	changeIssueLock(c, false, "")
+}
+
+func changeIssueLock(c *context.APIContext, locked bool, reason string) {
+	issue, err := database.GetIssueByIndex(c.Repo.Repository.ID, c.ParamsInt64(":index"))
+	if err != nil {
+		c.NotFoundOrError(err, "get issue by index")
+		return
+	}
+
+	if err = issue.ChangeLock(c.User, locked, reason); err != nil {
+		if database.IsErrInvalidLockReason(err) {
+			c.ErrorStatus(http.StatusUnprocessableEntity, err)
+		} else {
+			c.Error(err, "change lock")
+		}
+		return
+	}
+	c.NoContent()
 }
@@ -4,6 +4,7 @@
 package repo
 
 import (
+	"errors"
 	"net/http"
 	"time"
 
@@ -13,6 +14,41 @@ import (
 	"gogs.io/gogs/internal/database"
 )
 
+// Comment is the API representation of an issue comment or event, the old and
+// new values are set for events that change the issue.
+type Comment struct {
+	*api.Comment
+	Type      string         `json:"type"`
+	Label     *api.Label     `json:"label,omitempty"`
+	OldValue  string         `json:"old_value,omitempty"`
+	NewValue  string         `json:"new_value,omitempty"`
+	Reactions map[string]int `json:"reactions"`
+}
+
+// toAPIComment converts the comment to its API format. This function assumes
+// reactions of the comment have been loaded.
+func toAPIComment(comment *database.Comment) *Comment {
+	apiComment := &Comment{
+		Comment:   comment.APIFormat(),
+		Type:      comment.Type.String(),
+		OldValue:  comment.OldValue,
+		NewValue:  comment.NewValue,
+		Reactions: comment.Reactions.Counts(),
+	}
+	if comment.Label != nil {
+		apiComment.Label = comment.Label.APIFormat()
+	}
+	return apiComment
+}
+
+func toAPIComments(comments []*database.Comment) []*Comment {
+	apiComments := make([]*Comment, len(comments))
+	for i := range comments {
+		apiComments[i] = toAPIComment(comments[i])
+	}
+	return apiComments
+}
+
 func ListIssueComments(c *context.APIContext) {
 	var since time.Time
 	if len(c.Query("since")) > 0 {
@@ -24,10 +60,8 @@ func ListIssueComments(c *context.APIContext) {
 		}
 	}
 
-	// comments,err:=db.GetCommentsByIssueIDSince(, since)
-	issue, err := database.GetRawIssueByIndex(c.Repo.Repository.ID, c.ParamsInt64(":index"))
-	if err != nil {
-		c.Error(err, "get raw issue by index")
+	issue := getIssueByIndex(c, "/comments")
+	if c.Written() {
 		return
 	}
 
@@ -35,13 +69,12 @@ func ListIssueComments(c *context.APIContext) {
 	if err != nil {
 		c.Error(err, "get comments by issue ID")
 		return
+	} else if err = database.LoadCommentsReactions(comments); err != nil {
+		c.Error(err, "load comment reactions")
+		return
 	}
 
-	apiComments := make([]*api.Comment, len(comments))
-	for i := range comments {
-		apiComments[i] = comments[i].APIFormat()
-	}
-	c.JSONSuccess(&apiComments)
+	c.JSONSuccess(toAPIComments(comments))
 }
 
 func ListRepoIssueComments(c *context.APIContext) {
@@ -59,13 +92,12 @@ func ListRepoIssueComments(c *context.APIContext) {
 	if err != nil {
 		c.Error(err, "get comments by repository ID")
 		return
+	} else if err = database.LoadCommentsReactions(comments); err != nil {
+		c.Error(err, "load comment reactions")
+		return
 	}
 
-	apiComments := make([]*api.Comment, len(comments))
-	for i := range comments {
-		apiComments[i] = comments[i].APIFormat()
-	}
-	c.JSONSuccess(&apiComments)
+	c.JSONSuccess(toAPIComments(comments))
 }
 
 func CreateIssueComment(c *context.APIContext, form api.CreateIssueCommentOption) {
@@ -73,6 +105,9 @@ func CreateIssueComment(c *context.APIContext, form api.CreateIssueCommentOption
 	if err != nil {
 		c.Error(err, "get issue by index")
 		return
+	} else if !c.Repo.CanComment(issue) {
+		c.ErrorStatus(http.StatusForbidden, errors.New("the conversation is locked"))
+		return
 	}
 
 	comment, err := database.CreateIssueComment(c.User, c.Repo.Repository, issue, form.Body, nil)
@@ -81,7 +116,7 @@ func CreateIssueComment(c *context.APIContext, form api.CreateIssueCommentOption
 		return
 	}
 
-	c.JSON(http.StatusCreated, comment.APIFormat())
+	c.JSON(http.StatusCreated, toAPIComment(comment))
 }
 
 func EditIssueComment(c *context.APIContext, form api.EditIssueCommentOption) {
@@ -90,6 +125,14 @@ func EditIssueComment(c *context.APIContext, form api.EditIssueCommentOption) {
 		c.NotFoundOrError(err, "get comment by ID")
 		return
 	}
+	issue, err := database.GetIssueByID(comment.IssueID)
+	if err != nil {
+		c.NotFoundOrError(err, "get issue by ID")
+		return
+	} else if issue.RepoID != c.Repo.Repository.ID {
+		c.NotFound()
+		return
+	}
 
 	if c.User.ID != comment.PosterID && !c.Repo.IsAdmin() {
 		c.Status(http.StatusForbidden)
@@ -97,6 +140,9 @@ func EditIssueComment(c *context.APIContext, form api.EditIssueCommentOption) {
 	} else if comment.Type != database.CommentTypeComment {
 		c.NoContent()
 		return
+	} else if !c.Repo.CanComment(issue) {
+		c.ErrorStatus(http.StatusForbidden, errors.New("the conversation is locked"))
+		return
 	}
 
 	oldContent := comment.Content
@@ -104,8 +150,11 @@ func EditIssueComment(c *context.APIContext, form api.EditIssueCommentOption) {
 	if err := database.UpdateComment(c.User, comment, oldContent); err != nil {
 		c.Error(err, "update comment")
 		return
+	} else if err = database.LoadCommentsReactions([]*database.Comment{comment}); err != nil {
+		c.Error(err, "load comment reactions")
+		return
 	}
-	c.JSONSuccess(comment.APIFormat())
+	c.JSONSuccess(toAPIComment(comment))
 }
 
 func DeleteIssueComment(c *context.APIContext) {
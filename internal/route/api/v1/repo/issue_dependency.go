@@ -0,0 +1,144 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"fmt"
+	"net/http"
+
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/database"
+)
+
+// IssueDependency is the API representation of an issue on either side of a
+// dependency, which may belong to a different repository.
+type IssueDependency struct {
+	*Issue
+	Repository string `json:"repository"`
+}
+
+// IssueDependencyOption is the API message for adding or removing a blocker
+// of an issue, Owner and Repo default to the current repository.
+type IssueDependencyOption struct {
+	Owner string `json:"owner"`
+	Repo  string `json:"repo"`
+	Index int64  `json:"index" binding:"Required"`
+}
+
+func toAPIIssueDependencies(c *context.APIContext, issues []*database.Issue) []*IssueDependency {
+	apiIssues := make([]*IssueDependency, 0, len(issues))
+	for _, issue := range issues {
+		if !c.CanReadIssue(issue) {
+			continue
+		}
+		apiIssues = append(apiIssues, &IssueDependency{
+			Issue:      toAPIIssue(issue),
+			Repository: issue.Repo.FullName(),
+		})
+	}
+	return apiIssues
+}
+
+// ListIssueDependencies returns issues that block the issue.
+func ListIssueDependencies(c *context.APIContext) {
+	issue, err := database.GetIssueByIndex(c.Repo.Repository.ID, c.ParamsInt64(":index"))
+	if err != nil {
+		c.NotFoundOrError(err, "get issue by index")
+		return
+	}
+
+	blockedBy, err := issue.BlockedBy()
+	if err != nil {
+		c.Error(err, "get blockers")
+		return
+	}
+	c.JSONSuccess(toAPIIssueDependencies(c, blockedBy))
+}
+
+// ListIssueBlocks returns issues that are blocked by the issue.
+func ListIssueBlocks(c *context.APIContext) {
+	issue, err := database.GetIssueByIndex(c.Repo.Repository.ID, c.ParamsInt64(":index"))
+	if err != nil {
+		c.NotFoundOrError(err, "get issue by index")
+		return
+	}
+
+	blocks, err := issue.Blocks()
+	if err != nil {
+		c.Error(err, "get blocked issues")
+		return
+	}
+	c.JSONSuccess(toAPIIssueDependencies(c, blocks))
+}
+
+// getIssueAndDependency returns the issue of the request and the dependency
+// referenced by the option, it responds with 404 when any of them does not
+// exist or is not visible to the current user.
+func getIssueAndDependency(c *context.APIContext, form IssueDependencyOption) (issue, dependency *database.Issue) {
+	issue, err := database.GetIssueByIndex(c.Repo.Repository.ID, c.ParamsInt64(":index"))
+	if err != nil {
+		c.NotFoundOrError(err, "get issue by index")
+		return nil, nil
+	}
+
+	ref := fmt.Sprintf("#%d", form.Index)
+	if form.Owner != "" || form.Repo != "" {
+		owner, repo := form.Owner, form.Repo
+		if owner == "" {
+			owner = c.Repo.Owner.Name
+		}
+		if repo == "" {
+			repo = c.Repo.Repository.Name
+		}
+		ref = fmt.Sprintf("%s/%s%s", owner, repo, ref)
+	}
+
+	dependency, err = database.GetIssueByRelativeRef(c.Repo.Repository, ref)
+	if err != nil {
+		c.NotFoundOrError(err, "get issue by reference")
+		return nil, nil
+	} else if !c.CanReadIssue(dependency) {
+		c.NotFound()
+		return nil, nil
+	}
+	return issue, dependency
+}
+
+// AddIssueDependency makes the issue blocked by the issue referenced by the
+// option.
+func AddIssueDependency(c *context.APIContext, form IssueDependencyOption) {
+	issue, dependency := getIssueAndDependency(c, form)
+	if c.Written() {
+		return
+	}
+
+	if err := database.AddIssueDependency(c.User, issue, dependency); err != nil {
+		if database.IsErrIssueDependencyExist(err) || database.IsErrIssueDependencyCircular(err) {
+			c.ErrorStatus(http.StatusUnprocessableEntity, err)
+		} else {
+			c.Error(err, "add issue dependency")
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, &IssueDependency{
+		Issue:      toAPIIssue(dependency),
+		Repository: dependency.Repo.FullName(),
+	})
+}
+
+// RemoveIssueDependency removes the issue referenced by the option from
+// blockers of the issue.
+func RemoveIssueDependency(c *context.APIContext, form IssueDependencyOption) {
+	issue, dependency := getIssueAndDependency(c, form)
+	if c.Written() {
+		return
+	}
+
+	if err := database.RemoveIssueDependency(issue, dependency); err != nil {
+		c.NotFoundOrError(err, "remove issue dependency")
+		return
+	}
+	c.NoContent()
+}
@@ -14,9 +14,8 @@ import (
 )
 
 func ListIssueLabels(c *context.APIContext) {
-	issue, err := database.GetIssueByIndex(c.Repo.Repository.ID, c.ParamsInt64(":index"))
-	if err != nil {
-		c.NotFoundOrError(err, "get issue by index")
+	issue := getIssueByIndex(c, "/labels")
+	if c.Written() {
 		return
 	}
 
@@ -34,7 +33,7 @@ func AddIssueLabels(c *context.APIContext, form api.IssueLabelsOption) {
 		return
 	}
 
-	labels, err := database.GetLabelsInRepoByIDs(c.Repo.Repository.ID, form.Labels)
+	labels, err := database.GetLabelsAvailableToRepoByIDs(c.Repo.Repository, form.Labels)
 	if err != nil {
 		c.Error(err, "get labels in repository by IDs")
 		return
@@ -65,7 +64,7 @@ func DeleteIssueLabel(c *context.APIContext) {
 		return
 	}
 
-	label, err := database.GetLabelOfRepoByID(c.Repo.Repository.ID, c.ParamsInt64(":id"))
+	label, err := database.GetLabelAvailableToRepoByID(c.Repo.Repository, c.ParamsInt64(":id"))
 	if err != nil {
 		if database.IsErrLabelNotExist(err) {
 			c.ErrorStatus(http.StatusUnprocessableEntity, err)
@@ -75,7 +74,7 @@ func DeleteIssueLabel(c *context.APIContext) {
 		return
 	}
 
-	if err := database.DeleteIssueLabel(issue, label); err != nil {
+	if err := issue.RemoveLabel(c.User, label); err != nil {
 		c.Error(err, "delete issue label")
 		return
 	}
@@ -90,13 +89,13 @@ func ReplaceIssueLabels(c *context.APIContext, form api.IssueLabelsOption) {
 		return
 	}
 
-	labels, err := database.GetLabelsInRepoByIDs(c.Repo.Repository.ID, form.Labels)
+	labels, err := database.GetLabelsAvailableToRepoByIDs(c.Repo.Repository, form.Labels)
 	if err != nil {
 		c.Error(err, "get labels in repository by IDs")
 		return
 	}
 
-	if err := issue.ReplaceLabels(labels); err != nil {
+	if err := issue.ReplaceLabels(c.User, labels); err != nil {
 		c.Error(err, "replace labels")
 		return
 	}
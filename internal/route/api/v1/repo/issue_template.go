@@ -0,0 +1,74 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"gogs.io/gogs/internal/context"
+)
+
+// IssueTemplate is the API representation of an issue template.
+type IssueTemplate struct {
+	FileName string   `json:"file_name"`
+	Name     string   `json:"name"`
+	About    string   `json:"about"`
+	Title    string   `json:"title"`
+	Labels   []string `json:"labels"`
+	Content  string   `json:"content"`
+}
+
+// PullRequestTemplate is the API representation of the pull request template.
+type PullRequestTemplate struct {
+	Title   string `json:"title"`
+	Content string `json:"content"`
+}
+
+// GET /repos/:username/:reponame/issue_templates
+func ListIssueTemplates(c *context.APIContext) {
+	tmpls, err := c.Repo.Repository.GetIssueTemplates()
+	if err != nil {
+		c.Error(err, "get issue templates")
+		return
+	}
+
+	apiTmpls := make([]*IssueTemplate, len(tmpls))
+	for i := range tmpls {
+		labels := tmpls[i].Labels
+		if labels == nil {
+			labels = []string{}
+		}
+		apiTmpls[i] = &IssueTemplate{
+			FileName: tmpls[i].FileName,
+			Name:     tmpls[i].Name,
+			About:    tmpls[i].About,
+			Title:    tmpls[i].Title,
+			Labels:   labels,
+			Content:  tmpls[i].Content,
+		}
+	}
+	c.JSONSuccess(&apiTmpls)
+}
+
+// GET /repos/:username/:reponame/pull_request_template
+func GetPullRequestTemplate(c *context.APIContext) {
+	content, found, err := c.Repo.Repository.GetPullRequestTemplate()
+	if err != nil {
+		c.Error(err, "get pull request template")
+		return
+	}
+	title, titleFound, err := c.Repo.Repository.GetPullRequestTitleTemplate()
+	if err != nil {
+		c.Error(err, "get pull request title template")
+		return
+	}
+	if !found && !titleFound {
+		c.NotFound()
+		return
+	}
+
+	c.JSONSuccess(&PullRequestTemplate{
+		Title:   title,
+		Content: content,
+	})
+}
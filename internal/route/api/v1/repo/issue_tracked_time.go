@@ -0,0 +1,256 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+	"time"
+
+	api "github.com/gogs/go-gogs-client"
+
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/database"
+)
+
+// TrackedTime is the API representation of time spent by a user on an issue.
+type TrackedTime struct {
+	ID         int64     `json:"id"`
+	User       *api.User `json:"user"`
+	Repository string    `json:"repository"`
+	IssueIndex int64     `json:"issue_index"`
+	Time       int64     `json:"time"`
+	Created    time.Time `json:"created"`
+}
+
+// Stopwatch is the API representation of a running timer on an issue.
+type Stopwatch struct {
+	Repository string    `json:"repository"`
+	IssueIndex int64     `json:"issue_index"`
+	Seconds    int64     `json:"seconds"`
+	Created    time.Time `json:"created"`
+}
+
+// AddTrackedTimeOption is the API message for recording time spent on an
+// issue, the time is in seconds.
+type AddTrackedTimeOption struct {
+	Time    int64     `json:"time" binding:"Required"`
+	Created time.Time `json:"created"`
+}
+
+func toAPITrackedTimes(c *context.APIContext, times []*database.TrackedTime) ([]*TrackedTime, error) {
+	users := make(map[int64]*api.User)
+	apiTimes := make([]*TrackedTime, 0, len(times))
+	for _, t := range times {
+		u, ok := users[t.UserID]
+		if !ok {
+			user, err := database.Handle.Users().GetByID(c.Req.Context(), t.UserID)
+			if err != nil {
+				if database.IsErrUserNotExist(err) {
+					continue
+				}
+				return nil, err
+			}
+			u = user.APIFormat()
+			users[t.UserID] = u
+		}
+		apiTimes = append(apiTimes, &TrackedTime{
+			ID:         t.ID,
+			User:       u,
+			Repository: t.Issue.Repo.FullName(),
+			IssueIndex: t.Issue.Index,
+			Time:       t.Time,
+			Created:    t.Created,
+		})
+	}
+	return apiTimes, nil
+}
+
+func listTrackedTimes(c *context.APIContext, opts database.TrackedTimeOptions) {
+	if t, err := time.ParseInLocation("2006-01-02", c.Query("from"), time.Local); err == nil {
+		opts.Since = t
+	}
+	if t, err := time.ParseInLocation("2006-01-02", c.Query("to"), time.Local); err == nil {
+		opts.Before = t.AddDate(0, 0, 1)
+	}
+
+	times, err := database.GetTrackedTimes(opts)
+	if err != nil {
+		c.Error(err, "get tracked times")
+		return
+	}
+	apiTimes, err := toAPITrackedTimes(c, times)
+	if err != nil {
+		c.Error(err, "convert tracked times")
+		return
+	}
+	c.JSONSuccess(apiTimes)
+}
+
+// ListRepoTrackedTimes returns time tracked on issues of the repository,
+// optionally filtered by "milestone", "user", "from" and "to".
+func ListRepoTrackedTimes(c *context.APIContext) {
+	listTrackedTimes(c, database.TrackedTimeOptions{
+		RepoID:      c.Repo.Repository.ID,
+		MilestoneID: c.QueryInt64("milestone"),
+		UserID:      c.QueryInt64("user"),
+	})
+}
+
+// ListMyTrackedTimes returns time tracked by the authenticated user,
+// optionally filtered by "from" and "to".
+func ListMyTrackedTimes(c *context.APIContext) {
+	listTrackedTimes(c, database.TrackedTimeOptions{
+		UserID: c.User.ID,
+	})
+}
+
+// ListMyStopwatches returns running timers of the authenticated user.
+func ListMyStopwatches(c *context.APIContext) {
+	stopwatches, err := database.GetUserStopwatches(c.User.ID)
+	if err != nil {
+		c.Error(err, "get user stopwatches")
+		return
+	}
+
+	apiStopwatches := make([]*Stopwatch, len(stopwatches))
+	for i, s := range stopwatches {
+		apiStopwatches[i] = &Stopwatch{
+			Repository: s.Issue.Repo.FullName(),
+			IssueIndex: s.Issue.Index,
+			Seconds:    s.Seconds(),
+			Created:    s.Created,
+		}
+	}
+	c.JSONSuccess(apiStopwatches)
+}
+
+func getTrackedTimeIssue(c *context.APIContext) *database.Issue {
+	issue, err := database.GetIssueByIndex(c.Repo.Repository.ID, c.ParamsInt64(":index"))
+	if err != nil {
+		c.NotFoundOrError(err, "get issue by index")
+		return nil
+	}
+	return issue
+}
+
+// ListIssueTrackedTimes returns time tracked on the issue.
+func ListIssueTrackedTimes(c *context.APIContext) {
+	issue := getTrackedTimeIssue(c)
+	if c.Written() {
+		return
+	}
+
+	listTrackedTimes(c, database.TrackedTimeOptions{
+		IssueID: issue.ID,
+		UserID:  c.QueryInt64("user"),
+	})
+}
+
+// AddIssueTrackedTime records time spent by the authenticated user on the
+// issue.
+func AddIssueTrackedTime(c *context.APIContext, form AddTrackedTimeOption) {
+	issue := getTrackedTimeIssue(c)
+	if c.Written() {
+		return
+	}
+
+	t, err := database.AddTrackedTime(c.User, issue, time.Duration(form.Time)*time.Second, form.Created)
+	if err != nil {
+		if database.IsErrInvalidTrackedTime(err) {
+			c.ErrorStatus(http.StatusUnprocessableEntity, err)
+		} else {
+			c.Error(err, "add tracked time")
+		}
+		return
+	}
+
+	apiTimes, err := toAPITrackedTimes(c, []*database.TrackedTime{t})
+	if err != nil {
+		c.Error(err, "convert tracked times")
+		return
+	}
+	c.JSON(http.StatusCreated, apiTimes[0])
+}
+
+// DeleteIssueTrackedTime deletes a tracked time entry of the issue, only the
+// user who tracked the time and repository admins are allowed.
+func DeleteIssueTrackedTime(c *context.APIContext) {
+	issue := getTrackedTimeIssue(c)
+	if c.Written() {
+		return
+	}
+
+	t, err := database.GetTrackedTimeByID(c.ParamsInt64(":id"))
+	if err != nil {
+		c.NotFoundOrError(err, "get tracked time by ID")
+		return
+	} else if t.IssueID != issue.ID {
+		c.NotFound()
+		return
+	} else if t.UserID != c.User.ID && !c.Repo.IsAdmin() {
+		c.Status(http.StatusForbidden)
+		return
+	}
+
+	if err = database.DeleteTrackedTime(t.ID); err != nil {
+		c.Error(err, "delete tracked time")
+		return
+	}
+	c.NoContent()
+}
+
+// StartIssueStopwatch starts a timer of the authenticated user on the issue.
+func StartIssueStopwatch(c *context.APIContext) {
+	issue := getTrackedTimeIssue(c)
+	if c.Written() {
+		return
+	}
+
+	if err := database.StartStopwatch(c.User, issue); err != nil {
+		c.Error(err, "start stopwatch")
+		return
+	}
+	c.Status(http.StatusCreated)
+}
+
+// StopIssueStopwatch stops the running timer of the authenticated user on
+// the issue and records the elapsed time.
+func StopIssueStopwatch(c *context.APIContext) {
+	issue := getTrackedTimeIssue(c)
+	if c.Written() {
+		return
+	}
+
+	t, err := database.StopStopwatch(c.User, issue)
+	if err != nil {
+		c.NotFoundOrError(err, "stop stopwatch")
+		return
+	} else if t == nil {
+		c.NoContent()
+		return
+	}
+
+	apiTimes, err := toAPITrackedTimes(c, []*database.TrackedTime{t})
+	if err != nil {
+		c.Error(err, "convert tracked times")
+		return
+	}
+	c.JSON(http.StatusCreated, apiTimes[0])
+}
+
+// CancelIssueStopwatch discards the running timer of the authenticated user
+// on the issue.
+func CancelIssueStopwatch(c *context.APIContext) {
+	issue := getTrackedTimeIssue(c)
+	if c.Written() {
+		return
+	}
+
+	if err := database.CancelStopwatch(c.User, issue); err != nil {
+		c.Error(err, "cancel stopwatch")
+		return
+	}
+	c.NoContent()
+}
@@ -0,0 +1,96 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"fmt"
+	"net/http"
+
+	"gogs.io/gogs/internal/conf"
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/database"
+)
+
+// TransferIssueOption is the API message for transferring an issue to another
+// repository.
+type TransferIssueOption struct {
+	Owner string `json:"owner" binding:"Required"`
+	Repo  string `json:"repo" binding:"Required"`
+}
+
+// getIssueByIndex returns the issue with the index of the current repository.
+// If the issue was transferred to another repository, it responds with a
+// permanent redirect to the same subpath of the new location.
+func getIssueByIndex(c *context.APIContext, subpath string) *database.Issue {
+	index := c.ParamsInt64(":index")
+	issue, err := database.GetIssueByIndex(c.Repo.Repository.ID, index)
+	if err == nil {
+		return issue
+	} else if !database.IsErrIssueNotExist(err) {
+		c.Error(err, "get issue by index")
+		return nil
+	}
+
+	issue, err = c.GetTransferredIssue(index)
+	if err != nil {
+		c.NotFoundOrError(err, "get transferred issue")
+		return nil
+	}
+
+	link := fmt.Sprintf("%s/api/v1/repos/%s/issues/%d%s", conf.Server.Subpath, issue.Repo.FullName(), issue.Index, subpath)
+	if c.Req.URL.RawQuery != "" {
+		link += "?" + c.Req.URL.RawQuery
+	}
+	c.Redirect(link, http.StatusMovedPermanently)
+	return nil
+}
+
+// TransferIssue moves the issue to the repository of the option, the
+// authenticated user must be a writer of both repositories.
+func TransferIssue(c *context.APIContext, form TransferIssueOption) {
+	issue, err := database.GetIssueByIndex(c.Repo.Repository.ID, c.ParamsInt64(":index"))
+	if err != nil {
+		c.NotFoundOrError(err, "get issue by index")
+		return
+	}
+
+	owner, err := database.Handle.Users().GetByUsername(c.Req.Context(), form.Owner)
+	if err != nil {
+		c.NotFoundOrError(err, "get user by name")
+		return
+	}
+	target, err := database.Handle.Repositories().GetByName(c.Req.Context(), owner.ID, form.Repo)
+	if err != nil {
+		c.NotFoundOrError(err, "get repository by name")
+		return
+	} else if !database.Handle.Permissions().Authorize(c.Req.Context(), c.User.ID, target.ID, database.AccessModeWrite,
+		database.AccessModeOptions{
+			OwnerID: target.OwnerID,
+			Private: target.IsPrivate,
+		},
+	) {
+		c.NotFound()
+		return
+	} else if target.ID == issue.RepoID || !target.EnableIssues || target.EnableExternalTracker {
+		c.ErrorStatus(http.StatusUnprocessableEntity, fmt.Errorf("issues cannot be transferred to %q", target.FullName()))
+		return
+	}
+
+	if err = database.TransferIssue(c.User, issue, target); err != nil {
+		if database.IsErrInvalidIssueTransfer(err) {
+			c.ErrorStatus(http.StatusUnprocessableEntity, err)
+		} else {
+			c.Error(err, "transfer issue")
+		}
+		return
+	}
+
+	issue, err = database.GetIssueByID(issue.ID)
+	if err != nil {
+		c.Error(err, "get issue by ID")
+		return
+	}
+	c.JSONSuccess(toAPIIssue(issue))
+}
@@ -14,10 +14,11 @@ import (
 	"gogs.io/gogs/internal/database"
 )
 
+// ListLabels returns labels of the repository and of its organization.
 func ListLabels(c *context.APIContext) {
-	labels, err := database.GetLabelsByRepoID(c.Repo.Repository.ID)
+	labels, err := database.GetLabelsAvailableToRepo(c.Repo.Repository)
 	if err != nil {
-		c.Error(err, "get labels by repository ID")
+		c.Error(err, "get labels available to repository")
 		return
 	}
 
@@ -33,9 +34,9 @@ func GetLabel(c *context.APIContext) {
 	var err error
 	idStr := c.Params(":id")
 	if id := com.StrTo(idStr).MustInt64(); id > 0 {
-		label, err = database.GetLabelOfRepoByID(c.Repo.Repository.ID, id)
+		label, err = database.GetLabelAvailableToRepoByID(c.Repo.Repository, id)
 	} else {
-		label, err = database.GetLabelOfRepoByName(c.Repo.Repository.ID, idStr)
+		label, err = database.GetLabelAvailableToRepoByName(c.Repo.Repository, idStr)
 	}
 	if err != nil {
 		c.NotFoundOrError(err, "get label")
@@ -0,0 +1,26 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/database"
+)
+
+// ListLanguages returns the number of bytes of each language in the default
+// branch of the repository.
+func ListLanguages(c *context.APIContext) {
+	stats, err := database.GetLanguageStats(c.Repo.Repository.ID)
+	if err != nil {
+		c.Error(err, "get language stats")
+		return
+	}
+
+	languages := make(map[string]int64, len(stats))
+	for _, s := range stats {
+		languages[s.Language] = s.Size
+	}
+	c.JSONSuccess(languages)
+}
@@ -0,0 +1,259 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+	"time"
+
+	api "github.com/gogs/go-gogs-client"
+
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/database"
+)
+
+// Project endpoints are shared by repositories and organizations, the current
+// repository decides which one the request is about.
+
+// Project is the API representation of a kanban board.
+type Project struct {
+	ID          int64            `json:"id"`
+	Title       string           `json:"title"`
+	Description string           `json:"description"`
+	State       api.StateType    `json:"state"`
+	Columns     []*ProjectColumn `json:"columns,omitempty"`
+	Created     time.Time        `json:"created_at"`
+	Updated     time.Time        `json:"updated_at"`
+}
+
+// ProjectColumn is the API representation of a column of a project.
+type ProjectColumn struct {
+	ID    int64          `json:"id"`
+	Title string         `json:"title"`
+	Cards []*ProjectCard `json:"cards"`
+}
+
+// ProjectCard is the API representation of an issue placed in a project.
+type ProjectCard struct {
+	ID         int64         `json:"id"`
+	ColumnID   int64         `json:"column_id"`
+	IssueID    int64         `json:"issue_id"`
+	Repository string        `json:"repository"`
+	IssueIndex int64         `json:"issue_index"`
+	Title      string        `json:"title"`
+	State      api.StateType `json:"state"`
+	IsPull     bool          `json:"is_pull"`
+}
+
+// AddProjectCardOption is the API message for adding an issue to a column.
+type AddProjectCardOption struct {
+	ColumnID int64 `json:"column_id" binding:"Required"`
+	IssueID  int64 `json:"issue_id" binding:"Required"`
+}
+
+// MoveProjectCardOption is the API message for moving a card to the
+// zero-based position of a column.
+type MoveProjectCardOption struct {
+	ColumnID int64 `json:"column_id" binding:"Required"`
+	Position int   `json:"position"`
+}
+
+func toAPIProject(p *database.Project) *Project {
+	apiProject := &Project{
+		ID:          p.ID,
+		Title:       p.Title,
+		Description: p.Description,
+		State:       api.STATE_OPEN,
+		Created:     p.Created,
+		Updated:     p.Updated,
+	}
+	if p.IsClosed {
+		apiProject.State = api.STATE_CLOSED
+	}
+	return apiProject
+}
+
+func toAPIProjectCard(card *database.ProjectCard) *ProjectCard {
+	return &ProjectCard{
+		ID:         card.ID,
+		ColumnID:   card.ColumnID,
+		IssueID:    card.IssueID,
+		Repository: card.Issue.Repo.FullName(),
+		IssueIndex: card.Issue.Index,
+		Title:      card.Issue.Title,
+		State:      card.Issue.State(),
+		IsPull:     card.Issue.IsPull,
+	}
+}
+
+// getProjectScope returns the owner and the repository of projects that the
+// request is about.
+func getProjectScope(c *context.APIContext) (ownerID, repoID int64) {
+	if c.Repo.Repository != nil {
+		return 0, c.Repo.Repository.ID
+	}
+	return c.Org.Organization.ID, 0
+}
+
+func getAPIProject(c *context.APIContext) *database.Project {
+	ownerID, repoID := getProjectScope(c)
+	p, err := database.GetProjectByID(c.ParamsInt64(":id"))
+	if err != nil {
+		c.NotFoundOrError(err, "get project by ID")
+		return nil
+	} else if p.OwnerID != ownerID || p.RepoID != repoID {
+		c.NotFound()
+		return nil
+	}
+	return p
+}
+
+// ListProjects returns open projects, or closed projects if "state" is
+// "closed".
+func ListProjects(c *context.APIContext) {
+	ownerID, repoID := getProjectScope(c)
+	projects, err := database.GetProjects(ownerID, repoID, api.StateType(c.Query("state")) == api.STATE_CLOSED)
+	if err != nil {
+		c.Error(err, "get projects")
+		return
+	}
+
+	apiProjects := make([]*Project, len(projects))
+	for i := range projects {
+		apiProjects[i] = toAPIProject(projects[i])
+	}
+	c.JSONSuccess(apiProjects)
+}
+
+// GetProject returns the project with its columns and cards.
+func GetProject(c *context.APIContext) {
+	p := getAPIProject(c)
+	if c.Written() {
+		return
+	}
+
+	if err := p.LoadColumns(); err != nil {
+		c.Error(err, "load project columns")
+		return
+	}
+
+	apiProject := toAPIProject(p)
+	apiProject.Columns = make([]*ProjectColumn, len(p.Columns))
+	for i, col := range p.Columns {
+		apiColumn := &ProjectColumn{
+			ID:    col.ID,
+			Title: col.Title,
+			Cards: make([]*ProjectCard, 0, len(col.Cards)),
+		}
+		for _, card := range col.Cards {
+			if c.CanReadIssue(card.Issue) {
+				apiColumn.Cards = append(apiColumn.Cards, toAPIProjectCard(card))
+			}
+		}
+		apiProject.Columns[i] = apiColumn
+	}
+	c.JSONSuccess(apiProject)
+}
+
+// AddProjectCard adds the issue to the end of the column. The issue must
+// belong to the repository of the project, or to a repository of the
+// organization of the project.
+func AddProjectCard(c *context.APIContext, form AddProjectCardOption) {
+	p := getAPIProject(c)
+	if c.Written() {
+		return
+	}
+
+	col, err := database.GetProjectColumn(p.ID, form.ColumnID)
+	if err != nil {
+		if database.IsErrProjectColumnNotExist(err) {
+			c.ErrorStatus(http.StatusUnprocessableEntity, err)
+		} else {
+			c.Error(err, "get project column")
+		}
+		return
+	}
+
+	issue, err := database.GetIssueByID(form.IssueID)
+	if err != nil {
+		if database.IsErrIssueNotExist(err) {
+			c.ErrorStatus(http.StatusUnprocessableEntity, err)
+		} else {
+			c.Error(err, "get issue by ID")
+		}
+		return
+	} else if (p.RepoID > 0 && issue.RepoID != p.RepoID) ||
+		(p.OwnerID > 0 && issue.Repo.OwnerID != p.OwnerID) ||
+		!c.CanReadIssue(issue) {
+		c.ErrorStatus(http.StatusUnprocessableEntity, database.ErrIssueNotExist{})
+		return
+	}
+
+	card, err := database.AddProjectCard(c.User, col, issue)
+	if err != nil {
+		if database.IsErrProjectCardExist(err) {
+			c.ErrorStatus(http.StatusUnprocessableEntity, err)
+		} else {
+			c.Error(err, "add project card")
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, toAPIProjectCard(card))
+}
+
+// MoveProjectCard moves the card to the zero-based position of the column.
+func MoveProjectCard(c *context.APIContext, form MoveProjectCardOption) {
+	p := getAPIProject(c)
+	if c.Written() {
+		return
+	}
+
+	card, err := database.GetProjectCard(p.ID, c.ParamsInt64(":card"))
+	if err != nil {
+		c.NotFoundOrError(err, "get project card")
+		return
+	}
+	col, err := database.GetProjectColumn(p.ID, form.ColumnID)
+	if err != nil {
+		if database.IsErrProjectColumnNotExist(err) {
+			c.ErrorStatus(http.StatusUnprocessableEntity, err)
+		} else {
+			c.Error(err, "get project column")
+		}
+		return
+	}
+
+	if err = database.MoveProjectCard(card, col, form.Position); err != nil {
+		c.Error(err, "move project card")
+		return
+	}
+
+	card.Issue, err = database.GetIssueByID(card.IssueID)
+	if err != nil {
+		c.Error(err, "get issue by ID")
+		return
+	}
+	c.JSONSuccess(toAPIProjectCard(card))
+}
+
+// DeleteProjectCard removes the card from the project.
+func DeleteProjectCard(c *context.APIContext) {
+	p := getAPIProject(c)
+	if c.Written() {
+		return
+	}
+
+	card, err := database.GetProjectCard(p.ID, c.ParamsInt64(":card"))
+	if err != nil {
+		c.NotFoundOrError(err, "get project card")
+		return
+	}
+
+	if err = database.DeleteProjectCard(card); err != nil {
+		c.Error(err, "delete project card")
+		return
+	}
+	c.NoContent()
+}
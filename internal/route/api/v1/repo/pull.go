@@ -0,0 +1,133 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"os"
+
+	"github.com/gogs/git-module"
+
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/database"
+	"gogs.io/gogs/internal/gitutil"
+	"gogs.io/gogs/internal/route/repo"
+)
+
+// PullRequestFile is the API representation of a file changed by a pull
+// request.
+type PullRequestFile struct {
+	Filename         string `json:"filename"`
+	PreviousFilename string `json:"previous_filename,omitempty"`
+	Status           string `json:"status"`
+	Additions        int    `json:"additions"`
+	Deletions        int    `json:"deletions"`
+	Changes          int    `json:"changes"`
+	SHA              string `json:"sha"`
+	IsBinary         bool   `json:"is_binary"`
+	IsIncomplete     bool   `json:"is_incomplete"`
+}
+
+func getPullRequestByIndex(c *context.APIContext) *database.PullRequest {
+	issue, err := database.GetIssueByIndex(c.Repo.Repository.ID, c.ParamsInt64(":index"))
+	if err != nil {
+		c.NotFoundOrError(err, "get issue by index")
+		return nil
+	} else if !issue.IsPull || issue.PullRequest == nil {
+		c.NotFound()
+		return nil
+	}
+	return issue.PullRequest
+}
+
+// GetPullRequestDiff returns the stored patch of the pull request for the
+// "diff" extension, or its commits in the mailbox format for the "patch"
+// extension.
+func GetPullRequestDiff(c *context.APIContext) {
+	pull := getPullRequestByIndex(c)
+	if c.Written() {
+		return
+	}
+
+	diff, err := pull.RawDiff(git.RawDiffFormat(c.Params(":ext")))
+	if err != nil {
+		if os.IsNotExist(err) {
+			c.NotFound()
+		} else {
+			c.Error(err, "get raw diff")
+		}
+		return
+	}
+	repo.ServeRawDiff(c.Context, diff)
+}
+
+// ListPullRequestFiles returns files changed by the pull request.
+func ListPullRequestFiles(c *context.APIContext) {
+	pull := getPullRequestByIndex(c)
+	if c.Written() {
+		return
+	}
+
+	diff, err := pull.Diff()
+	if err != nil {
+		if os.IsNotExist(err) {
+			c.NotFound()
+		} else {
+			c.Error(err, "get diff")
+		}
+		return
+	}
+
+	c.JSONSuccess(toPullRequestFiles(diff))
+}
+
+// toPullRequestFiles converts files of the diff to their API representations.
+func toPullRequestFiles(diff *gitutil.Diff) []*PullRequestFile {
+	apiFiles := make([]*PullRequestFile, 0, len(diff.Files))
+	for _, file := range diff.Files {
+		apiFile := &PullRequestFile{
+			Filename:     file.Name,
+			Additions:    file.NumAdditions(),
+			Deletions:    file.NumDeletions(),
+			Changes:      file.NumAdditions() + file.NumDeletions(),
+			SHA:          file.Index,
+			IsBinary:     file.IsBinary(),
+			IsIncomplete: file.IsIncomplete(),
+		}
+		switch file.Type {
+		case git.DiffFileAdd:
+			apiFile.Status = "added"
+		case git.DiffFileDelete:
+			apiFile.Status = "removed"
+		case git.DiffFileRename:
+			apiFile.Status = "renamed"
+			apiFile.PreviousFilename = file.OldName()
+		default:
+			apiFile.Status = "modified"
+		}
+		apiFiles = append(apiFiles, apiFile)
+	}
+	return apiFiles
+}
+
+// ListPullRequestCommits returns commits of the pull request.
+func ListPullRequestCommits(c *context.APIContext) {
+	pull := getPullRequestByIndex(c)
+	if c.Written() {
+		return
+	}
+
+	commits, err := pull.Commits()
+	if err != nil {
+		c.Error(err, "get commits")
+		return
+	}
+
+	apiCommits, err := gitCommitsToAPICommits(commits, c)
+	if err != nil {
+		c.Error(err, "convert git commits to api commits")
+		return
+	}
+	c.JSONSuccess(apiCommits)
+}
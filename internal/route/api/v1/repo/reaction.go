@@ -0,0 +1,144 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	api "github.com/gogs/go-gogs-client"
+
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/database"
+)
+
+// Reaction is the API representation of a reaction of a user.
+type Reaction struct {
+	User    *api.User `json:"user"`
+	Content string    `json:"content"`
+	Created time.Time `json:"created_at"`
+}
+
+// ReactionOption is the API message for adding or removing a reaction.
+type ReactionOption struct {
+	Content string `json:"content" binding:"Required"`
+}
+
+func listReactions(c *context.APIContext, reactions database.ReactionList) {
+	users, err := reactions.Users()
+	if err != nil {
+		c.Error(err, "get users of reactions")
+		return
+	}
+
+	apiReactions := make([]*Reaction, 0, len(reactions))
+	for _, r := range reactions {
+		u := users[r.UserID]
+		if u == nil {
+			continue
+		}
+		apiReactions = append(apiReactions, &Reaction{
+			User:    u.APIFormat(),
+			Content: r.Type,
+			Created: r.Created,
+		})
+	}
+	c.JSONSuccess(apiReactions)
+}
+
+// getReactionTarget returns the issue of the request, and the comment of the
+// request if any.
+func getReactionTarget(c *context.APIContext) (issue *database.Issue, commentID int64, reactions database.ReactionList) {
+	if c.Params(":id") == "" {
+		issue, err := database.GetIssueByIndex(c.Repo.Repository.ID, c.ParamsInt64(":index"))
+		if err != nil {
+			c.NotFoundOrError(err, "get issue by index")
+			return nil, 0, nil
+		}
+		return issue, 0, issue.Reactions
+	}
+
+	comment, err := database.GetCommentByID(c.ParamsInt64(":id"))
+	if err != nil {
+		c.NotFoundOrError(err, "get comment by ID")
+		return nil, 0, nil
+	}
+	issue, err = database.GetIssueByID(comment.IssueID)
+	if err != nil {
+		c.NotFoundOrError(err, "get issue by ID")
+		return nil, 0, nil
+	} else if issue.RepoID != c.Repo.Repository.ID || comment.Type != database.CommentTypeComment {
+		c.NotFound()
+		return nil, 0, nil
+	}
+
+	if err = database.LoadCommentsReactions([]*database.Comment{comment}); err != nil {
+		c.Error(err, "load comment reactions")
+		return nil, 0, nil
+	}
+	return issue, comment.ID, comment.Reactions
+}
+
+// getReactableTarget is like getReactionTarget but responds with 403 if the
+// conversation is locked and the current user is not a writer.
+func getReactableTarget(c *context.APIContext) (issue *database.Issue, commentID int64) {
+	issue, commentID, _ = getReactionTarget(c)
+	if c.Written() {
+		return nil, 0
+	} else if !c.Repo.CanComment(issue) {
+		c.ErrorStatus(http.StatusForbidden, errors.New("the conversation is locked"))
+		return nil, 0
+	}
+	return issue, commentID
+}
+
+// ListReactions returns reactions to the issue or the comment.
+func ListReactions(c *context.APIContext) {
+	_, _, reactions := getReactionTarget(c)
+	if c.Written() {
+		return
+	}
+	listReactions(c, reactions)
+}
+
+// AddReaction adds the reaction of the current user to the issue or the
+// comment.
+func AddReaction(c *context.APIContext, form ReactionOption) {
+	issue, commentID := getReactableTarget(c)
+	if c.Written() {
+		return
+	}
+
+	reaction, err := database.AddReaction(c.User, issue.ID, commentID, form.Content)
+	if err != nil {
+		if database.IsErrInvalidReactionType(err) {
+			c.ErrorStatus(http.StatusUnprocessableEntity, err)
+		} else {
+			c.Error(err, "add reaction")
+		}
+		return
+	}
+	c.JSON(http.StatusCreated, &Reaction{
+		User:    c.User.APIFormat(),
+		Content: reaction.Type,
+		Created: reaction.Created,
+	})
+}
+
+// RemoveReaction removes the reaction of the current user from the issue or
+// the comment.
+func RemoveReaction(c *context.APIContext, form ReactionOption) {
+	issue, commentID := getReactableTarget(c)
+	if c.Written() {
+		return
+	}
+
+	if err := database.RemoveReaction(c.User, issue.ID, commentID, form.Content); err != nil {
+		c.Error(err, "remove reaction")
+		return
+	}
+	c.NoContent()
+}
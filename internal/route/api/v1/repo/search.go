@@ -0,0 +1,104 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	api "github.com/gogs/go-gogs-client"
+	"github.com/pkg/errors"
+
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/database"
+	"gogs.io/gogs/internal/route/api/v1/convert"
+)
+
+// CodeSearchLine is the API representation of a matched line of a file.
+type CodeSearchLine struct {
+	Number  int    `json:"number"`
+	Content string `json:"content"`
+}
+
+// CodeSearchResult is the API representation of a file that matches a code
+// search.
+type CodeSearchResult struct {
+	Repository *api.Repository   `json:"repository"`
+	Path       string            `json:"path"`
+	HTMLURL    string            `json:"html_url"`
+	Lines      []*CodeSearchLine `json:"lines"`
+	NumLines   int               `json:"num_lines"`
+}
+
+// escapePath escapes each segment of the path to be used in a URL.
+func escapePath(p string) string {
+	segments := strings.Split(p, "/")
+	for i := range segments {
+		segments[i] = url.PathEscape(segments[i])
+	}
+	return strings.Join(segments, "/")
+}
+
+// searchCode searches code by the search function and responds with results
+// of the requested page.
+func searchCode(c *context.APIContext, search func(keyword string, page, pageSize int) ([]*database.CodeSearchResult, int, error)) {
+	if !database.IsCodeSearchEnabled() {
+		c.NotFound()
+		return
+	}
+
+	keyword := strings.TrimSpace(c.Query("q"))
+	if keyword == "" {
+		c.ErrorStatus(http.StatusUnprocessableEntity, errors.New("query cannot be empty"))
+		return
+	}
+
+	pageSize := convert.ToCorrectPageSize(c.QueryInt("limit"))
+	results, total, err := search(keyword, c.QueryInt("page"), pageSize)
+	if err != nil {
+		if err == database.ErrCodeSearchBusy {
+			c.ErrorStatus(http.StatusServiceUnavailable, err)
+			return
+		}
+		c.Error(err, "search code")
+		return
+	}
+
+	apiResults := make([]*CodeSearchResult, len(results))
+	for i, r := range results {
+		lines := make([]*CodeSearchLine, len(r.Lines))
+		for j := range r.Lines {
+			lines[j] = &CodeSearchLine{
+				Number:  r.Lines[j].Num,
+				Content: r.Lines[j].Content,
+			}
+		}
+		apiResults[i] = &CodeSearchResult{
+			Repository: r.Repo.APIFormatLegacy(nil),
+			Path:       r.Path,
+			HTMLURL:    r.Repo.HTMLURL() + "/src/" + escapePath(r.Repo.DefaultBranch) + "/" + escapePath(r.Path),
+			Lines:      lines,
+			NumLines:   r.NumLines,
+		}
+	}
+
+	c.SetLinkHeader(total, pageSize)
+	c.JSONSuccess(apiResults)
+}
+
+// SearchCode searches files of the default branch of the repository.
+func SearchCode(c *context.APIContext) {
+	searchCode(c, func(keyword string, page, pageSize int) ([]*database.CodeSearchResult, int, error) {
+		return database.SearchCode([]*database.Repository{c.Repo.Repository}, keyword, page, pageSize)
+	})
+}
+
+// SearchAllCode searches files of all repositories the user can read.
+func SearchAllCode(c *context.APIContext) {
+	searchCode(c, func(keyword string, page, pageSize int) ([]*database.CodeSearchResult, int, error) {
+		return database.SearchAllCode(c.Req.Context(), c.UserID(), keyword, page, pageSize)
+	})
+}
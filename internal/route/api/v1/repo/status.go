@@ -0,0 +1,198 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gogs/git-module"
+	api "github.com/gogs/go-gogs-client"
+	"github.com/pkg/errors"
+
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/database"
+	"gogs.io/gogs/internal/gitutil"
+)
+
+// CommitStatus is the API representation of a commit status.
+type CommitStatus struct {
+	ID          int64     `json:"id"`
+	State       string    `json:"state"`
+	TargetURL   string    `json:"target_url"`
+	Description string    `json:"description"`
+	Context     string    `json:"context"`
+	Creator     *api.User `json:"creator"`
+	Created     time.Time `json:"created_at"`
+	Updated     time.Time `json:"updated_at"`
+}
+
+// CombinedCommitStatus is the API representation of the combined status of a commit.
+type CombinedCommitStatus struct {
+	State      string          `json:"state"`
+	SHA        string          `json:"sha"`
+	TotalCount int             `json:"total_count"`
+	Statuses   []*CommitStatus `json:"statuses"`
+}
+
+// CreateCommitStatusRequest is the API message for creating a commit status.
+type CreateCommitStatusRequest struct {
+	State       string `json:"state" binding:"Required"`
+	TargetURL   string `json:"target_url"`
+	Description string `json:"description"`
+	Context     string `json:"context"`
+}
+
+func toAPICommitStatus(status *database.CommitStatus) (*CommitStatus, error) {
+	if err := status.LoadAttributes(); err != nil {
+		return nil, err
+	}
+
+	apiStatus := &CommitStatus{
+		ID:          status.ID,
+		State:       string(status.State),
+		TargetURL:   status.TargetURL,
+		Description: status.Description,
+		Context:     status.Context,
+		Created:     status.Created,
+		Updated:     status.Updated,
+	}
+	if status.Creator != nil {
+		apiStatus.Creator = status.Creator.APIFormat()
+	}
+	return apiStatus, nil
+}
+
+func toAPICommitStatuses(statuses []*database.CommitStatus) ([]*CommitStatus, error) {
+	apiStatuses := make([]*CommitStatus, len(statuses))
+	for i := range statuses {
+		apiStatus, err := toAPICommitStatus(statuses[i])
+		if err != nil {
+			return nil, err
+		}
+		apiStatuses[i] = apiStatus
+	}
+	return apiStatuses, nil
+}
+
+// resolveRefCommitID returns the commit ID that given branch, tag or commit
+// SHA points to.
+func resolveRefCommitID(c *context.APIContext, ref string) (string, bool) {
+	gitRepo, err := git.Open(c.Repo.Repository.RepoPath())
+	if err != nil {
+		c.Error(err, "open repository")
+		return "", false
+	}
+
+	var sha string
+	switch {
+	case gitRepo.HasBranch(ref):
+		sha, err = gitRepo.BranchCommitID(ref)
+	case gitRepo.HasTag(ref):
+		sha, err = gitRepo.TagCommitID(ref)
+	default:
+		var commit *git.Commit
+		commit, err = gitRepo.CatFileCommit(ref)
+		if err == nil {
+			sha = commit.ID.String()
+		}
+	}
+	if err != nil {
+		c.NotFoundOrError(gitutil.NewError(err), "get reference commit ID")
+		return "", false
+	}
+	return sha, true
+}
+
+// POST /repos/:username/:reponame/statuses/:sha
+func CreateCommitStatus(c *context.APIContext, r CreateCommitStatusRequest) {
+	sha, ok := resolveRefCommitID(c, c.Params(":sha"))
+	if !ok {
+		return
+	}
+
+	state := database.CommitStatusState(r.State)
+	if !state.IsValid() {
+		c.ErrorStatus(http.StatusUnprocessableEntity, errors.Errorf("invalid state: %s", r.State))
+		return
+	}
+
+	status, err := database.NewCommitStatus(database.NewCommitStatusOptions{
+		Repo:        c.Repo.Repository,
+		Creator:     c.User,
+		SHA:         sha,
+		State:       state,
+		TargetURL:   r.TargetURL,
+		Description: r.Description,
+		Context:     r.Context,
+	})
+	if err != nil {
+		c.Error(err, "new commit status")
+		return
+	}
+
+	apiStatus, err := toAPICommitStatus(status)
+	if err != nil {
+		c.Error(err, "convert commit status")
+		return
+	}
+	c.JSON(http.StatusCreated, apiStatus)
+}
+
+// GET /repos/:username/:reponame/statuses/:sha
+func ListCommitStatuses(c *context.APIContext) {
+	listCommitStatuses(c, c.Params(":sha"))
+}
+
+// GET /repos/:username/:reponame/commits/:ref/statuses
+func ListCommitStatusesByRef(c *context.APIContext) {
+	listCommitStatuses(c, c.Params(":ref"))
+}
+
+func listCommitStatuses(c *context.APIContext, ref string) {
+	sha, ok := resolveRefCommitID(c, ref)
+	if !ok {
+		return
+	}
+
+	statuses, err := database.GetCommitStatuses(c.Repo.Repository.ID, sha)
+	if err != nil {
+		c.Error(err, "get commit statuses")
+		return
+	}
+
+	apiStatuses, err := toAPICommitStatuses(statuses)
+	if err != nil {
+		c.Error(err, "convert commit statuses")
+		return
+	}
+	c.JSONSuccess(apiStatuses)
+}
+
+// GET /repos/:username/:reponame/commits/:ref/status
+func GetCombinedCommitStatus(c *context.APIContext) {
+	sha, ok := resolveRefCommitID(c, c.Params(":ref"))
+	if !ok {
+		return
+	}
+
+	statuses, err := database.GetLatestCommitStatuses(c.Repo.Repository.ID, sha)
+	if err != nil {
+		c.Error(err, "get latest commit statuses")
+		return
+	}
+
+	apiStatuses, err := toAPICommitStatuses(statuses)
+	if err != nil {
+		c.Error(err, "convert commit statuses")
+		return
+	}
+	c.JSONSuccess(&CombinedCommitStatus{
+		State:      string(database.CombinedCommitStatusState(statuses)),
+		SHA:        sha,
+		TotalCount: len(apiStatuses),
+		Statuses:   apiStatuses,
+	})
+}
@@ -8,6 +8,7 @@ import (
 	gocontext "context"
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/go-macaron/i18n"
 	"github.com/unknwon/paginater"
@@ -24,6 +25,7 @@ const (
 	tmplExploreRepos         = "explore/repos"
 	tmplExploreUsers         = "explore/users"
 	tmplExploreOrganizations = "explore/organizations"
+	tmplExploreCode          = "explore/code"
 )
 
 func Home(c *context.Context) {
@@ -59,8 +61,10 @@ func ExploreRepos(c *context.Context) {
 	}
 
 	keyword := c.Query("q")
+	language := c.Query("language")
 	repos, count, err := database.SearchRepositoryByName(&database.SearchRepoOptions{
 		Keyword:  keyword,
+		Language: language,
 		UserID:   c.UserID(),
 		OrderBy:  "updated_unix DESC",
 		Page:     page,
@@ -71,18 +75,65 @@ func ExploreRepos(c *context.Context) {
 		return
 	}
 	c.Data["Keyword"] = keyword
+	c.Data["Language"] = language
 	c.Data["Total"] = count
 	c.Data["Page"] = paginater.New(int(count), conf.UI.ExplorePagingNum, page, 5)
 
+	c.Data["Languages"], err = database.GetPrimaryLanguages(c.UserID())
+	if err != nil {
+		c.Error(err, "get primary languages")
+		return
+	}
+
 	if err = database.RepositoryList(repos).LoadAttributes(); err != nil {
 		c.Error(err, "load attributes")
 		return
 	}
+	if err = database.RepositoryList(repos).LoadPrimaryLanguages(); err != nil {
+		c.Error(err, "load primary languages")
+		return
+	}
 	c.Data["Repos"] = repos
 
 	c.Success(tmplExploreRepos)
 }
 
+// ExploreCode searches code of all repositories the user can read.
+func ExploreCode(c *context.Context) {
+	if !database.IsCodeSearchEnabled() {
+		c.NotFound()
+		return
+	}
+
+	c.Data["Title"] = c.Tr("explore")
+	c.Data["PageIsExplore"] = true
+	c.Data["PageIsExploreCode"] = true
+
+	page := c.QueryInt("page")
+	if page <= 0 {
+		page = 1
+	}
+
+	keyword := strings.TrimSpace(c.Query("q"))
+	c.Data["Keyword"] = keyword
+	if keyword != "" {
+		results, total, err := database.SearchAllCode(c.Req.Context(), c.UserID(), keyword, page, conf.UI.ExplorePagingNum)
+		if err != nil {
+			if err == database.ErrCodeSearchBusy {
+				c.RenderWithErr(c.Tr("explore.code_search_busy"), tmplExploreCode, nil)
+				return
+			}
+			c.Error(err, "search code")
+			return
+		}
+		c.Data["Results"] = results
+		c.Data["Total"] = total
+		c.Data["Page"] = paginater.New(total, conf.UI.ExplorePagingNum, page, 5)
+	}
+
+	c.Success(tmplExploreCode)
+}
+
 type UserSearchOptions struct {
 	Type     database.UserType
 	Counter  func(ctx gocontext.Context) int64
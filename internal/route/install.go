@@ -85,6 +85,8 @@ func GlobalInit(customConf string) error {
 		database.InitSyncMirrors()
 		database.InitDeliverHooks()
 		database.InitTestPullRequests()
+		database.InitRepoIndexer()
+		database.InitIssueIndexer()
 	}
 	if conf.HasMinWinSvc {
 		log.Info("Builtin Windows Service is supported")
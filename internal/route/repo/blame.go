@@ -0,0 +1,104 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"html/template"
+	"strings"
+	"time"
+
+	"gogs.io/gogs/internal/conf"
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/gitutil"
+	"gogs.io/gogs/internal/template/highlight"
+)
+
+const (
+	tmplRepoBlame = "repo/blame"
+)
+
+// blamePart is a part of blame results with highlighted lines.
+type blamePart struct {
+	*gitutil.BlamePart
+	Lines []template.HTML
+}
+
+// Blame shows the commit, author and age of the last change of each line of a
+// file.
+func Blame(c *context.Context) {
+	entry, err := c.Repo.Commit.TreeEntry(c.Repo.TreePath)
+	if err != nil {
+		c.NotFoundOrError(gitutil.NewError(err), "get tree entry")
+		return
+	} else if entry.IsTree() || entry.IsCommit() {
+		c.NotFound()
+		return
+	}
+
+	blob := entry.Blob()
+	c.Data["Title"] = c.Repo.TreePath + " · " + c.Repo.Repository.FullName()
+	c.Data["PageIsViewFiles"] = true
+	c.Data["FileName"] = blob.Name()
+	c.Data["FileSize"] = blob.Size()
+	c.Data["RawFileLink"] = c.Repo.RepoLink + "/raw/" + c.Repo.BranchName + "/" + c.Repo.TreePath
+
+	treeNames := strings.Split(c.Repo.TreePath, "/")
+	paths := make([]string, len(treeNames))
+	for i := range treeNames {
+		paths[i] = strings.Join(treeNames[:i+1], "/")
+	}
+	c.Data["TreeNames"] = treeNames
+	c.Data["Paths"] = paths
+	c.Data["BranchLink"] = c.Repo.RepoLink + "/src/" + c.Repo.BranchName
+
+	p, err := gitutil.ReadBlameBlob(blob, conf.UI.MaxDisplayFileSize)
+	if err != nil {
+		switch err {
+		case gitutil.ErrBlameFileTooLarge:
+			c.Data["IsFileTooLarge"] = true
+		case gitutil.ErrBlameBinaryFile:
+			c.Data["IsNotTextFile"] = true
+		default:
+			c.Error(err, "read blob")
+			return
+		}
+		c.Success(tmplRepoBlame)
+		return
+	}
+
+	parts, err := gitutil.BlameFile(
+		c.Repo.GitRepo.Path(),
+		c.Repo.CommitID,
+		c.Repo.TreePath,
+		time.Duration(conf.Git.Timeout.Blame)*time.Second,
+	)
+	if err != nil {
+		c.Error(err, "blame file")
+		return
+	}
+
+	// Highlight the file as a whole to have tokens spanning multiple lines (e.g.
+	// block comments) rendered correctly.
+	var lines []string
+	for _, part := range parts {
+		for _, line := range part.Lines {
+			lines = append(lines, strings.TrimRight(line, "\r"))
+		}
+	}
+	lexer := highlight.Lexer(blob.Name(), gitutil.ReadAttributes(c.Repo.Commit, c.Repo.TreePath).Language(c.Repo.TreePath), p)
+	highlighted := highlight.Lines(lexer, strings.Join(lines, "\n"))
+
+	blameParts := make([]*blamePart, len(parts))
+	offset := 0
+	for i, part := range parts {
+		blameParts[i] = &blamePart{
+			BlamePart: part,
+			Lines:     highlighted[offset : offset+len(part.Lines)],
+		}
+		offset += len(part.Lines)
+	}
+	c.Data["BlameParts"] = blameParts
+	c.Success(tmplRepoBlame)
+}
@@ -6,7 +6,10 @@ package repo
 
 import (
 	gocontext "context"
+	"net/http"
+	"net/url"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/gogs/git-module"
@@ -54,14 +57,30 @@ func renderCommits(c *context.Context, filename string) {
 		pageSize = conf.UI.User.CommitsPagingNum
 	}
 
-	commits, err := c.Repo.Commit.CommitsByPage(page, pageSize, git.CommitsByPageOptions{Path: filename})
-	if err != nil {
-		c.Error(err, "paging commits")
-		return
+	var commits []*git.Commit
+	var err error
+	if filename != "" && c.QueryBool("follow") {
+		var paths map[string]string
+		commits, paths, err = followFileHistory(c.Repo.GitRepo, c.Repo.CommitID, filename, (page-1)*pageSize, pageSize)
+		if err != nil {
+			c.Error(err, "follow file history")
+			return
+		}
+		c.Data["Follow"] = true
+		c.Data["CommitPaths"] = paths
+	} else {
+		commits, err = c.Repo.Commit.CommitsByPage(page, pageSize, git.CommitsByPageOptions{Path: filename})
+		if err != nil {
+			c.Error(err, "paging commits")
+			return
+		}
 	}
 
 	commits = RenderIssueLinks(commits, c.Repo.RepoLink)
 	c.Data["Commits"] = matchUsersWithCommitEmails(c.Req.Context(), commits)
+	if !setCommitStatuses(c, commits) {
+		return
+	}
 
 	if page > 1 {
 		c.Data["HasPrevious"] = true
@@ -78,6 +97,28 @@ func renderCommits(c *context.Context, filename string) {
 	c.Success(COMMITS)
 }
 
+// setCommitStatuses puts the combined status state of each commit into the
+// context data. It returns false if an error has been written to the response.
+func setCommitStatuses(c *context.Context, commits []*git.Commit) bool {
+	shas := make([]string, len(commits))
+	for i := range commits {
+		shas[i] = commits[i].ID.String()
+	}
+
+	statuses, err := database.GetLatestCommitStatusesBySHAs(c.Repo.Repository.ID, shas)
+	if err != nil {
+		c.Error(err, "get latest commit statuses by SHAs")
+		return false
+	}
+
+	states := make(map[string]database.CommitStatusState, len(statuses))
+	for sha := range statuses {
+		states[sha] = database.CombinedCommitStatusState(statuses[sha])
+	}
+	c.Data["CommitStatuses"] = states
+	return true
+}
+
 func Commits(c *context.Context) {
 	renderCommits(c, "")
 }
@@ -99,6 +140,9 @@ func SearchCommits(c *context.Context) {
 
 	commits = RenderIssueLinks(commits, c.Repo.RepoLink)
 	c.Data["Commits"] = matchUsersWithCommitEmails(c.Req.Context(), commits)
+	if !setCommitStatuses(c, commits) {
+		return
+	}
 
 	c.Data["Keyword"] = keyword
 	c.Data["Username"] = c.Repo.Owner.Name
@@ -108,9 +152,42 @@ func SearchCommits(c *context.Context) {
 }
 
 func FileHistory(c *context.Context) {
+	// Renames can only be followed for a single file.
+	entry, err := c.Repo.Commit.TreeEntry(c.Repo.TreePath)
+	if err == nil && !entry.IsTree() {
+		c.Data["CanFollow"] = true
+	} else if c.QueryBool("follow") {
+		c.Redirect(c.Repo.RepoLink + "/commits/" + c.Repo.BranchName + "/" + c.Repo.TreePath)
+		return
+	}
 	renderCommits(c, c.Repo.TreePath)
 }
 
+// followFileHistory returns commits that changed the file following renames,
+// along with the path of the file in each commit.
+func followFileHistory(gitRepo *git.Repository, rev, file string, skip, limit int) ([]*git.Commit, map[string]string, error) {
+	entries, err := gitutil.FollowFileHistory(gitRepo.Path(), rev, file, skip, limit, time.Duration(conf.Git.Timeout.Log)*time.Second)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	commits := make([]*git.Commit, 0, len(entries))
+	paths := make(map[string]string, len(entries))
+	for _, e := range entries {
+		commit, err := gitRepo.CatFileCommit(e.CommitID)
+		if err != nil {
+			return nil, nil, err
+		}
+		commits = append(commits, commit)
+		if e.Path != "" {
+			paths[e.CommitID] = e.Path
+		} else {
+			paths[e.CommitID] = file
+		}
+	}
+	return commits, paths, nil
+}
+
 // tryGetUserByEmail returns a non-nil value if the email is corresponding to an
 // existing user.
 func tryGetUserByEmail(ctx gocontext.Context, email string) *database.User {
@@ -215,45 +292,124 @@ func matchUsersWithCommitEmails(ctx gocontext.Context, oldCommits []*git.Commit)
 	return newCommits
 }
 
-func CompareDiff(c *context.Context) {
-	c.Data["IsDiffCompare"] = true
-	userName := c.Repo.Owner.Name
-	repoName := c.Repo.Repository.Name
-	beforeCommitID := c.Params(":before")
-	afterCommitID := c.Params(":after")
+// isPullRequestCompare returns true if the range should be shown as a new pull
+// request, i.e. the head is in a fork or both sides are branches that the user
+// can open a pull request with.
+func isPullRequestCompare(c *context.Context, r *gitutil.CompareRange) bool {
+	if r.Direct || !c.Repo.Repository.AllowsPulls() {
+		return false
+	}
+	if strings.Contains(r.Head, ":") {
+		return true
+	}
+	return c.IsLogged && c.Repo.IsWriter() &&
+		c.Repo.GitRepo.HasBranch(r.Base) && c.Repo.GitRepo.HasBranch(r.Head)
+}
+
+// Compare shows commits and the diff between two revisions, which can be
+// branches, tags or (short) commit IDs in the form of "<base>...<head>" to
+// compare with the merge base, or "<base>..<head>" to compare directly. The
+// raw patch or diff is returned with the ".patch" or ".diff" suffix.
+func Compare(c *context.Context) {
+	spec := c.Params("*")
+	var rawFormat git.RawDiffFormat
+	for _, format := range []git.RawDiffFormat{git.RawDiffPatch, git.RawDiffNormal} {
+		if strings.HasSuffix(spec, "."+string(format)) {
+			rawFormat = format
+			spec = strings.TrimSuffix(spec, "."+string(format))
+			break
+		}
+	}
+
+	r, ok := gitutil.ParseCompareRange(spec)
+	if !ok {
+		c.NotFound()
+		return
+	}
 
-	commit, err := c.Repo.GitRepo.CatFileCommit(afterCommitID)
+	if rawFormat == "" && isPullRequestCompare(c, r) {
+		if !c.IsLogged {
+			c.SetCookie("redirect_to", url.QueryEscape(conf.Server.Subpath+c.Req.RequestURI), 0, conf.Server.Subpath)
+			c.RedirectSubpath("/user/login")
+			return
+		}
+		MustAllowPulls(c)
+		if c.Written() {
+			return
+		}
+		CompareAndPullRequest(c)
+		return
+	}
+
+	timeout := time.Duration(conf.Git.Timeout.Diff) * time.Second
+	cmp, err := gitutil.Compare(c.Repo.GitRepo, r, timeout)
+	if err != nil {
+		if gitutil.IsErrNoMergeBase(err) {
+			c.NotFound()
+			return
+		}
+		c.NotFoundOrError(gitutil.NewError(err), "compare revisions")
+		return
+	}
+
+	if rawFormat != "" {
+		data, err := cmp.RawDiff(c.Repo.GitRepo.Path(), rawFormat, gitutil.CompareMaxRawDiffSize, timeout)
+		if err != nil {
+			if err == gitutil.ErrRawDiffTooLarge {
+				c.PlainText(http.StatusUnprocessableEntity, err.Error())
+				return
+			}
+			c.Error(err, "get raw comparison")
+			return
+		}
+		ServeRawDiff(c, data)
+		return
+	}
+
+	commit, err := c.Repo.GitRepo.CatFileCommit(cmp.HeadCommitID)
 	if err != nil {
 		c.NotFoundOrError(gitutil.NewError(err), "get head commit")
 		return
 	}
 
 	diff, err := gitutil.RepoDiff(c.Repo.GitRepo,
-		afterCommitID, conf.Git.MaxDiffFiles, conf.Git.MaxDiffLines, conf.Git.MaxDiffLineChars,
-		git.DiffOptions{Base: beforeCommitID, Timeout: time.Duration(conf.Git.Timeout.Diff) * time.Second},
+		cmp.HeadCommitID, conf.Git.MaxDiffFiles, conf.Git.MaxDiffLines, conf.Git.MaxDiffLineChars,
+		git.DiffOptions{Base: cmp.DiffBase(), Timeout: timeout},
 	)
 	if err != nil {
 		c.NotFoundOrError(gitutil.NewError(err), "get diff")
 		return
 	}
 
-	commits, err := commit.CommitsAfter(beforeCommitID)
+	commits, err := cmp.Commits(c.Repo.GitRepo, gitutil.CompareMaxCommits, timeout)
+	if err != nil {
+		c.Error(err, "list commits")
+		return
+	}
+	numCommits, err := cmp.NumCommits(c.Repo.GitRepo, timeout)
 	if err != nil {
-		c.NotFoundOrError(gitutil.NewError(err), "get commits after")
+		c.Error(err, "count commits")
 		return
 	}
 
+	userName := c.Repo.Owner.Name
+	repoName := c.Repo.Repository.Name
+	beforeCommitID := cmp.DiffBase()
+	afterCommitID := cmp.HeadCommitID
+	c.Data["IsDiffCompare"] = true
 	c.Data["IsSplitStyle"] = c.Query("style") == "split"
 	c.Data["CommitRepoLink"] = c.Repo.RepoLink
 	c.Data["Commits"] = matchUsersWithCommitEmails(c.Req.Context(), commits)
-	c.Data["CommitsCount"] = len(commits)
+	c.Data["CommitsCount"] = numCommits
+	c.Data["Comparison"] = cmp
+	c.Data["CompareLink"] = c.Repo.RepoLink + "/compare/" + r.String()
 	c.Data["BeforeCommitID"] = beforeCommitID
 	c.Data["AfterCommitID"] = afterCommitID
 	c.Data["Username"] = userName
 	c.Data["Reponame"] = repoName
 	c.Data["IsImageFile"] = commit.IsImageFile
 	c.Data["IsImageFileByIndex"] = commit.IsImageFileByIndex
-	c.Data["Title"] = "Comparing " + tool.ShortSHA1(beforeCommitID) + "..." + tool.ShortSHA1(afterCommitID) + " · " + userName + "/" + repoName
+	c.Data["Title"] = "Comparing " + r.String() + " · " + userName + "/" + repoName
 	c.Data["Commit"] = commit
 	c.Data["Diff"] = diff
 	c.Data["DiffNotAvailable"] = diff.NumFiles() == 0
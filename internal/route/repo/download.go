@@ -10,6 +10,7 @@ import (
 	"path"
 
 	"github.com/gogs/git-module"
+	log "unknwon.dev/clog/v2"
 
 	"gogs.io/gogs/internal/conf"
 	"gogs.io/gogs/internal/context"
@@ -48,6 +49,16 @@ func ServeBlob(c *context.Context, blob *git.Blob) error {
 	return serveData(c, path.Base(c.Repo.TreePath), p)
 }
 
+// ServeRawDiff writes the raw diff or patch as plain text. The data should be
+// fully generated beforehand, so that failures can still be responded with a
+// proper status code instead of a truncated body.
+func ServeRawDiff(c *context.Context, data []byte) {
+	c.Resp.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if _, err := c.Resp.Write(data); err != nil {
+		log.Error("Failed to write raw diff to response: %v", err)
+	}
+}
+
 func SingleDownload(c *context.Context) {
 	blob, err := c.Repo.Commit.Blob(c.Repo.TreePath)
 	if err != nil {
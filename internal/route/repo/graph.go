@@ -0,0 +1,166 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"fmt"
+	"time"
+
+	"gogs.io/gogs/internal/conf"
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/gitutil"
+)
+
+const (
+	tmplRepoGraph = "repo/graph"
+)
+
+// Dimensions of the commit graph in pixels.
+const (
+	graphRowHeight   = 32
+	graphColumnWidth = 16
+	graphDotRadius   = 4
+)
+
+// graphMaxCommits is the maximum number of commits to be laid out for the
+// commit graph. Every page requires laying out all commits before it, so pages
+// beyond the limit are not served.
+const graphMaxCommits = 10000
+
+var graphColors = []string{
+	"#2185d0", "#21ba45", "#db2828", "#a333c8", "#f2711c", "#00b5ad", "#e03997", "#a5673f",
+}
+
+// graphPath is a line of the commit graph in a row, which is drawn as an SVG
+// path.
+type graphPath struct {
+	D     string
+	Color string
+}
+
+// graphRow is a row of the commit graph with the coordinates to draw.
+type graphRow struct {
+	*gitutil.GraphRow
+	DotX     int
+	DotY     int
+	DotColor string
+	Paths    []*graphPath
+}
+
+func graphX(column int) int {
+	return column*graphColumnWidth + graphColumnWidth/2
+}
+
+func graphY(level int) int {
+	return level * graphRowHeight / 2
+}
+
+// newGraphRow computes coordinates of the commit and edges of the row, edges
+// across columns are drawn as curves.
+func newGraphRow(row *gitutil.GraphRow) *graphRow {
+	r := &graphRow{
+		GraphRow: row,
+		DotX:     graphX(row.Column),
+		DotY:     graphY(gitutil.GraphLevelMiddle),
+		DotColor: graphColors[row.Column%len(graphColors)],
+		Paths:    make([]*graphPath, 0, len(row.Edges)),
+	}
+	for _, e := range row.Edges {
+		x1, y1 := graphX(e.FromColumn), graphY(e.FromLevel)
+		x2, y2 := graphX(e.ToColumn), graphY(e.ToLevel)
+		var d string
+		if x1 == x2 {
+			d = fmt.Sprintf("M%d %dL%d %d", x1, y1, x2, y2)
+		} else {
+			ym := (y1 + y2) / 2
+			d = fmt.Sprintf("M%d %dC%d %d %d %d %d %d", x1, y1, x1, ym, x2, ym, x2, y2)
+		}
+		r.Paths = append(r.Paths, &graphPath{
+			D:     d,
+			Color: graphColors[e.Lane%len(graphColors)],
+		})
+	}
+	return r
+}
+
+// Graph shows the commit graph of all branches or the given branch, so that
+// the merge history can be inspected.
+func Graph(c *context.Context) {
+	c.Data["Title"] = c.Tr("repo.commits.graph") + " · " + c.Repo.Repository.FullName()
+	c.Data["PageIsViewFiles"] = true
+	c.Data["PageIsCommits"] = true
+
+	branches, err := c.Repo.GitRepo.Branches()
+	if err != nil {
+		c.Error(err, "list branches")
+		return
+	}
+	c.Data["Branches"] = branches
+
+	branch := c.Query("branch")
+	if branch != "" && !c.Repo.GitRepo.HasBranch(branch) {
+		c.NotFound()
+		return
+	}
+	c.Data["Branch"] = branch
+
+	page := c.QueryInt("page")
+	if page < 1 {
+		page = 1
+	}
+	pageSize := conf.UI.User.CommitsPagingNum
+	maxPage := graphMaxCommits / pageSize
+	if maxPage < 1 {
+		maxPage = 1
+	}
+	if page > maxPage {
+		page = maxPage
+	}
+
+	// The layout of a page depends on all commits before it, and one more commit
+	// tells whether there is a next page.
+	rows, err := gitutil.CommitGraph(
+		c.Repo.GitRepo.Path(),
+		branch,
+		page*pageSize+1,
+		time.Duration(conf.Git.Timeout.Graph)*time.Second,
+	)
+	if err != nil {
+		c.Error(err, "get commit graph")
+		return
+	}
+
+	start := (page - 1) * pageSize
+	if start > len(rows) {
+		start = len(rows)
+	}
+	end := start + pageSize
+	if end > len(rows) {
+		end = len(rows)
+	}
+
+	width := 1
+	graphRows := make([]*graphRow, 0, end-start)
+	for _, row := range rows[start:end] {
+		if row.Width > width {
+			width = row.Width
+		}
+		graphRows = append(graphRows, newGraphRow(row))
+	}
+	c.Data["GraphRows"] = graphRows
+	c.Data["GraphWidth"] = width * graphColumnWidth
+	c.Data["GraphHeight"] = graphRowHeight
+	c.Data["GraphDotRadius"] = graphDotRadius
+
+	if page > 1 {
+		c.Data["HasPrevious"] = true
+		c.Data["PreviousPage"] = page - 1
+	}
+	if len(rows) > end && page < maxPage {
+		c.Data["HasNext"] = true
+		c.Data["NextPage"] = page + 1
+	}
+	c.Success(tmplRepoGraph)
+}
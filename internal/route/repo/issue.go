@@ -27,6 +27,7 @@ import (
 const (
 	tmplRepoIssueList          = "repo/issue/list"
 	tmplRepoIssueNew           = "repo/issue/new"
+	tmplRepoIssueChoose        = "repo/issue/choose"
 	tmplRepoIssueView          = "repo/issue/view"
 	tmplRepoIssueLabels        = "repo/issue/labels"
 	tmplRepoIssueMilestones    = "repo/issue/milestones"
@@ -39,12 +40,6 @@ const (
 var (
 	ErrFileTypeForbidden = errors.New("File type is not allowed")
 	ErrTooManyFiles      = errors.New("Maximum number of files to upload exceeded")
-
-	IssueTemplateCandidates = []string{
-		"ISSUE_TEMPLATE.md",
-		".gogs/ISSUE_TEMPLATE.md",
-		".github/ISSUE_TEMPLATE.md",
-	}
 )
 
 func MustEnableIssues(c *context.Context) {
@@ -73,9 +68,9 @@ func MustAllowPulls(c *context.Context) {
 }
 
 func RetrieveLabels(c *context.Context) {
-	labels, err := database.GetLabelsByRepoID(c.Repo.Repository.ID)
+	labels, err := database.GetLabelsAvailableToRepo(c.Repo.Repository)
 	if err != nil {
-		c.Error(err, "get labels by repository ID")
+		c.Error(err, "get labels available to repository")
 		return
 	}
 	for _, l := range labels {
@@ -106,6 +101,9 @@ func issues(c *context.Context, isPullList bool) {
 	viewType := c.Query("type")
 	sortType := c.Query("sort")
 	types := []string{"assigned", "created_by", "mentioned"}
+	if isPullList {
+		types = append(types, "review_requested")
+	}
 	if !com.IsSliceContainsStr(types, viewType) {
 		viewType = "all"
 	}
@@ -119,8 +117,12 @@ func issues(c *context.Context, isPullList bool) {
 
 	var (
 		assigneeID = c.QueryInt64("assignee")
+		reviewerID int64
 		posterID   int64
 	)
+	if isPullList {
+		reviewerID = c.QueryInt64("reviewer")
+	}
 	filterMode := database.FilterModeYourRepos
 	switch viewType {
 	case "assigned":
@@ -131,6 +133,9 @@ func issues(c *context.Context, isPullList bool) {
 		posterID = c.User.ID
 	case "mentioned":
 		filterMode = database.FilterModeMention
+	case "review_requested":
+		filterMode = database.FilterModeReviewRequested
+		reviewerID = c.User.ID
 	}
 
 	var uid int64 = -1
@@ -142,14 +147,27 @@ func issues(c *context.Context, isPullList bool) {
 	selectLabels := c.Query("labels")
 	milestoneID := c.QueryInt64("milestone")
 	isShowClosed := c.Query("state") == "closed"
+	filter := ParseIssueQuery(c, &isShowClosed, &sortType)
+	if c.Written() {
+		return
+	}
+	draft := database.DraftFilter(c.Query("draft"))
+	if !isPullList || (draft != database.DraftFilterDraft && draft != database.DraftFilterReady) {
+		draft = database.DraftFilterNone
+	}
+	due := database.ParseDueFilter(c.Query("due"))
 	issueStats := database.GetIssueStats(&database.IssueStatsOptions{
 		RepoID:      repo.ID,
 		UserID:      uid,
 		Labels:      selectLabels,
 		MilestoneID: milestoneID,
 		AssigneeID:  assigneeID,
+		ReviewerID:  reviewerID,
 		FilterMode:  filterMode,
 		IsPull:      isPullList,
+		Draft:       draft,
+		Due:         due,
+		Filter:      filter,
 	})
 
 	page := c.QueryInt("page")
@@ -169,6 +187,7 @@ func issues(c *context.Context, isPullList bool) {
 	issues, err := database.Issues(&database.IssuesOptions{
 		UserID:      uid,
 		AssigneeID:  assigneeID,
+		ReviewerID:  reviewerID,
 		RepoID:      repo.ID,
 		PosterID:    posterID,
 		MilestoneID: milestoneID,
@@ -176,8 +195,11 @@ func issues(c *context.Context, isPullList bool) {
 		IsClosed:    isShowClosed,
 		IsMention:   filterMode == database.FilterModeMention,
 		IsPull:      isPullList,
+		Draft:       draft,
+		Due:         due,
 		Labels:      selectLabels,
 		SortType:    sortType,
+		Filter:      filter,
 	})
 	if err != nil {
 		c.Error(err, "list issues")
@@ -224,6 +246,8 @@ func issues(c *context.Context, isPullList bool) {
 
 	if viewType == "assigned" {
 		assigneeID = 0 // Reset ID to prevent unexpected selection of assignee.
+	} else if viewType == "review_requested" {
+		reviewerID = 0 // Reset ID to prevent unexpected selection of reviewer.
 	}
 
 	c.Data["IssueStats"] = issueStats
@@ -232,7 +256,10 @@ func issues(c *context.Context, isPullList bool) {
 	c.Data["SortType"] = sortType
 	c.Data["MilestoneID"] = milestoneID
 	c.Data["AssigneeID"] = assigneeID
+	c.Data["ReviewerID"] = reviewerID
 	c.Data["IsShowClosed"] = isShowClosed
+	c.Data["Draft"] = draft
+	c.Data["Due"] = due
 	if isShowClosed {
 		c.Data["State"] = "closed"
 	} else {
@@ -283,9 +310,9 @@ func RetrieveRepoMetas(c *context.Context, repo *database.Repository) []*databas
 		return nil
 	}
 
-	labels, err := database.GetLabelsByRepoID(repo.ID)
+	labels, err := database.GetLabelsAvailableToRepo(repo)
 	if err != nil {
-		c.Error(err, "get labels by repository ID")
+		c.Error(err, "get labels available to repository")
 		return nil
 	}
 	c.Data["Labels"] = labels
@@ -294,40 +321,12 @@ func RetrieveRepoMetas(c *context.Context, repo *database.Repository) []*databas
 	if c.Written() {
 		return nil
 	}
+	c.Data["AssigneeIDMark"] = map[int64]bool{}
+	c.Data["ReviewerIDMark"] = map[int64]bool{}
 
 	return labels
 }
 
-func getFileContentFromDefaultBranch(c *context.Context, filename string) (string, bool) {
-	if c.Repo.Commit == nil {
-		var err error
-		c.Repo.Commit, err = c.Repo.GitRepo.BranchCommit(c.Repo.Repository.DefaultBranch)
-		if err != nil {
-			return "", false
-		}
-	}
-
-	entry, err := c.Repo.Commit.TreeEntry(filename)
-	if err != nil {
-		return "", false
-	}
-	p, err := entry.Blob().Bytes()
-	if err != nil {
-		return "", false
-	}
-	return string(p), true
-}
-
-func setTemplateIfExists(c *context.Context, ctxDataKey string, possibleFiles []string) {
-	for _, filename := range possibleFiles {
-		content, found := getFileContentFromDefaultBranch(c, filename)
-		if found {
-			c.Data[ctxDataKey] = content
-			return
-		}
-	}
-}
-
 func NewIssue(c *context.Context) {
 	c.Data["Title"] = c.Tr("repo.issues.new")
 	c.Data["PageIsIssueList"] = true
@@ -335,18 +334,73 @@ func NewIssue(c *context.Context) {
 	c.Data["RequireSimpleMDE"] = true
 	c.Data["title"] = c.Query("title")
 	c.Data["content"] = c.Query("content")
-	setTemplateIfExists(c, IssueTemplateKey, IssueTemplateCandidates)
+
+	tmpls, err := c.Repo.Repository.GetIssueTemplates()
+	if err != nil {
+		c.Error(err, "get issue templates")
+		return
+	}
+
+	// Let the user choose one of templates before writing the issue.
+	templateName := c.Query("template")
+	if len(tmpls) > 1 && templateName == "" {
+		c.Data["IssueTemplates"] = tmpls
+		c.Success(tmplRepoIssueChoose)
+		return
+	}
+
+	var tmpl *database.IssueTemplate
+	for i := range tmpls {
+		if len(tmpls) == 1 || tmpls[i].FileName == templateName {
+			tmpl = tmpls[i]
+			break
+		}
+	}
+	if tmpl != nil {
+		c.Data[IssueTemplateKey] = tmpl.Content
+		if c.Query("title") == "" {
+			c.Data["title"] = tmpl.Title
+		}
+	}
 	renderAttachmentSettings(c)
 
-	RetrieveRepoMetas(c, c.Repo.Repository)
+	labels := RetrieveRepoMetas(c, c.Repo.Repository)
 	if c.Written() {
 		return
 	}
 
+	// Pre-select labels of the template.
+	if tmpl != nil && len(labels) > 0 && len(tmpl.Labels) > 0 {
+		labelIDs := make([]string, 0, len(tmpl.Labels))
+		for _, label := range labels {
+			for _, name := range tmpl.Labels {
+				if strings.EqualFold(label.Name, name) {
+					label.IsChecked = true
+					labelIDs = append(labelIDs, com.ToStr(label.ID))
+					break
+				}
+			}
+		}
+		c.Data["HasSelectedLabel"] = len(labelIDs) > 0
+		c.Data["label_ids"] = strings.Join(labelIDs, ",")
+	}
+
 	c.Success(tmplRepoIssueNew)
 }
 
-func ValidateRepoMetas(c *context.Context, f form.NewIssue) ([]int64, int64, int64) {
+// selectedUsers returns users of given IDs from candidates, in the order of candidates.
+func selectedUsers(candidates []*database.User, ids []int64) []*database.User {
+	idMark := tool.Int64sToMap(ids)
+	users := make([]*database.User, 0, len(ids))
+	for i := range candidates {
+		if idMark[candidates[i].ID] {
+			users = append(users, candidates[i])
+		}
+	}
+	return users
+}
+
+func ValidateRepoMetas(c *context.Context, f form.NewIssue) ([]int64, int64, []int64, []int64) {
 	var (
 		repo = c.Repo.Repository
 		err  error
@@ -354,11 +408,11 @@ func ValidateRepoMetas(c *context.Context, f form.NewIssue) ([]int64, int64, int
 
 	labels := RetrieveRepoMetas(c, c.Repo.Repository)
 	if c.Written() {
-		return nil, 0, 0
+		return nil, 0, nil, nil
 	}
 
 	if !c.Repo.IsWriter() {
-		return nil, 0, 0
+		return nil, 0, nil, nil
 	}
 
 	// Check labels.
@@ -381,23 +435,32 @@ func ValidateRepoMetas(c *context.Context, f form.NewIssue) ([]int64, int64, int
 		c.Data["Milestone"], err = repo.GetMilestoneByID(milestoneID)
 		if err != nil {
 			c.Error(err, "get milestone by ID")
-			return nil, 0, 0
+			return nil, 0, nil, nil
 		}
 		c.Data["milestone_id"] = milestoneID
 	}
 
-	// Check assignee.
-	assigneeID := f.AssigneeID
-	if assigneeID > 0 {
-		c.Data["Assignee"], err = repo.GetAssigneeByID(assigneeID)
-		if err != nil {
-			c.Error(err, "get assignee by ID")
-			return nil, 0, 0
-		}
-		c.Data["assignee_id"] = assigneeID
+	// Check assignees and reviewers, users that are not assignable are dropped.
+	candidates, _ := c.Data["Assignees"].([]*database.User)
+	assignees := selectedUsers(candidates, tool.StringsToInt64s(strings.Split(f.AssigneeIDs, ",")))
+	assigneeIDs := make([]int64, len(assignees))
+	for i := range assignees {
+		assigneeIDs[i] = assignees[i].ID
 	}
+	c.Data["SelectedAssignees"] = assignees
+	c.Data["AssigneeIDMark"] = tool.Int64sToMap(assigneeIDs)
+	c.Data["assignee_ids"] = f.AssigneeIDs
 
-	return labelIDs, milestoneID, assigneeID
+	reviewers := selectedUsers(candidates, tool.StringsToInt64s(strings.Split(f.ReviewerIDs, ",")))
+	reviewerIDs := make([]int64, len(reviewers))
+	for i := range reviewers {
+		reviewerIDs[i] = reviewers[i].ID
+	}
+	c.Data["SelectedReviewers"] = reviewers
+	c.Data["ReviewerIDMark"] = tool.Int64sToMap(reviewerIDs)
+	c.Data["reviewer_ids"] = f.ReviewerIDs
+
+	return labelIDs, milestoneID, assigneeIDs, reviewerIDs
 }
 
 func NewIssuePost(c *context.Context, f form.NewIssue) {
@@ -407,7 +470,7 @@ func NewIssuePost(c *context.Context, f form.NewIssue) {
 	c.Data["RequireSimpleMDE"] = true
 	renderAttachmentSettings(c)
 
-	labelIDs, milestoneID, assigneeID := ValidateRepoMetas(c, f)
+	labelIDs, milestoneID, assigneeIDs, _ := ValidateRepoMetas(c, f)
 	if c.Written() {
 		return
 	}
@@ -428,10 +491,15 @@ func NewIssuePost(c *context.Context, f form.NewIssue) {
 		PosterID:    c.User.ID,
 		Poster:      c.User,
 		MilestoneID: milestoneID,
-		AssigneeID:  assigneeID,
 		Content:     f.Content,
 	}
-	if err := database.NewIssue(c.Repo.Repository, issue, labelIDs, attachments); err != nil {
+	if err := database.NewIssueWithOptions(database.NewIssueOptions{
+		Repo:        c.Repo.Repository,
+		Issue:       issue,
+		LableIDs:    labelIDs,
+		AssigneeIDs: assigneeIDs,
+		Attachments: attachments,
+	}); err != nil {
 		c.Error(err, "new issue")
 		return
 	}
@@ -503,6 +571,9 @@ func viewIssue(c *context.Context, isPullList bool) {
 
 	issue, err := database.GetIssueByIndex(c.Repo.Repository.ID, index)
 	if err != nil {
+		if database.IsErrIssueNotExist(err) && redirectTransferredIssue(c, index) {
+			return
+		}
 		c.NotFoundOrError(err, "get issue by index")
 		return
 	}
@@ -555,9 +626,9 @@ func viewIssue(c *context.Context, isPullList bool) {
 	for i := range issue.Labels {
 		labelIDMark[issue.Labels[i].ID] = true
 	}
-	labels, err := database.GetLabelsByRepoID(repo.ID)
+	labels, err := database.GetLabelsAvailableToRepo(repo)
 	if err != nil {
-		c.Error(err, "get labels by repository ID")
+		c.Error(err, "get labels available to repository")
 		return
 	}
 	hasSelected := false
@@ -663,10 +734,27 @@ func viewIssue(c *context.Context, isPullList bool) {
 		})
 	}
 
+	if err = database.LoadCommentsReactions(issue.Comments); err != nil {
+		c.Error(err, "load comment reactions")
+		return
+	}
+
+	prepareIssueDependencies(c, issue)
+	if c.Written() {
+		return
+	}
+
+	prepareIssueTimeTracking(c, issue)
+	if c.Written() {
+		return
+	}
+
+	c.Data["LockReasons"] = database.LockReasons
 	c.Data["Participants"] = participants
 	c.Data["NumParticipants"] = len(participants)
 	c.Data["Issue"] = issue
 	c.Data["IsIssueOwner"] = c.Repo.IsWriter() || (c.IsLogged && issue.IsPoster(c.User.ID))
+	c.Data["CanComment"] = c.IsLogged && c.Repo.CanComment(issue)
 	c.Data["SignInLink"] = conf.Server.Subpath + "/user/login?redirect_to=" + c.Data["Link"].(string)
 	c.Success(tmplRepoIssueView)
 }
@@ -757,7 +845,7 @@ func UpdateIssueLabel(c *context.Context) {
 		}
 	} else {
 		isAttach := c.Query("action") == "attach"
-		label, err := database.GetLabelOfRepoByID(c.Repo.Repository.ID, c.QueryInt64("id"))
+		label, err := database.GetLabelAvailableToRepoByID(c.Repo.Repository, c.QueryInt64("id"))
 		if err != nil {
 			c.NotFoundOrError(err, "get label by ID")
 			return
@@ -808,22 +896,62 @@ func UpdateIssueMilestone(c *context.Context) {
 	})
 }
 
+// updateIssueUserIDs returns the new list of user IDs after applying the
+// action ("attach", "detach" or "clear") of the request to current users.
+func updateIssueUserIDs(c *context.Context, users []*database.User) []int64 {
+	if c.Query("action") == "clear" {
+		return nil
+	}
+
+	id := c.QueryInt64("id")
+	ids := make([]int64, 0, len(users)+1)
+	for i := range users {
+		if users[i].ID != id {
+			ids = append(ids, users[i].ID)
+		}
+	}
+	if c.Query("action") == "attach" {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
 func UpdateIssueAssignee(c *context.Context) {
 	issue := getActionIssue(c)
 	if c.Written() {
 		return
 	}
 
-	assigneeID := c.QueryInt64("id")
-	if issue.AssigneeID == assigneeID {
-		c.JSONSuccess(map[string]any{
-			"ok": true,
-		})
+	if err := issue.ChangeAssignees(c.User, updateIssueUserIDs(c, issue.Assignees)); err != nil {
+		if database.IsErrUserNotAssignable(err) {
+			c.Status(http.StatusUnprocessableEntity)
+		} else {
+			c.Error(err, "change assignees")
+		}
+		return
+	}
+
+	c.JSONSuccess(map[string]any{
+		"ok": true,
+	})
+}
+
+func UpdateIssueReviewer(c *context.Context) {
+	issue := getActionIssue(c)
+	if c.Written() {
+		return
+	}
+	if !issue.IsPull {
+		c.NotFound()
 		return
 	}
 
-	if err := issue.ChangeAssignee(c.User, assigneeID); err != nil {
-		c.Error(err, "change assignee")
+	if err := issue.ChangeRequestedReviewers(c.User, updateIssueUserIDs(c, issue.RequestedReviewers)); err != nil {
+		if database.IsErrUserNotAssignable(err) {
+			c.Status(http.StatusUnprocessableEntity)
+		} else {
+			c.Error(err, "change requested reviewers")
+		}
 		return
 	}
 
@@ -832,12 +960,63 @@ func UpdateIssueAssignee(c *context.Context) {
 	})
 }
 
+// UpdateIssueDeadline sets the due date of the issue in the form of
+// "2006-01-02", or removes the due date if the value is empty.
+func UpdateIssueDeadline(c *context.Context) {
+	issue := getActionIssue(c)
+	if c.Written() {
+		return
+	}
+
+	var deadline time.Time
+	if value := c.Query("deadline"); value != "" {
+		var err error
+		deadline, err = time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			c.Flash.Error(c.Tr("repo.issues.due_date_invalid"))
+			c.Redirect(issueLink(c, issue))
+			return
+		}
+	}
+
+	if err := issue.ChangeDeadline(c.User, deadline); err != nil {
+		c.Error(err, "change deadline")
+		return
+	}
+	c.Redirect(issueLink(c, issue))
+}
+
+// LockIssue locks the conversation of the issue with the reason of "reason",
+// or unlocks it if "action" is "unlock".
+func LockIssue(c *context.Context) {
+	issue := getActionIssue(c)
+	if c.Written() {
+		return
+	}
+
+	if err := issue.ChangeLock(c.User, c.Params(":action") == "lock", c.Query("reason")); err != nil {
+		if database.IsErrInvalidLockReason(err) {
+			c.Flash.Error(c.Tr("repo.issues.lock.invalid_reason"))
+		} else {
+			c.Error(err, "change lock")
+			return
+		}
+	}
+	c.Redirect(issueLink(c, issue))
+}
+
 func NewComment(c *context.Context, f form.CreateComment) {
 	issue := getActionIssue(c)
 	if c.Written() {
 		return
 	}
 
+	if !c.Repo.CanComment(issue) {
+		c.Flash.Error(c.Tr("repo.issues.lock.no_permission"))
+		c.Redirect(issueLink(c, issue))
+		return
+	}
+
 	var attachments []string
 	if conf.Attachment.Enabled {
 		attachments = f.Files
@@ -885,7 +1064,11 @@ func NewComment(c *context.Context, f form.CreateComment) {
 				c.Flash.Info(c.Tr("repo.pulls.open_unmerged_pull_exists", pr.Index))
 			} else {
 				if err = issue.ChangeStatus(c.User, c.Repo.Repository, f.Status == "close"); err != nil {
-					log.Error("ChangeStatus: %v", err)
+					if database.IsErrIssueBlocked(err) {
+						c.Flash.Error(c.Tr("repo.issues.dependency.close_blocked"))
+					} else {
+						log.Error("ChangeStatus: %v", err)
+					}
 				} else {
 					log.Trace("Issue [%d] status changed to closed: %v", issue.ID, issue.IsClosed)
 				}
@@ -929,13 +1112,22 @@ func UpdateCommentContent(c *context.Context) {
 		c.NotFoundOrError(err, "get comment by ID")
 		return
 	}
+	issue, err := database.GetIssueByID(comment.IssueID)
+	if err != nil {
+		c.NotFoundOrError(err, "get issue by ID")
+		return
+	}
 
-	if c.UserID() != comment.PosterID && !c.Repo.IsAdmin() {
+	if issue.RepoID != c.Repo.Repository.ID ||
+		(c.UserID() != comment.PosterID && !c.Repo.IsAdmin()) {
 		c.NotFound()
 		return
 	} else if comment.Type != database.CommentTypeComment {
 		c.Status(http.StatusNoContent)
 		return
+	} else if !c.Repo.CanComment(issue) {
+		c.Status(http.StatusForbidden)
+		return
 	}
 
 	oldContent := comment.Content
@@ -1038,7 +1230,7 @@ func NewLabel(c *context.Context, f form.CreateLabel) {
 }
 
 func UpdateLabel(c *context.Context, f form.CreateLabel) {
-	l, err := database.GetLabelByID(f.ID)
+	l, err := database.GetLabelOfRepoByID(c.Repo.Repository.ID, f.ID)
 	if err != nil {
 		c.NotFoundOrError(err, "get label by ID")
 		return
@@ -1100,6 +1292,11 @@ func Milestones(c *context.Context) {
 			m.Completeness = m.NumClosedIssues * 100 / (m.NumOpenIssues + m.NumClosedIssues)
 		}
 		m.RenderedContent = string(markup.Markdown(m.Content, c.Repo.RepoLink, c.Repo.Repository.ComposeMetas()))
+		m.TotalTrackedTime, err = database.TotalTrackedTime(database.TrackedTimeOptions{MilestoneID: m.ID})
+		if err != nil {
+			c.Error(err, "get milestone total tracked time")
+			return
+		}
 	}
 	c.Data["Milestones"] = miles
 
@@ -0,0 +1,111 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"fmt"
+
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/database"
+)
+
+// readableIssues returns issues that the current user is allowed to read.
+func readableIssues(c *context.Context, issues []*database.Issue) []*database.Issue {
+	readable := issues[:0]
+	for _, issue := range issues {
+		if c.CanReadIssue(issue) {
+			readable = append(readable, issue)
+		}
+	}
+	return readable
+}
+
+// prepareIssueDependencies sets issues that block and are blocked by the issue
+// for the issue page.
+func prepareIssueDependencies(c *context.Context, issue *database.Issue) {
+	blockedBy, err := issue.BlockedBy()
+	if err != nil {
+		c.Error(err, "get blockers")
+		return
+	}
+	blocks, err := issue.Blocks()
+	if err != nil {
+		c.Error(err, "get blocked issues")
+		return
+	}
+
+	blockedBy = readableIssues(c, blockedBy)
+	c.Data["BlockedBy"] = blockedBy
+	c.Data["Blocks"] = readableIssues(c, blocks)
+
+	for _, blocker := range blockedBy {
+		if !blocker.IsClosed {
+			c.Data["HasOpenBlockers"] = true
+			break
+		}
+	}
+}
+
+func issueLink(c *context.Context, issue *database.Issue) string {
+	typeName := "issues"
+	if issue.IsPull {
+		typeName = "pulls"
+	}
+	return fmt.Sprintf("%s/%s/%d", c.Repo.RepoLink, typeName, issue.Index)
+}
+
+// AddIssueDependency makes the issue blocked by the issue referenced by
+// "#index" or "owner/repo#index".
+func AddIssueDependency(c *context.Context) {
+	issue := getActionIssue(c)
+	if c.Written() {
+		return
+	}
+
+	dependency, err := database.GetIssueByRelativeRef(c.Repo.Repository, c.Query("dependency"))
+	if err != nil && !database.IsErrIssueNotExist(err) {
+		c.Error(err, "get issue by reference")
+		return
+	} else if err != nil || !c.CanReadIssue(dependency) {
+		c.Flash.Error(c.Tr("repo.issues.dependency.not_exist"))
+		c.Redirect(issueLink(c, issue))
+		return
+	}
+
+	err = database.AddIssueDependency(c.User, issue, dependency)
+	if err != nil {
+		switch {
+		case database.IsErrIssueDependencyExist(err):
+			c.Flash.Error(c.Tr("repo.issues.dependency.exist"))
+		case database.IsErrIssueDependencyCircular(err):
+			c.Flash.Error(c.Tr("repo.issues.dependency.circular"))
+		default:
+			c.Error(err, "add issue dependency")
+			return
+		}
+	}
+	c.Redirect(issueLink(c, issue))
+}
+
+// RemoveIssueDependency removes the issue of given ID from blockers of the
+// issue.
+func RemoveIssueDependency(c *context.Context) {
+	issue := getActionIssue(c)
+	if c.Written() {
+		return
+	}
+
+	dependency, err := database.GetIssueByID(c.QueryInt64("id"))
+	if err != nil {
+		c.NotFoundOrError(err, "get issue by ID")
+		return
+	}
+
+	if err = database.RemoveIssueDependency(issue, dependency); err != nil {
+		c.NotFoundOrError(err, "remove issue dependency")
+		return
+	}
+	c.Redirect(issueLink(c, issue))
+}
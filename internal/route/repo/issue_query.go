@@ -0,0 +1,49 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/database"
+)
+
+// ParseIssueQuery parses the issue search query of the request and returns the
+// filter for listing issues. The state and sort type of the query, if any,
+// take precedence over given ones.
+func ParseIssueQuery(c *context.Context, isShowClosed *bool, sortType *string) *database.IssueFilter {
+	q := database.ParseIssueQuery(c.Query("q"))
+	if q.State != "" {
+		*isShowClosed = q.State == "closed"
+	}
+	if q.Sort != "" {
+		*sortType = q.Sort
+	}
+
+	filter, err := q.Filter(c.Req.Context(), c.User)
+	if err != nil {
+		c.Error(err, "get issue filter")
+		return nil
+	}
+
+	// The state and sort type are reflected by status buttons and the sort menu
+	// instead of the search input, but a saved search should have them all.
+	q.State, q.Sort = "", ""
+	c.Data["Query"] = q.String()
+	q.State = "open"
+	if *isShowClosed {
+		q.State = "closed"
+	}
+	q.Sort = *sortType
+	c.Data["FullQuery"] = q.String()
+
+	if c.IsLogged {
+		c.Data["SavedSearches"], err = database.GetSavedSearchesByUserID(c.User.ID)
+		if err != nil {
+			c.Error(err, "get saved searches by user ID")
+			return nil
+		}
+	}
+	return filter
+}
@@ -0,0 +1,109 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"time"
+
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/database"
+)
+
+func prepareIssueTimeTracking(c *context.Context, issue *database.Issue) {
+	totals, err := database.GetIssueTrackedTimeTotals(issue.ID)
+	if err != nil {
+		c.Error(err, "get issue tracked time totals")
+		return
+	}
+	c.Data["TrackedTimeTotals"] = totals
+
+	var total int64
+	for i := range totals {
+		total += totals[i].Time
+	}
+	c.Data["TotalTrackedTime"] = total
+
+	if !c.IsLogged || !c.Repo.IsWriter() {
+		return
+	}
+	stopwatch, err := database.GetStopwatch(c.User.ID, issue.ID)
+	if err != nil {
+		if !database.IsErrStopwatchNotExist(err) {
+			c.Error(err, "get stopwatch")
+		}
+		return
+	}
+	c.Data["Stopwatch"] = stopwatch
+}
+
+func StartIssueStopwatch(c *context.Context) {
+	issue := getActionIssue(c)
+	if c.Written() {
+		return
+	}
+
+	if err := database.StartStopwatch(c.User, issue); err != nil {
+		c.Error(err, "start stopwatch")
+		return
+	}
+	c.Redirect(issueLink(c, issue))
+}
+
+func StopIssueStopwatch(c *context.Context) {
+	issue := getActionIssue(c)
+	if c.Written() {
+		return
+	}
+
+	if _, err := database.StopStopwatch(c.User, issue); err != nil && !database.IsErrStopwatchNotExist(err) {
+		c.Error(err, "stop stopwatch")
+		return
+	}
+	c.Redirect(issueLink(c, issue))
+}
+
+func CancelIssueStopwatch(c *context.Context) {
+	issue := getActionIssue(c)
+	if c.Written() {
+		return
+	}
+
+	if err := database.CancelStopwatch(c.User, issue); err != nil {
+		c.Error(err, "cancel stopwatch")
+		return
+	}
+	c.Redirect(issueLink(c, issue))
+}
+
+// AddIssueTrackedTime records time spent on the issue entered by hours and
+// minutes, optionally dated by "date".
+func AddIssueTrackedTime(c *context.Context) {
+	issue := getActionIssue(c)
+	if c.Written() {
+		return
+	}
+
+	var created time.Time
+	if value := c.Query("date"); value != "" {
+		var err error
+		created, err = time.ParseInLocation("2006-01-02", value, time.Local)
+		if err != nil {
+			c.Flash.Error(c.Tr("repo.issues.tracking.invalid_date"))
+			c.Redirect(issueLink(c, issue))
+			return
+		}
+	}
+
+	d := time.Duration(c.QueryInt64("hours"))*time.Hour + time.Duration(c.QueryInt64("minutes"))*time.Minute
+	if _, err := database.AddTrackedTime(c.User, issue, d, created); err != nil {
+		if database.IsErrInvalidTrackedTime(err) {
+			c.Flash.Error(c.Tr("repo.issues.tracking.invalid_time"))
+		} else {
+			c.Error(err, "add tracked time")
+			return
+		}
+	}
+	c.Redirect(issueLink(c, issue))
+}
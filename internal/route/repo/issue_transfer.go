@@ -0,0 +1,71 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"fmt"
+	"strings"
+
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/database"
+	dberrors "gogs.io/gogs/internal/database/errors"
+	"gogs.io/gogs/internal/errutil"
+)
+
+// redirectTransferredIssue redirects to the new location of the issue that was
+// transferred out of the current repository with the index, and returns false
+// if there is no such issue the current user can read.
+func redirectTransferredIssue(c *context.Context, index int64) bool {
+	issue, err := c.GetTransferredIssue(index)
+	if err != nil {
+		if !database.IsErrIssueNotExist(err) {
+			c.Error(err, "get transferred issue")
+			return true
+		}
+		return false
+	}
+
+	c.Redirect(fmt.Sprintf("%s/issues/%d", issue.Repo.Link(), issue.Index))
+	return true
+}
+
+// TransferIssue moves the issue to the repository referenced by "owner/repo",
+// the current user must be a writer of both repositories.
+func TransferIssue(c *context.Context) {
+	issue := getActionIssue(c)
+	if c.Written() {
+		return
+	}
+
+	target, err := database.GetRepositoryByRef(strings.TrimSpace(c.Query("repo")))
+	if err != nil && !errutil.IsNotFound(err) && !dberrors.IsInvalidRepoReference(err) {
+		c.Error(err, "get repository by reference")
+		return
+	} else if err != nil || target.ID == issue.RepoID ||
+		!target.EnableIssues || target.EnableExternalTracker ||
+		!database.Handle.Permissions().Authorize(c.Req.Context(), c.User.ID, target.ID, database.AccessModeWrite,
+			database.AccessModeOptions{
+				OwnerID: target.OwnerID,
+				Private: target.IsPrivate,
+			},
+		) {
+		c.Flash.Error(c.Tr("repo.issues.transfer.invalid_repo"))
+		c.Redirect(issueLink(c, issue))
+		return
+	}
+
+	if err = database.TransferIssue(c.User, issue, target); err != nil {
+		if database.IsErrInvalidIssueTransfer(err) {
+			c.Flash.Error(c.Tr("repo.issues.transfer.invalid_repo"))
+			c.Redirect(issueLink(c, issue))
+		} else {
+			c.Error(err, "transfer issue")
+		}
+		return
+	}
+
+	c.Flash.Success(c.Tr("repo.issues.transfer.success", target.FullName()))
+	c.Redirect(fmt.Sprintf("%s/issues/%d", target.Link(), issue.Index))
+}
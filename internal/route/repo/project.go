@@ -0,0 +1,453 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/database"
+	"gogs.io/gogs/internal/form"
+	"gogs.io/gogs/internal/markup"
+)
+
+// Project pages are shared by repositories and organizations, the current
+// repository decides which one the request is about. Route middleware restricts
+// project management to repository writers or organization owners, card
+// handlers check projectScope.CanEdit themselves.
+const (
+	tmplRepoProjectList = "repo/project/list"
+	tmplRepoProjectNew  = "repo/project/new"
+	tmplRepoProjectView = "repo/project/view"
+)
+
+// projectScope is the owner of projects that the request is about.
+type projectScope struct {
+	OwnerID int64
+	RepoID  int64
+	// Link is the link of the list of projects.
+	Link string
+	// CanEdit indicates whether the current user can add, move and remove
+	// cards.
+	CanEdit bool
+	// CanManage indicates whether the current user can create, edit and
+	// delete projects and their columns.
+	CanManage bool
+}
+
+func getProjectScope(c *context.Context) projectScope {
+	if c.Repo.Repository != nil {
+		return projectScope{
+			RepoID:    c.Repo.Repository.ID,
+			Link:      c.Repo.RepoLink + "/projects",
+			CanEdit:   c.Repo.IsWriter(),
+			CanManage: c.Repo.IsWriter(),
+		}
+	}
+	return projectScope{
+		OwnerID:   c.Org.Organization.ID,
+		Link:      c.Org.OrgLink + "/projects",
+		CanEdit:   c.Org.IsMember,
+		CanManage: c.Org.IsOwner,
+	}
+}
+
+func prepareProjectScope(c *context.Context) projectScope {
+	scope := getProjectScope(c)
+	c.Data["PageIsProjects"] = true
+	if scope.RepoID == 0 {
+		c.Data["PageIsOrgProjects"] = true
+	}
+	c.Data["ProjectsLink"] = scope.Link
+	c.Data["CanEditProject"] = scope.CanEdit
+	c.Data["CanManageProject"] = scope.CanManage
+	return scope
+}
+
+// getProject returns the project of the request that belongs to the scope.
+func getProject(c *context.Context, scope projectScope) *database.Project {
+	p, err := database.GetProjectByID(c.ParamsInt64(":id"))
+	if err != nil {
+		c.NotFoundOrError(err, "get project by ID")
+		return nil
+	} else if p.OwnerID != scope.OwnerID || p.RepoID != scope.RepoID {
+		c.NotFound()
+		return nil
+	}
+	c.Data["Project"] = p
+	return p
+}
+
+func projectLink(scope projectScope, p *database.Project) string {
+	return fmt.Sprintf("%s/%d", scope.Link, p.ID)
+}
+
+func Projects(c *context.Context) {
+	c.Data["Title"] = c.Tr("repo.projects")
+	scope := prepareProjectScope(c)
+
+	isShowClosed := c.Query("state") == "closed"
+	c.Data["IsShowClosed"] = isShowClosed
+	c.Data["OpenCount"] = database.CountProjects(scope.OwnerID, scope.RepoID, false)
+	c.Data["ClosedCount"] = database.CountProjects(scope.OwnerID, scope.RepoID, true)
+
+	projects, err := database.GetProjects(scope.OwnerID, scope.RepoID, isShowClosed)
+	if err != nil {
+		c.Error(err, "get projects")
+		return
+	}
+	c.Data["Projects"] = projects
+
+	c.Success(tmplRepoProjectList)
+}
+
+func NewProject(c *context.Context) {
+	c.Data["Title"] = c.Tr("repo.projects.new")
+	prepareProjectScope(c)
+	c.Success(tmplRepoProjectNew)
+}
+
+func NewProjectPost(c *context.Context, f form.CreateProject) {
+	c.Data["Title"] = c.Tr("repo.projects.new")
+	scope := prepareProjectScope(c)
+
+	if c.HasError() {
+		c.Success(tmplRepoProjectNew)
+		return
+	}
+
+	p := &database.Project{
+		OwnerID:     scope.OwnerID,
+		RepoID:      scope.RepoID,
+		Title:       f.Title,
+		Description: f.Description,
+		CreatorID:   c.User.ID,
+	}
+	if err := database.NewProject(p); err != nil {
+		c.Error(err, "new project")
+		return
+	}
+
+	c.Flash.Success(c.Tr("repo.projects.create_success", p.Title))
+	c.Redirect(projectLink(scope, p))
+}
+
+func EditProject(c *context.Context) {
+	c.Data["Title"] = c.Tr("repo.projects.edit")
+	c.Data["PageIsEditProject"] = true
+	scope := prepareProjectScope(c)
+
+	p := getProject(c, scope)
+	if c.Written() {
+		return
+	}
+	c.Data["title"] = p.Title
+	c.Data["description"] = p.Description
+
+	c.Success(tmplRepoProjectNew)
+}
+
+func EditProjectPost(c *context.Context, f form.CreateProject) {
+	c.Data["Title"] = c.Tr("repo.projects.edit")
+	c.Data["PageIsEditProject"] = true
+	scope := prepareProjectScope(c)
+
+	p := getProject(c, scope)
+	if c.Written() {
+		return
+	}
+
+	if c.HasError() {
+		c.Success(tmplRepoProjectNew)
+		return
+	}
+
+	p.Title = f.Title
+	p.Description = f.Description
+	if err := database.UpdateProject(p); err != nil {
+		c.Error(err, "update project")
+		return
+	}
+
+	c.Flash.Success(c.Tr("repo.projects.edit_success", p.Title))
+	c.Redirect(projectLink(scope, p))
+}
+
+func ChangeProjectStatus(c *context.Context) {
+	scope := getProjectScope(c)
+	p := getProject(c, scope)
+	if c.Written() {
+		return
+	}
+
+	p.IsClosed = c.Params(":action") == "close"
+	if err := database.UpdateProject(p); err != nil {
+		c.Error(err, "update project")
+		return
+	}
+
+	if p.IsClosed {
+		c.Redirect(scope.Link + "?state=closed")
+	} else {
+		c.Redirect(scope.Link)
+	}
+}
+
+func DeleteProject(c *context.Context) {
+	scope := getProjectScope(c)
+	p := getProject(c, scope)
+	if c.Written() {
+		return
+	}
+
+	if err := database.DeleteProject(p.ID); err != nil {
+		c.Error(err, "delete project")
+		return
+	}
+
+	c.Flash.Success(c.Tr("repo.projects.deletion_success"))
+	c.Redirect(scope.Link)
+}
+
+// ViewProject shows the board of the project. Cards of issues that the current
+// user cannot read are hidden.
+func ViewProject(c *context.Context) {
+	scope := prepareProjectScope(c)
+	p := getProject(c, scope)
+	if c.Written() {
+		return
+	}
+	c.Data["Title"] = p.Title
+
+	if err := p.LoadColumns(); err != nil {
+		c.Error(err, "load project columns")
+		return
+	}
+	for _, col := range p.Columns {
+		cards := col.Cards[:0]
+		for _, card := range col.Cards {
+			if c.CanReadIssue(card.Issue) {
+				cards = append(cards, card)
+			}
+		}
+		col.Cards = cards
+	}
+
+	metas := map[string]string{}
+	if c.Repo.Repository != nil {
+		metas = c.Repo.Repository.ComposeMetas()
+	}
+	c.Data["RenderedDescription"] = string(markup.Markdown(p.Description, scope.Link, metas))
+
+	c.Success(tmplRepoProjectView)
+}
+
+// getProjectColumn returns the column of the project by given ID.
+func getProjectColumn(c *context.Context, p *database.Project, id int64) *database.ProjectColumn {
+	col, err := database.GetProjectColumn(p.ID, id)
+	if err != nil {
+		c.NotFoundOrError(err, "get project column")
+		return nil
+	}
+	return col
+}
+
+func NewProjectColumn(c *context.Context) {
+	scope := getProjectScope(c)
+	p := getProject(c, scope)
+	if c.Written() {
+		return
+	}
+
+	title := strings.TrimSpace(c.Query("title"))
+	if title == "" {
+		c.Flash.Error(c.Tr("repo.projects.column.title_required"))
+		c.Redirect(projectLink(scope, p))
+		return
+	}
+
+	if _, err := database.NewProjectColumn(p.ID, title); err != nil {
+		c.Error(err, "new project column")
+		return
+	}
+	c.Redirect(projectLink(scope, p))
+}
+
+func EditProjectColumn(c *context.Context) {
+	scope := getProjectScope(c)
+	p := getProject(c, scope)
+	if c.Written() {
+		return
+	}
+	col := getProjectColumn(c, p, c.ParamsInt64(":column"))
+	if c.Written() {
+		return
+	}
+
+	title := strings.TrimSpace(c.Query("title"))
+	if title == "" {
+		c.Flash.Error(c.Tr("repo.projects.column.title_required"))
+		c.Redirect(projectLink(scope, p))
+		return
+	}
+
+	col.Title = title
+	if err := database.UpdateProjectColumn(col); err != nil {
+		c.Error(err, "update project column")
+		return
+	}
+	c.Redirect(projectLink(scope, p))
+}
+
+// MoveProjectColumn moves the column to the zero-based "position".
+func MoveProjectColumn(c *context.Context) {
+	scope := getProjectScope(c)
+	p := getProject(c, scope)
+	if c.Written() {
+		return
+	}
+	col := getProjectColumn(c, p, c.ParamsInt64(":column"))
+	if c.Written() {
+		return
+	}
+
+	if err := database.MoveProjectColumn(col, c.QueryInt("position")); err != nil {
+		c.Error(err, "move project column")
+		return
+	}
+	c.Redirect(projectLink(scope, p))
+}
+
+func DeleteProjectColumn(c *context.Context) {
+	scope := getProjectScope(c)
+	p := getProject(c, scope)
+	if c.Written() {
+		return
+	}
+	col := getProjectColumn(c, p, c.ParamsInt64(":column"))
+	if c.Written() {
+		return
+	}
+
+	if err := database.DeleteProjectColumn(col); err != nil {
+		if !database.IsErrProjectColumnLast(err) {
+			c.Error(err, "delete project column")
+			return
+		}
+		c.Flash.Error(c.Tr("repo.projects.column.delete_last"))
+	}
+	c.Redirect(projectLink(scope, p))
+}
+
+// getProjectIssue returns the issue referenced by "#index" or
+// "owner/repo#index" that is allowed to be placed in projects of the scope.
+func getProjectIssue(c *context.Context, scope projectScope, ref string) (*database.Issue, error) {
+	if scope.RepoID == 0 && strings.HasPrefix(strings.TrimSpace(ref), "#") {
+		return nil, database.ErrIssueNotExist{}
+	}
+
+	issue, err := database.GetIssueByRelativeRef(c.Repo.Repository, ref)
+	if err != nil {
+		return nil, err
+	}
+	if (scope.RepoID > 0 && issue.RepoID != scope.RepoID) ||
+		(scope.OwnerID > 0 && issue.Repo.OwnerID != scope.OwnerID) ||
+		!c.CanReadIssue(issue) {
+		return nil, database.ErrIssueNotExist{}
+	}
+	return issue, nil
+}
+
+// AddProjectCard adds the issue referenced by "issue" to the end of the
+// column.
+func AddProjectCard(c *context.Context) {
+	scope := getProjectScope(c)
+	if !scope.CanEdit {
+		c.NotFound()
+		return
+	}
+	p := getProject(c, scope)
+	if c.Written() {
+		return
+	}
+	col := getProjectColumn(c, p, c.QueryInt64("column"))
+	if c.Written() {
+		return
+	}
+
+	issue, err := getProjectIssue(c, scope, c.Query("issue"))
+	if err != nil {
+		if !database.IsErrIssueNotExist(err) {
+			c.Error(err, "get issue by reference")
+			return
+		}
+		c.Flash.Error(c.Tr("repo.projects.card.issue_not_exist"))
+		c.Redirect(projectLink(scope, p))
+		return
+	}
+
+	if _, err = database.AddProjectCard(c.User, col, issue); err != nil {
+		if !database.IsErrProjectCardExist(err) {
+			c.Error(err, "add project card")
+			return
+		}
+		c.Flash.Error(c.Tr("repo.projects.card.exist"))
+	}
+	c.Redirect(projectLink(scope, p))
+}
+
+// MoveProjectCard moves the card to the zero-based "position" of the
+// "column", it is requested by dragging cards on the board.
+func MoveProjectCard(c *context.Context) {
+	scope := getProjectScope(c)
+	if !scope.CanEdit {
+		c.NotFound()
+		return
+	}
+	p := getProject(c, scope)
+	if c.Written() {
+		return
+	}
+	card, err := database.GetProjectCard(p.ID, c.ParamsInt64(":card"))
+	if err != nil {
+		c.NotFoundOrError(err, "get project card")
+		return
+	}
+	col := getProjectColumn(c, p, c.QueryInt64("column"))
+	if c.Written() {
+		return
+	}
+
+	if err = database.MoveProjectCard(card, col, c.QueryInt("position")); err != nil {
+		c.Error(err, "move project card")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func DeleteProjectCard(c *context.Context) {
+	scope := getProjectScope(c)
+	if !scope.CanEdit {
+		c.NotFound()
+		return
+	}
+	p := getProject(c, scope)
+	if c.Written() {
+		return
+	}
+	card, err := database.GetProjectCard(p.ID, c.ParamsInt64(":card"))
+	if err != nil {
+		c.NotFoundOrError(err, "get project card")
+		return
+	}
+
+	if err = database.DeleteProjectCard(card); err != nil {
+		c.Error(err, "delete project card")
+		return
+	}
+	c.Redirect(projectLink(scope, p))
+}
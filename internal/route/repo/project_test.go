@@ -0,0 +1,66 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/macaron.v1"
+
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/database"
+	"gogs.io/gogs/internal/mocks"
+)
+
+func TestProjectCards_NonMember(t *testing.T) {
+	tmplDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(tmplDir, "status"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(tmplDir, "status", "404.tmpl"), []byte("not found"), 0o644))
+
+	m := macaron.New()
+	m.Use(macaron.Renderer(macaron.RenderOptions{Directory: tmplDir}))
+	m.Use(func(mc *macaron.Context) {
+		mc.Locale = &mocks.Locale{
+			MockLang: "en",
+			MockTr: func(s string, _ ...any) string {
+				return s
+			},
+		}
+		mc.Map(&context.Context{
+			Context:  mc,
+			User:     &database.User{ID: 2, Name: "stranger"},
+			IsLogged: true,
+			Repo:     &context.Repository{},
+			Org: &context.Organization{
+				Organization: &database.User{ID: 1, Name: "org"},
+				IsMember:     false,
+			},
+		})
+	})
+	m.Post("/org/:org/projects/:id/cards", AddProjectCard)
+	m.Post("/org/:org/projects/:id/cards/:card/move", MoveProjectCard)
+	m.Post("/org/:org/projects/:id/cards/:card/delete", DeleteProjectCard)
+
+	for _, path := range []string{
+		"/org/org/projects/1/cards?column=1&issue=owner/repo%231",
+		"/org/org/projects/1/cards/1/move?column=1&position=0",
+		"/org/org/projects/1/cards/1/delete",
+	} {
+		t.Run(path, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodPost, path, nil)
+			require.NoError(t, err)
+
+			m.ServeHTTP(resp, req)
+			assert.Equal(t, http.StatusNotFound, resp.Code)
+		})
+	}
+}
@@ -6,6 +6,7 @@ package repo
 
 import (
 	"net/http"
+	"os"
 	"path"
 	"strings"
 	"time"
@@ -32,20 +33,6 @@ const (
 	PullRequestTitleTemplateKey = "PullRequestTitleTemplate"
 )
 
-var (
-	PullRequestTemplateCandidates = []string{
-		"PULL_REQUEST.md",
-		".gogs/PULL_REQUEST.md",
-		".github/PULL_REQUEST.md",
-	}
-
-	PullRequestTitleTemplateCandidates = []string{
-		"PULL_REQUEST_TITLE.md",
-		".gogs/PULL_REQUEST_TITLE.md",
-		".github/PULL_REQUEST_TITLE.md",
-	}
-)
-
 func parseBaseRepository(c *context.Context) *database.Repository {
 	baseRepo, err := database.GetRepositoryByID(c.ParamsInt64(":repoid"))
 	if err != nil {
@@ -247,6 +234,53 @@ func PrepareViewPullInfo(c *context.Context, issue *database.Issue) *gitutil.Pul
 	}
 	c.Data["NumCommits"] = len(prMeta.Commits)
 	c.Data["NumFiles"] = prMeta.NumFiles
+
+	statuses, err := pull.LatestCommitStatuses()
+	if err != nil {
+		c.Error(err, "get latest commit statuses")
+		return nil
+	}
+	c.Data["CommitStatuses"] = statuses
+	if len(statuses) > 0 {
+		c.Data["CombinedCommitStatus"] = database.CombinedCommitStatusState(statuses)
+	}
+
+	c.Data["IsStatusChecksPassed"], err = pull.IsStatusChecksPassed()
+	if err != nil {
+		c.Error(err, "check status checks")
+		return nil
+	}
+
+	approvers, err := pull.Approvers()
+	if err != nil {
+		c.Error(err, "get approvers")
+		return nil
+	}
+	c.Data["Approvers"] = approvers
+	c.Data["RequiredApprovals"], err = pull.RequiredApprovals()
+	if err != nil {
+		c.Error(err, "get required approvals")
+		return nil
+	}
+	c.Data["IsApprovalsSatisfied"], err = pull.IsApprovalsSatisfied()
+	if err != nil {
+		c.Error(err, "check approvals")
+		return nil
+	}
+	if c.IsLogged {
+		c.Data["CanApprove"] = c.Repo.IsWriter() && !issue.IsPoster(c.User.ID)
+		for i := range approvers {
+			if approvers[i].ID == c.User.ID {
+				c.Data["HasApproved"] = true
+				break
+			}
+		}
+	}
+
+	if err = pull.LoadAutoMergeDoer(); err != nil {
+		c.Error(err, "load auto-merge doer")
+		return nil
+	}
 	return prMeta
 }
 
@@ -402,6 +436,30 @@ func ViewPullFiles(c *context.Context) {
 	c.Success(tmplRepoPullsFiles)
 }
 
+// RawPullDiff serves the stored patch of the pull request for the "diff"
+// extension, or its commits in the mailbox format for the "patch" extension.
+func RawPullDiff(c *context.Context) {
+	issue, err := database.GetIssueByIndex(c.Repo.Repository.ID, c.ParamsInt64(":index"))
+	if err != nil {
+		c.NotFoundOrError(err, "get issue by index")
+		return
+	} else if !issue.IsPull || issue.PullRequest == nil {
+		c.NotFound()
+		return
+	}
+
+	diff, err := issue.PullRequest.RawDiff(git.RawDiffFormat(c.Params(":ext")))
+	if err != nil {
+		if os.IsNotExist(err) {
+			c.NotFound()
+		} else {
+			c.Error(err, "get raw diff")
+		}
+		return
+	}
+	ServeRawDiff(c, diff)
+}
+
 func MergePullRequest(c *context.Context) {
 	issue := checkPullInfo(c)
 	if c.Written() {
@@ -418,11 +476,41 @@ func MergePullRequest(c *context.Context) {
 		return
 	}
 
-	if !pr.CanAutoMerge() || pr.HasMerged {
+	if !pr.CanAutoMerge() || pr.HasMerged || pr.IsDraft {
 		c.NotFound()
 		return
 	}
 
+	passed, err := pr.IsStatusChecksPassed()
+	if err != nil {
+		c.Error(err, "check status checks")
+		return
+	} else if !passed {
+		c.Flash.Error(c.Tr("repo.pulls.status_checks_required"))
+		c.Redirect(c.Repo.RepoLink + "/pulls/" + com.ToStr(pr.Index))
+		return
+	}
+
+	approved, err := pr.IsApprovalsSatisfied()
+	if err != nil {
+		c.Error(err, "check approvals")
+		return
+	} else if !approved {
+		approvers, err := pr.Approvers()
+		if err != nil {
+			c.Error(err, "get approvers")
+			return
+		}
+		required, err := pr.RequiredApprovals()
+		if err != nil {
+			c.Error(err, "get required approvals")
+			return
+		}
+		c.Flash.Error(c.Tr("repo.pulls.approvals_required", len(approvers), required))
+		c.Redirect(c.Repo.RepoLink + "/pulls/" + com.ToStr(pr.Index))
+		return
+	}
+
 	pr.Issue = issue
 	pr.Issue.Repo = c.Repo.Repository
 	if err = pr.Merge(c.User, c.Repo.GitRepo, database.MergeStyle(c.Query("merge_style")), c.Query("commit_description")); err != nil {
@@ -434,6 +522,120 @@ func MergePullRequest(c *context.Context) {
 	c.Redirect(c.Repo.RepoLink + "/pulls/" + com.ToStr(pr.Index))
 }
 
+func ScheduleAutoMerge(c *context.Context) {
+	issue := checkPullInfo(c)
+	if c.Written() {
+		return
+	}
+	pr := issue.PullRequest
+	if issue.IsClosed || pr.HasMerged || pr.IsDraft {
+		c.NotFound()
+		return
+	}
+
+	if err := pr.ScheduleAutoMerge(c.User, database.MergeStyle(c.Query("merge_style")), c.Query("commit_description")); err != nil {
+		c.Error(err, "schedule auto-merge")
+		return
+	}
+
+	log.Trace("Pull request auto-merge scheduled: %d", pr.ID)
+	c.Redirect(c.Repo.RepoLink + "/pulls/" + com.ToStr(pr.Index))
+}
+
+func CancelAutoMerge(c *context.Context) {
+	issue := checkPullInfo(c)
+	if c.Written() {
+		return
+	}
+	pr := issue.PullRequest
+	if !pr.IsAutoMergeScheduled() {
+		c.NotFound()
+		return
+	}
+
+	if err := pr.CancelAutoMerge(); err != nil {
+		c.Error(err, "cancel auto-merge")
+		return
+	}
+
+	log.Trace("Pull request auto-merge cancelled: %d", pr.ID)
+	c.Redirect(c.Repo.RepoLink + "/pulls/" + com.ToStr(pr.Index))
+}
+
+func ApprovePullRequest(c *context.Context) {
+	issue := checkPullInfo(c)
+	if c.Written() {
+		return
+	}
+	if issue.IsClosed || issue.PullRequest.HasMerged {
+		c.NotFound()
+		return
+	}
+	if issue.IsPoster(c.User.ID) {
+		c.Status(http.StatusForbidden)
+		return
+	}
+
+	pr := issue.PullRequest
+	pr.Issue = issue
+	if err := pr.SetApproval(c.User, c.Query("action") != "dismiss"); err != nil {
+		c.Error(err, "set approval")
+		return
+	}
+
+	c.Redirect(c.Repo.RepoLink + "/pulls/" + com.ToStr(pr.Index))
+}
+
+func MarkPullRequestReady(c *context.Context) {
+	issue := checkPullInfo(c)
+	if c.Written() {
+		return
+	}
+	if issue.IsClosed || issue.PullRequest.HasMerged {
+		c.NotFound()
+		return
+	}
+	if !c.Repo.IsWriter() && !issue.IsPoster(c.User.ID) {
+		c.Status(http.StatusForbidden)
+		return
+	}
+
+	pr := issue.PullRequest
+	pr.Issue = issue
+	if err := pr.MarkReadyForReview(c.User); err != nil {
+		c.Error(err, "mark ready for review")
+		return
+	}
+
+	log.Trace("Pull request marked as ready for review: %d", pr.ID)
+	c.Redirect(c.Repo.RepoLink + "/pulls/" + com.ToStr(pr.Index))
+}
+
+func MarkPullRequestDraft(c *context.Context) {
+	issue := checkPullInfo(c)
+	if c.Written() {
+		return
+	}
+	if issue.IsClosed || issue.PullRequest.HasMerged {
+		c.NotFound()
+		return
+	}
+	if !c.Repo.IsWriter() && !issue.IsPoster(c.User.ID) {
+		c.Status(http.StatusForbidden)
+		return
+	}
+
+	pr := issue.PullRequest
+	pr.Issue = issue
+	if err := pr.MarkAsDraft(c.User); err != nil {
+		c.Error(err, "mark as draft")
+		return
+	}
+
+	log.Trace("Pull request converted to draft: %d", pr.ID)
+	c.Redirect(c.Repo.RepoLink + "/pulls/" + com.ToStr(pr.Index))
+}
+
 func ParseCompareInfo(c *context.Context) (*database.User, *database.Repository, *git.Repository, *gitutil.PullRequestMeta, string, string) {
 	baseRepo := c.Repo.Repository
 
@@ -626,9 +828,16 @@ func CompareAndPullRequest(c *context.Context) {
 	c.Data["PageIsComparePull"] = true
 	c.Data["IsDiffCompare"] = true
 	c.Data["RequireHighlightJS"] = true
-	setTemplateIfExists(c, PullRequestTemplateKey, PullRequestTemplateCandidates)
 	renderAttachmentSettings(c)
 
+	content, found, err := c.Repo.Repository.GetPullRequestTemplate()
+	if err != nil {
+		c.Error(err, "get pull request template")
+		return
+	} else if found {
+		c.Data[PullRequestTemplateKey] = content
+	}
+
 	headUser, headRepo, headGitRepo, prInfo, baseBranch, headBranch := ParseCompareInfo(c)
 	if c.Written() {
 		return
@@ -666,10 +875,13 @@ func CompareAndPullRequest(c *context.Context) {
 	}
 
 	c.Data["IsSplitStyle"] = c.Query("style") == "split"
-	setTemplateIfExists(c, PullRequestTitleTemplateKey, PullRequestTitleTemplateCandidates)
 
-	if c.Data[PullRequestTitleTemplateKey] != nil {
-		customTitle := c.Data[PullRequestTitleTemplateKey].(string)
+	customTitle, found, err := c.Repo.Repository.GetPullRequestTitleTemplate()
+	if err != nil {
+		c.Error(err, "get pull request title template")
+		return
+	} else if found {
+		c.Data[PullRequestTitleTemplateKey] = customTitle
 		r := strings.NewReplacer("{{headBranch}}", headBranch, "{{baseBranch}}", baseBranch)
 		c.Data["title"] = r.Replace(customTitle)
 	}
@@ -694,7 +906,7 @@ func CompareAndPullRequestPost(c *context.Context, f form.NewIssue) {
 		return
 	}
 
-	labelIDs, milestoneID, assigneeID := ValidateRepoMetas(c, f)
+	labelIDs, milestoneID, assigneeIDs, reviewerIDs := ValidateRepoMetas(c, f)
 	if c.Written() {
 		return
 	}
@@ -730,7 +942,6 @@ func CompareAndPullRequestPost(c *context.Context, f form.NewIssue) {
 		PosterID:    c.User.ID,
 		Poster:      c.User,
 		MilestoneID: milestoneID,
-		AssigneeID:  assigneeID,
 		IsPull:      true,
 		Content:     f.Content,
 	}
@@ -744,10 +955,18 @@ func CompareAndPullRequestPost(c *context.Context, f form.NewIssue) {
 		BaseRepo:     repo,
 		MergeBase:    meta.MergeBase,
 		Type:         database.PullRequestTypeGogs,
+		IsDraft:      f.IsDraft,
 	}
 	// FIXME: check error in the case two people send pull request at almost same time, give nice error prompt
 	// instead of 500.
-	if err := database.NewPullRequest(repo, pullIssue, labelIDs, attachments, pullRequest, patch); err != nil {
+	if err := database.NewPullRequest(database.NewIssueOptions{
+		Repo:        repo,
+		Issue:       pullIssue,
+		LableIDs:    labelIDs,
+		AssigneeIDs: assigneeIDs,
+		ReviewerIDs: reviewerIDs,
+		Attachments: attachments,
+	}, pullRequest, patch); err != nil {
 		c.Error(err, "new pull request")
 		return
 	} else if err := pullRequest.PushToBaseRepo(); err != nil {
@@ -0,0 +1,64 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"net/http"
+
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/database"
+)
+
+// toggleReaction toggles the reaction of the current user to the issue, or to
+// the comment if commentID is not zero, and redirects to given link.
+func toggleReaction(c *context.Context, issue *database.Issue, commentID int64, redirectTo string) {
+	if !c.Repo.CanComment(issue) {
+		c.Flash.Error(c.Tr("repo.issues.lock.no_permission"))
+		c.Redirect(redirectTo)
+		return
+	}
+
+	_, err := database.ToggleReaction(c.User, issue.ID, commentID, c.Query("type"))
+	if err != nil {
+		if database.IsErrInvalidReactionType(err) {
+			c.Status(http.StatusUnprocessableEntity)
+		} else {
+			c.Error(err, "toggle reaction")
+		}
+		return
+	}
+	c.Redirect(redirectTo)
+}
+
+// ToggleIssueReaction toggles the reaction of the current user to the issue.
+func ToggleIssueReaction(c *context.Context) {
+	issue := getActionIssue(c)
+	if c.Written() {
+		return
+	}
+
+	toggleReaction(c, issue, 0, issueLink(c, issue))
+}
+
+// ToggleCommentReaction toggles the reaction of the current user to the
+// comment.
+func ToggleCommentReaction(c *context.Context) {
+	comment, err := database.GetCommentByID(c.ParamsInt64(":id"))
+	if err != nil {
+		c.NotFoundOrError(err, "get comment by ID")
+		return
+	}
+
+	issue, err := database.GetIssueByID(comment.IssueID)
+	if err != nil {
+		c.NotFoundOrError(err, "get issue by ID")
+		return
+	} else if issue.RepoID != c.Repo.Repository.ID || comment.Type != database.CommentTypeComment {
+		c.NotFound()
+		return
+	}
+
+	toggleReaction(c, issue, comment.ID, issueLink(c, issue)+"#"+comment.HashTag())
+}
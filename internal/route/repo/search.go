@@ -0,0 +1,50 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package repo
+
+import (
+	"strings"
+
+	"github.com/unknwon/paginater"
+
+	"gogs.io/gogs/internal/conf"
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/database"
+)
+
+const (
+	tmplRepoSearch = "repo/search"
+)
+
+// SearchCode searches files of the default branch of the repository.
+func SearchCode(c *context.Context) {
+	if !database.IsCodeSearchEnabled() {
+		c.NotFound()
+		return
+	}
+
+	c.Data["Title"] = c.Tr("repo.search.code")
+	c.Data["PageIsViewFiles"] = true
+
+	page := c.QueryInt("page")
+	if page <= 0 {
+		page = 1
+	}
+
+	keyword := strings.TrimSpace(c.Query("q"))
+	c.Data["Keyword"] = keyword
+	if keyword != "" {
+		results, total, err := database.SearchCode([]*database.Repository{c.Repo.Repository}, keyword, page, conf.UI.ExplorePagingNum)
+		if err != nil {
+			c.Error(err, "search code")
+			return
+		}
+		c.Data["Results"] = results
+		c.Data["Total"] = total
+		c.Data["Page"] = paginater.New(total, conf.UI.ExplorePagingNum, page, 5)
+	}
+
+	c.Success(tmplRepoSearch)
+}
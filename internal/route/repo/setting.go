@@ -153,6 +153,7 @@ func SettingsPost(c *context.Context, f form.RepoSetting) {
 		repo.ExternalTrackerURL = f.ExternalTrackerURL
 		repo.ExternalTrackerFormat = f.TrackerURLFormat
 		repo.ExternalTrackerStyle = f.TrackerIssueStyle
+		repo.BlockCloseByDependencies = f.BlockCloseByDependencies
 		repo.EnablePulls = f.EnablePulls
 		repo.PullsIgnoreWhitespace = f.PullsIgnoreWhitespace
 		repo.PullsAllowRebase = f.PullsAllowRebase
@@ -480,6 +481,8 @@ func UpdateDefaultBranch(c *context.Context) {
 		c.Error(err, "update repository")
 		return
 	}
+	database.UpdateRepoIndexer(c.Repo.Repository.ID)
+	database.UpdateLanguageStatsByRepoID(c.Repo.Repository.ID)
 
 	c.Flash.Success(c.Tr("repo.settings.update_default_branch_success"))
 	c.Redirect(c.Repo.RepoLink + "/settings/branches")
@@ -553,6 +556,12 @@ func SettingsProtectedBranchPost(c *context.Context, f form.ProtectBranch) {
 
 	protectBranch.Protected = f.Protected
 	protectBranch.RequirePullRequest = f.RequirePullRequest
+	protectBranch.RequireStatusChecks = f.RequireStatusChecks
+	protectBranch.StatusCheckContexts = f.StatusCheckContexts
+	protectBranch.RequiredApprovals = f.RequiredApprovals
+	if protectBranch.RequiredApprovals < 0 {
+		protectBranch.RequiredApprovals = 0
+	}
 	protectBranch.EnableWhitelist = f.EnableWhitelist
 	if c.Repo.Owner.IsOrganization() {
 		err = database.UpdateOrgProtectBranch(c.Repo.Repository, protectBranch, f.WhitelistUsers, f.WhitelistTeams)
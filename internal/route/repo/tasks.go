@@ -70,5 +70,9 @@ func TriggerTask(c *macaron.Context) {
 
 	go database.HookQueue.Add(repo.ID)
 	go database.AddTestPullRequestTask(pusher, repo.ID, branch, true)
+	if branch == repo.DefaultBranch {
+		database.UpdateRepoIndexer(repo.ID)
+		database.UpdateLanguageStatsByRepoID(repo.ID)
+	}
 	c.Status(http.StatusAccepted)
 }
@@ -90,6 +90,12 @@ func renderDirectory(c *context.Context, treeLink string) {
 			case markup.TypeOrgMode:
 				c.Data["IsMarkdown"] = true
 				p = markup.OrgMode(p, treeLink, c.Repo.Repository.ComposeMetas())
+			case markup.TypeAsciiDoc:
+				c.Data["IsMarkdown"] = true
+				p = markup.AsciiDoc(p, treeLink, c.Repo.Repository.ComposeMetas())
+			case markup.TypeReStructuredText:
+				c.Data["IsMarkdown"] = true
+				p = markup.ReStructuredText(p, treeLink, c.Repo.Repository.ComposeMetas())
 			case markup.TypeIPythonNotebook:
 				c.Data["IsIPythonNotebook"] = true
 				c.Data["RawFileLink"] = c.Repo.RepoLink + "/raw/" + path.Join(c.Repo.BranchName, c.Repo.TreePath, readmeFile.Name())
@@ -131,7 +137,6 @@ func renderFile(c *context.Context, entry *git.TreeEntry, treeLink, rawLink stri
 
 	c.Data["FileSize"] = blob.Size()
 	c.Data["FileName"] = blob.Name()
-	c.Data["HighlightClass"] = highlight.FileNameToHighlightClass(blob.Name())
 	c.Data["RawFileLink"] = rawLink + "/" + c.Repo.TreePath
 
 	isTextFile := tool.IsTextFile(p)
@@ -152,41 +157,36 @@ func renderFile(c *context.Context, entry *git.TreeEntry, treeLink, rawLink stri
 
 		c.Data["ReadmeExist"] = markup.IsReadmeFile(blob.Name())
 
-		switch markup.Detect(blob.Name()) {
+		switch typ := markup.Detect(blob.Name()); typ {
 		case markup.TypeMarkdown:
 			c.Data["IsMarkdown"] = true
 			c.Data["FileContent"] = string(markup.Markdown(p, path.Dir(treeLink), c.Repo.Repository.ComposeMetas()))
 		case markup.TypeOrgMode:
 			c.Data["IsMarkdown"] = true
 			c.Data["FileContent"] = string(markup.OrgMode(p, path.Dir(treeLink), c.Repo.Repository.ComposeMetas()))
+		case markup.TypeAsciiDoc:
+			c.Data["IsMarkdown"] = true
+			c.Data["FileContent"] = string(markup.AsciiDoc(p, path.Dir(treeLink), c.Repo.Repository.ComposeMetas()))
+		case markup.TypeReStructuredText:
+			c.Data["IsMarkdown"] = true
+			c.Data["FileContent"] = string(markup.ReStructuredText(p, path.Dir(treeLink), c.Repo.Repository.ComposeMetas()))
 		case markup.TypeIPythonNotebook:
 			c.Data["IsIPythonNotebook"] = true
-		default:
-			// Building code view blocks with line number on server side.
-			var fileContent string
-			if content, err := template.ToUTF8WithErr(p); err != nil {
-				log.Error("ToUTF8WithErr: %s", err)
-				fileContent = string(p)
-			} else {
-				fileContent = content
-			}
-
-			var output bytes.Buffer
-			lines := strings.Split(fileContent, "\n")
-			// Remove blank line at the end of file
-			if len(lines) > 0 && lines[len(lines)-1] == "" {
-				lines = lines[:len(lines)-1]
+		case markup.TypeCSV, markup.TypeTSV:
+			if blob.Size() <= conf.Markup.CSV.MaxFileSize {
+				table, truncated, err := markup.RawCSV(p, markup.CSVComma(typ), conf.Markup.CSV.MaxRows)
+				if err == nil {
+					c.Data["IsCSV"] = true
+					c.Data["CSVTruncated"] = truncated
+					c.Data["CSVMaxRows"] = conf.Markup.CSV.MaxRows
+					c.Data["FileContent"] = gotemplate.HTML(table)
+					break
+				}
+				log.Trace("Failed to render %q as table: %v", c.Repo.TreePath, err)
 			}
-			for index, line := range lines {
-				output.WriteString(fmt.Sprintf(`<li class="L%d" rel="L%d">%s</li>`, index+1, index+1, gotemplate.HTMLEscapeString(strings.TrimRight(line, "\r"))) + "\n")
-			}
-			c.Data["FileContent"] = gotemplate.HTML(output.String())
-
-			output.Reset()
-			for i := 0; i < len(lines); i++ {
-				output.WriteString(fmt.Sprintf(`<span id="L%d">%d</span>`, i+1, i+1))
-			}
-			c.Data["LineNums"] = gotemplate.HTML(output.String())
+			renderCode(c, blob, p)
+		default:
+			renderCode(c, blob, p)
 		}
 
 		if canEnableEditor {
@@ -216,6 +216,37 @@ func renderFile(c *context.Context, entry *git.TreeEntry, treeLink, rawLink stri
 	}
 }
 
+// renderCode builds code view blocks with line number on server side.
+func renderCode(c *context.Context, blob *git.Blob, p []byte) {
+	var fileContent string
+	if content, err := template.ToUTF8WithErr(p); err != nil {
+		log.Error("ToUTF8WithErr: %s", err)
+		fileContent = string(p)
+	} else {
+		fileContent = content
+	}
+
+	attrs := gitutil.ReadAttributes(c.Repo.Commit, c.Repo.TreePath)
+	lexer := highlight.Lexer(blob.Name(), attrs.Language(c.Repo.TreePath), p)
+
+	var output bytes.Buffer
+	lines := highlight.Lines(lexer, strings.ReplaceAll(fileContent, "\r\n", "\n"))
+	// Remove blank line at the end of file
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	for index, line := range lines {
+		output.WriteString(fmt.Sprintf(`<li class="L%d" rel="L%d">%s</li>`, index+1, index+1, line) + "\n")
+	}
+	c.Data["FileContent"] = gotemplate.HTML(output.String())
+
+	output.Reset()
+	for i := 0; i < len(lines); i++ {
+		output.WriteString(fmt.Sprintf(`<span id="L%d">%d</span>`, i+1, i+1))
+	}
+	c.Data["LineNums"] = gotemplate.HTML(output.String())
+}
+
 func setEditorconfigIfExists(c *context.Context) {
 	ec, err := c.Repo.Editorconfig()
 	if err != nil && !gitutil.IsErrRevisionNotExist(err) {
@@ -261,6 +292,12 @@ func Home(c *context.Context) {
 			return
 		}
 		c.Data["CommitsCount"] = c.Repo.CommitsCount
+
+		c.Data["LanguageStats"], err = database.GetLanguageStats(c.Repo.Repository.ID)
+		if err != nil {
+			c.Error(err, "get language stats")
+			return
+		}
 	}
 	c.Data["PageIsRepoHome"] = isRootDir
 
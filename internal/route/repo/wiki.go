@@ -5,6 +5,7 @@
 package repo
 
 import (
+	"path"
 	"strings"
 	"time"
 
@@ -42,6 +43,27 @@ type PageMeta struct {
 	Updated time.Time
 }
 
+// wikiPageName returns the name of the wiki page stored in the file, and
+// whether the file is a wiki page. Pages are Markdown files created on the web,
+// or AsciiDoc and reStructuredText files pushed to the wiki repository.
+func wikiPageName(entry *git.TreeEntry) (string, bool) {
+	if entry.Type() != git.ObjectBlob {
+		return "", false
+	}
+
+	name := entry.Name()
+	if strings.HasSuffix(name, ".md") {
+		return strings.TrimSuffix(name, ".md"), true
+	}
+	switch markup.Detect(name) {
+	case markup.TypeAsciiDoc, markup.TypeReStructuredText:
+		return strings.TrimSuffix(name, path.Ext(name)), true
+	}
+	return "", false
+}
+
+// renderWikiPage renders the wiki page and returns the wiki repository and name
+// of the file that stores the page.
 func renderWikiPage(c *context.Context, isViewPage bool) (*git.Repository, string) {
 	wikiRepo, err := git.Open(c.Repo.Repository.WikiPath())
 	if err != nil {
@@ -53,18 +75,17 @@ func renderWikiPage(c *context.Context, isViewPage bool) (*git.Repository, strin
 		c.Error(err, "get branch commit")
 		return nil, ""
 	}
+	entries, err := commit.Entries()
+	if err != nil {
+		c.Error(err, "list entries")
+		return nil, ""
+	}
 
 	// Get page list.
 	if isViewPage {
-		entries, err := commit.Entries()
-		if err != nil {
-			c.Error(err, "list entries")
-			return nil, ""
-		}
 		pages := make([]PageMeta, 0, len(entries))
 		for i := range entries {
-			if entries[i].Type() == git.ObjectBlob && strings.HasSuffix(entries[i].Name(), ".md") {
-				name := strings.TrimSuffix(entries[i].Name(), ".md")
+			if name, ok := wikiPageName(entries[i]); ok {
 				pages = append(pages, PageMeta{
 					Name: name,
 					URL:  database.ToWikiPageURL(name),
@@ -86,13 +107,33 @@ func renderWikiPage(c *context.Context, isViewPage bool) (*git.Repository, strin
 	c.Data["title"] = pageName
 	c.Data["RequireHighlightJS"] = true
 
-	blob, err := commit.Blob(pageName + ".md")
-	if err != nil {
-		if gitutil.IsErrRevisionNotExist(err) {
-			c.Redirect(c.Repo.RepoLink + "/wiki/_pages")
-		} else {
-			c.Error(err, "get blob")
+	// Markdown pages take precedence over pages in other markup languages with
+	// the same name.
+	var fileName string
+	for i := range entries {
+		if name, ok := wikiPageName(entries[i]); ok && name == pageName {
+			fileName = entries[i].Name()
+			if strings.HasSuffix(fileName, ".md") {
+				break
+			}
 		}
+	}
+	if fileName == "" {
+		c.Redirect(c.Repo.RepoLink + "/wiki/_pages")
+		return nil, ""
+	}
+
+	// Only Markdown pages can be edited on the web.
+	isMarkdown := strings.HasSuffix(fileName, ".md")
+	c.Data["IsMarkdownPage"] = isMarkdown
+	if !isViewPage && !isMarkdown {
+		c.Redirect(c.Repo.RepoLink + "/wiki/" + pageURL)
+		return nil, ""
+	}
+
+	blob, err := commit.Blob(fileName)
+	if err != nil {
+		c.NotFoundOrError(gitutil.NewError(err), "get blob")
 		return nil, ""
 	}
 	p, err := blob.Bytes()
@@ -100,13 +141,16 @@ func renderWikiPage(c *context.Context, isViewPage bool) (*git.Repository, strin
 		c.Error(err, "read blob")
 		return nil, ""
 	}
-	if isViewPage {
-		c.Data["content"] = string(markup.Markdown(p, c.Repo.RepoLink, c.Repo.Repository.ComposeMetas()))
-	} else {
+	switch {
+	case !isViewPage:
 		c.Data["content"] = string(p)
+	case isMarkdown:
+		c.Data["content"] = string(markup.Markdown(p, c.Repo.RepoLink, c.Repo.Repository.ComposeMetas()))
+	default:
+		c.Data["content"] = string(markup.Render(markup.Detect(fileName), p, c.Repo.RepoLink, c.Repo.Repository.ComposeMetas()))
 	}
 
-	return wikiRepo, pageName
+	return wikiRepo, fileName
 }
 
 func Wiki(c *context.Context) {
@@ -118,13 +162,13 @@ func Wiki(c *context.Context) {
 		return
 	}
 
-	wikiRepo, pageName := renderWikiPage(c, true)
+	wikiRepo, fileName := renderWikiPage(c, true)
 	if c.Written() {
 		return
 	}
 
 	// Get last change information.
-	commits, err := wikiRepo.Log(git.RefsHeads+"master", git.LogOptions{Path: pageName + ".md"})
+	commits, err := wikiRepo.Log(git.RefsHeads+"master", git.LogOptions{Path: fileName})
 	if err != nil {
 		c.Error(err, "get commits by path")
 		return
@@ -161,13 +205,12 @@ func WikiPages(c *context.Context) {
 	}
 	pages := make([]PageMeta, 0, len(entries))
 	for i := range entries {
-		if entries[i].Type() == git.ObjectBlob && strings.HasSuffix(entries[i].Name(), ".md") {
+		if name, ok := wikiPageName(entries[i]); ok {
 			commits, err := wikiRepo.Log(git.RefsHeads+"master", git.LogOptions{Path: entries[i].Name()})
 			if err != nil {
 				c.Error(err, "get commits by path")
 				return
 			}
-			name := strings.TrimSuffix(entries[i].Name(), ".md")
 			pages = append(pages, PageMeta{
 				Name:    name,
 				URL:     database.ToWikiPageURL(name),
@@ -15,6 +15,7 @@ import (
 	"gogs.io/gogs/internal/conf"
 	"gogs.io/gogs/internal/context"
 	"gogs.io/gogs/internal/database"
+	"gogs.io/gogs/internal/route/repo"
 )
 
 const (
@@ -214,6 +215,9 @@ func Issues(c *context.Context) {
 			string(database.FilterModeAssign),
 			string(database.FilterModeCreate),
 		}
+		if isPullList {
+			types = append(types, string(database.FilterModeReviewRequested))
+		}
 		if !com.IsSliceContainsStr(types, viewType) {
 			viewType = string(database.FilterModeYourRepos)
 		}
@@ -227,6 +231,11 @@ func Issues(c *context.Context) {
 
 	repoID := c.QueryInt64("repo")
 	isShowClosed := c.Query("state") == "closed"
+	due := database.ParseDueFilter(c.Query("due"))
+	filter := repo.ParseIssueQuery(c, &isShowClosed, &sortType)
+	if c.Written() {
+		return
+	}
 
 	// Get repositories.
 	var (
@@ -294,7 +303,9 @@ func Issues(c *context.Context) {
 		Page:     page,
 		IsClosed: isShowClosed,
 		IsPull:   isPullList,
+		Due:      due,
 		SortType: sortType,
+		Filter:   filter,
 	}
 	switch filterMode {
 	case database.FilterModeYourRepos:
@@ -312,6 +323,10 @@ func Issues(c *context.Context) {
 	case database.FilterModeCreate:
 		// Get all issues created by this user.
 		issueOptions.PosterID = ctxUser.ID
+
+	case database.FilterModeReviewRequested:
+		// Get all pull requests requested this user to review.
+		issueOptions.ReviewerID = ctxUser.ID
 	}
 
 	issues, err := database.Issues(issueOptions)
@@ -346,7 +361,7 @@ func Issues(c *context.Context) {
 		}
 	}
 
-	issueStats := database.GetUserIssueStats(repoID, ctxUser.ID, userRepoIDs, filterMode, isPullList)
+	issueStats := database.GetUserIssueStats(repoID, ctxUser.ID, userRepoIDs, filterMode, isPullList, due, filter)
 
 	var total int
 	if !isShowClosed {
@@ -363,6 +378,7 @@ func Issues(c *context.Context) {
 	c.Data["SortType"] = sortType
 	c.Data["RepoID"] = repoID
 	c.Data["IsShowClosed"] = isShowClosed
+	c.Data["Due"] = due
 
 	if isShowClosed {
 		c.Data["State"] = "closed"
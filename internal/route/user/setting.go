@@ -54,6 +54,7 @@ const (
 	tmplUserSettingsRepositories           = "user/settings/repositories"
 	tmplUserSettingsOrganizations          = "user/settings/organizations"
 	tmplUserSettingsApplications           = "user/settings/applications"
+	tmplUserSettingsSearches               = "user/settings/searches"
 	tmplUserSettingsDelete                 = "user/settings/delete"
 	tmplUserNotification                   = "user/notification"
 )
@@ -594,6 +595,62 @@ func SettingsLeaveOrganization(c *context.Context) {
 	})
 }
 
+func SettingsSearches(c *context.Context) {
+	c.Title("settings.searches")
+	c.PageIs("SettingsSearches")
+
+	searches, err := database.GetSavedSearchesByUserID(c.User.ID)
+	if err != nil {
+		c.Errorf(err, "get saved searches by user ID")
+		return
+	}
+	c.Data["Searches"] = searches
+	c.Data["query"] = c.Query("query")
+
+	c.Success(tmplUserSettingsSearches)
+}
+
+func SettingsSearchesPost(c *context.Context, f form.SavedSearch) {
+	c.Title("settings.searches")
+	c.PageIs("SettingsSearches")
+
+	if c.HasError() {
+		searches, err := database.GetSavedSearchesByUserID(c.User.ID)
+		if err != nil {
+			c.Errorf(err, "get saved searches by user ID")
+			return
+		}
+		c.Data["Searches"] = searches
+		c.Success(tmplUserSettingsSearches)
+		return
+	}
+
+	if _, err := database.NewSavedSearch(c.User.ID, f.Name, f.Query); err != nil {
+		if database.IsErrSavedSearchAlreadyExist(err) {
+			c.Flash.Error(c.Tr("settings.search_name_exists"))
+			c.RedirectSubpath("/user/settings/searches")
+		} else {
+			c.Errorf(err, "new saved search")
+		}
+		return
+	}
+
+	c.Flash.Success(c.Tr("settings.save_search_success"))
+	c.RedirectSubpath("/user/settings/searches")
+}
+
+func DeleteSavedSearch(c *context.Context) {
+	if err := database.DeleteSavedSearch(c.User.ID, c.QueryInt64("id")); err != nil {
+		c.Flash.Error("DeleteSavedSearch: " + err.Error())
+	} else {
+		c.Flash.Success(c.Tr("settings.delete_search_success"))
+	}
+
+	c.JSONSuccess(map[string]any{
+		"redirect": conf.Server.Subpath + "/user/settings/searches",
+	})
+}
+
 func (h *SettingsHandler) Applications() macaron.Handler {
 	return func(c *context.Context) {
 		c.Title("settings.applications")
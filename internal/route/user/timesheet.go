@@ -0,0 +1,132 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package user
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"gogs.io/gogs/internal/conf"
+	"gogs.io/gogs/internal/context"
+	"gogs.io/gogs/internal/database"
+)
+
+const tmplUserDashboardTimesheet = "user/dashboard/timesheet"
+
+// parseTimesheetRange returns the inclusive range of dates from query
+// parameters "from" and "to", which defaults to the current month until today.
+func parseTimesheetRange(c *context.Context) (from, to time.Time) {
+	now := time.Now()
+	from = time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
+	to = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if t, err := time.ParseInLocation("2006-01-02", c.Query("from"), time.Local); err == nil {
+		from = t
+	}
+	if t, err := time.ParseInLocation("2006-01-02", c.Query("to"), time.Local); err == nil {
+		to = t
+	}
+	return from, to
+}
+
+func getTimesheet(c *context.Context) ([]*database.TrackedTime, time.Time, time.Time) {
+	from, to := parseTimesheetRange(c)
+	times, err := database.GetTrackedTimes(database.TrackedTimeOptions{
+		UserID: c.User.ID,
+		Since:  from,
+		Before: to.AddDate(0, 0, 1),
+	})
+	if err != nil {
+		c.Error(err, "get tracked times")
+		return nil, from, to
+	}
+	return times, from, to
+}
+
+// Timesheet shows time tracked by the current user in the range of dates.
+func Timesheet(c *context.Context) {
+	c.Data["Title"] = c.Tr("timesheet")
+	c.Data["PageIsTimesheet"] = true
+
+	times, from, to := getTimesheet(c)
+	if c.Written() {
+		return
+	}
+	c.Data["TrackedTimes"] = times
+	c.Data["From"] = from.Format("2006-01-02")
+	c.Data["To"] = to.Format("2006-01-02")
+
+	var total int64
+	for _, t := range times {
+		total += t.Time
+	}
+	c.Data["TotalTrackedTime"] = total
+
+	stopwatches, err := database.GetUserStopwatches(c.User.ID)
+	if err != nil {
+		c.Error(err, "get user stopwatches")
+		return
+	}
+	c.Data["Stopwatches"] = stopwatches
+
+	c.Success(tmplUserDashboardTimesheet)
+}
+
+// escapeCSVCell prefixes the value with a single quote if it starts with a
+// character that spreadsheet applications interpret as a formula.
+func escapeCSVCell(s string) string {
+	if s != "" && strings.ContainsRune("=+-@", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+// ExportTimesheet exports time tracked by the current user in the range of
+// dates as a CSV file.
+func ExportTimesheet(c *context.Context) {
+	times, from, to := getTimesheet(c)
+	if c.Written() {
+		return
+	}
+
+	c.Resp.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	c.Resp.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="timesheet_%s_%s.csv"`, from.Format("20060102"), to.Format("20060102")))
+
+	w := csv.NewWriter(c.Resp)
+	_ = w.Write([]string{"date", "repository", "issue", "title", "seconds", "time"})
+	for _, t := range times {
+		_ = w.Write([]string{
+			t.Created.Format("2006-01-02"),
+			escapeCSVCell(t.Issue.Repo.FullName()),
+			strconv.FormatInt(t.Issue.Index, 10),
+			escapeCSVCell(t.Issue.Title),
+			strconv.FormatInt(t.Time, 10),
+			database.FormatTrackedTime(t.Time),
+		})
+	}
+	w.Flush()
+}
+
+// DeleteTrackedTime deletes a tracked time entry of the current user.
+func DeleteTrackedTime(c *context.Context) {
+	t, err := database.GetTrackedTimeByID(c.QueryInt64("id"))
+	if err != nil {
+		c.NotFoundOrError(err, "get tracked time by ID")
+		return
+	} else if t.UserID != c.User.ID {
+		c.NotFound()
+		return
+	}
+
+	if err = database.DeleteTrackedTime(t.ID); err != nil {
+		c.Error(err, "delete tracked time")
+		return
+	}
+	c.Flash.Success(c.Tr("home.timesheet.delete_success"))
+	c.Redirect(fmt.Sprintf("%s/timesheet?from=%s&to=%s", conf.Server.Subpath, url.QueryEscape(c.Query("from")), url.QueryEscape(c.Query("to"))))
+}
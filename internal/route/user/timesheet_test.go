@@ -0,0 +1,31 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package user
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeCSVCell(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "", want: ""},
+		{in: "Fix bug", want: "Fix bug"},
+		{in: "a=b", want: "a=b"},
+		{in: "=HYPERLINK(\"http://evil\")", want: "'=HYPERLINK(\"http://evil\")"},
+		{in: "+1", want: "'+1"},
+		{in: "-cmd", want: "'-cmd"},
+		{in: "@SUM(A1)", want: "'@SUM(A1)"},
+	}
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			assert.Equal(t, test.want, escapeCSVCell(test.in))
+		})
+	}
+}
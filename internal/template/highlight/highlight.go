@@ -5,9 +5,15 @@
 package highlight
 
 import (
+	"bytes"
+	"html"
+	"html/template"
 	"path"
 	"strings"
 
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/lexers"
+
 	"gogs.io/gogs/internal/conf"
 )
 
@@ -18,59 +24,26 @@ var (
 		"copying": true,
 	}
 
-	// File names that are representing highlight classes.
-	highlightFileNames = map[string]bool{
-		"cmakelists.txt": true,
-		"dockerfile":     true,
-		"makefile":       true,
-	}
-
-	// Extensions that are same as highlight classes.
-	highlightExts = map[string]bool{
-		".arm":    true,
-		".as":     true,
-		".sh":     true,
-		".cs":     true,
-		".cpp":    true,
-		".c":      true,
-		".css":    true,
-		".cmake":  true,
-		".bat":    true,
-		".dart":   true,
-		".patch":  true,
-		".elixir": true,
-		".erlang": true,
-		".go":     true,
-		".html":   true,
-		".xml":    true,
-		".hs":     true,
-		".ini":    true,
-		".json":   true,
-		".java":   true,
-		".js":     true,
-		".less":   true,
-		".lua":    true,
-		".php":    true,
-		".py":     true,
-		".rb":     true,
-		".scss":   true,
-		".sql":    true,
-		".scala":  true,
-		".swift":  true,
-		".ts":     true,
-		".vb":     true,
-		".r":      true,
-		".sas":    true,
-		".tex":    true,
-		".yaml":   true,
-	}
-
-	// Extensions that are not same as highlight classes.
+	// Extensions that are mapped to names of languages, "nohighlight" means the
+	// file should not be highlighted.
 	highlightMapping = map[string]string{
 		".txt": "nohighlight",
 	}
+
+	// Interpreters of shebangs that are not names of languages.
+	interpreterLanguages = map[string]string{
+		"node":   "javascript",
+		"nodejs": "javascript",
+		"sh":     "bash",
+		"zsh":    "bash",
+		"ksh":    "bash",
+	}
 )
 
+// maxHighlightSize is the maximum size in bytes of code to be highlighted,
+// larger code is rendered as plain text.
+const maxHighlightSize = 1 << 20
+
 func NewContext() {
 	keys := conf.File.Section("highlight.mapping").Keys()
 	for i := range keys {
@@ -78,27 +51,163 @@ func NewContext() {
 	}
 }
 
-// FileNameToHighlightClass returns the best match for highlight class name
-// based on the rule of highlight.js.
-func FileNameToHighlightClass(fname string) string {
-	fname = strings.ToLower(fname)
-	if ignoreFileNames[fname] {
-		return "nohighlight"
+// shebangLanguage returns the name of the language of the interpreter in the
+// shebang of the content, e.g. "python" for "#!/usr/bin/env python3".
+func shebangLanguage(content []byte) string {
+	if !bytes.HasPrefix(content, []byte("#!")) {
+		return ""
+	}
+	line, _, _ := bytes.Cut(content[2:], []byte("\n"))
+	fields := strings.Fields(string(line))
+	if len(fields) == 0 {
+		return ""
+	}
+
+	interpreter := path.Base(fields[0])
+	if interpreter == "env" {
+		interpreter = ""
+		for _, field := range fields[1:] {
+			if !strings.HasPrefix(field, "-") && !strings.Contains(field, "=") {
+				interpreter = path.Base(field)
+				break
+			}
+		}
+	}
+
+	// Versions are not part of names, e.g. "python3.11".
+	interpreter = strings.TrimRight(interpreter, "0123456789.")
+	if lang, ok := interpreterLanguages[interpreter]; ok {
+		return lang
+	}
+	return interpreter
+}
+
+// Lexer returns the lexer of the file with given name and content. The language
+// takes precedence when specified (e.g. by linguist-language attribute), then
+// the file name and the shebang of the content. It returns nil if the file
+// should not be highlighted.
+func Lexer(fileName, language string, content []byte) chroma.Lexer {
+	if language != "" {
+		if lexer := lexers.Get(language); lexer != nil {
+			return lexer
+		}
+	}
+
+	baseName := path.Base(fileName)
+	if ignoreFileNames[strings.ToLower(baseName)] {
+		return nil
+	}
+
+	if name, ok := highlightMapping[strings.ToLower(path.Ext(baseName))]; ok {
+		if name == "nohighlight" {
+			return nil
+		}
+		if lexer := lexers.Get(name); lexer != nil {
+			return lexer
+		}
+	}
+
+	if lexer := lexers.Match(baseName); lexer != nil {
+		return lexer
+	}
+
+	if name := shebangLanguage(content); name != "" {
+		return lexers.Get(name)
+	}
+	return nil
+}
+
+// Span is a piece of a highlighted line, which is styled by the CSS class of
+// its token type unless the class is empty.
+type Span struct {
+	Class string
+	Text  string
+}
+
+// HTML returns the escaped text of the span, wrapped in an element with its
+// class if any.
+func (s Span) HTML() string {
+	if s.Class == "" {
+		return html.EscapeString(s.Text)
+	}
+	return `<span class="` + s.Class + `">` + html.EscapeString(s.Text) + `</span>`
+}
+
+// LineSpans highlights the code with the lexer and returns spans of each line
+// separated by "\n", without the line break. Spans have no class when the lexer
+// is nil or the code is too large to be highlighted.
+func LineSpans(lexer chroma.Lexer, code string) [][]Span {
+	numLines := strings.Count(code, "\n") + 1
+	lines := make([][]Span, 0, numLines)
+
+	plain := func() [][]Span {
+		lines = lines[:0]
+		for _, line := range strings.Split(code, "\n") {
+			lines = append(lines, []Span{{Text: line}})
+		}
+		return lines
 	}
 
-	if highlightFileNames[fname] {
-		return fname
+	if lexer == nil || len(code) > maxHighlightSize {
+		return plain()
+	}
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, code)
+	if err != nil {
+		return plain()
 	}
 
-	ext := path.Ext(fname)
-	if highlightExts[ext] {
-		return ext[1:]
+	var spans []Span
+	for token := iterator(); token != chroma.EOF; token = iterator() {
+		class := tokenClass(token.Type)
+		parts := strings.Split(token.Value, "\n")
+		for i, part := range parts {
+			if i > 0 {
+				lines = append(lines, spans)
+				spans = nil
+			}
+			if part != "" {
+				spans = append(spans, Span{Class: class, Text: part})
+			}
+		}
 	}
+	lines = append(lines, spans)
 
-	name, ok := highlightMapping[ext]
-	if ok {
-		return name
+	// Lexers may append a line break to the end of the code.
+	if len(lines) > numLines {
+		lines = lines[:numLines]
+	} else if len(lines) < numLines {
+		return plain()
 	}
+	return lines
+}
 
+// Lines highlights the code with the lexer and returns HTML of each line
+// separated by "\n", without the line break. The code is only escaped when the
+// lexer is nil or the code is too large to be highlighted.
+func Lines(lexer chroma.Lexer, code string) []template.HTML {
+	spans := LineSpans(lexer, code)
+	lines := make([]template.HTML, len(spans))
+	var buf strings.Builder
+	for i := range spans {
+		buf.Reset()
+		for _, span := range spans[i] {
+			buf.WriteString(span.HTML())
+		}
+		lines[i] = template.HTML(buf.String())
+	}
+	return lines
+}
+
+// tokenClass returns the CSS class of the token type, or its closest parent
+// type that has one. Whitespaces are not styled.
+func tokenClass(t chroma.TokenType) string {
+	if t == chroma.TextWhitespace {
+		return ""
+	}
+	for ; t != 0; t = t.Parent() {
+		if class, ok := chroma.StandardTypes[t]; ok {
+			return class
+		}
+	}
 	return ""
 }
@@ -0,0 +1,58 @@
+// Copyright 2026 The Gogs Authors. All rights reserved.
+// Use of this source code is governed by a MIT-style
+// license that can be found in the LICENSE file.
+
+package highlight
+
+import (
+	"html/template"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLexer(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileName string
+		language string
+		content  string
+		want     string
+	}{
+		{name: "by extension", fileName: "main.kt", want: "Kotlin"},
+		{name: "by extension of Terraform", fileName: "infra/main.tf", want: "Terraform"},
+		{name: "by extension of Protobuf", fileName: "api.proto", want: "Protocol Buffer"},
+		{name: "by file name", fileName: "build/Dockerfile", want: "Docker"},
+		{name: "by shebang", fileName: "bin/run", content: "#!/usr/bin/env python3\nprint(1)\n", want: "Python"},
+		{name: "by language", fileName: "main.tpl", language: "terraform", want: "Terraform"},
+		{name: "ignored", fileName: "LICENSE", want: ""},
+		{name: "no highlight", fileName: "notes.txt", want: ""},
+		{name: "unknown", fileName: "data.unknown", want: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lexer := Lexer(test.fileName, test.language, []byte(test.content))
+			if test.want == "" {
+				assert.Nil(t, lexer)
+				return
+			}
+			if assert.NotNil(t, lexer) {
+				assert.Equal(t, test.want, lexer.Config().Name)
+			}
+		})
+	}
+}
+
+func TestLines(t *testing.T) {
+	code := "package main\n\n// <b>\nfunc main() {}\n"
+	lines := Lines(Lexer("main.go", "", nil), code)
+	assert.Equal(t, []template.HTML{
+		`<span class="kn">package</span> <span class="nx">main</span>`,
+		``,
+		`<span class="c1">// &lt;b&gt;</span>`,
+		`<span class="kd">func</span> <span class="nf">main</span><span class="p">()</span> <span class="p">{}</span>`,
+		``,
+	}, lines)
+
+	assert.Equal(t, []template.HTML{"a &lt; b", ""}, Lines(nil, "a < b\n"))
+}
@@ -26,6 +26,7 @@ import (
 	"gogs.io/gogs/internal/cryptoutil"
 	"gogs.io/gogs/internal/database"
 	"gogs.io/gogs/internal/gitutil"
+	"gogs.io/gogs/internal/linguist"
 	"gogs.io/gogs/internal/markup"
 	"gogs.io/gogs/internal/strutil"
 	"gogs.io/gogs/internal/tool"
@@ -70,6 +71,8 @@ func FuncMap() []template.FuncMap {
 			"ShowFooterTemplateLoadTime": func() bool {
 				return conf.Other.ShowFooterTemplateLoadTime
 			},
+			"IsCodeSearchEnabled": database.IsCodeSearchEnabled,
+			"LanguageColor":       linguist.Color,
 			"LoadTimes": func(startTime time.Time) string {
 				return fmt.Sprint(time.Since(startTime).Nanoseconds()/1e6) + "ms"
 			},
@@ -114,7 +117,13 @@ func FuncMap() []template.FuncMap {
 			"ShortSHA1":             tool.ShortSHA1,
 			"ActionContent2Commits": ActionContent2Commits,
 			"EscapePound":           EscapePound,
+			"EscapeHTML":            template.HTMLEscapeString,
 			"RenderCommitMessage":   RenderCommitMessage,
+			"ReactionTypes": func() []string {
+				return database.ReactionTypes
+			},
+			"ReactionEmoji": database.ReactionEmoji,
+			"TrackedTime":   database.FormatTrackedTime,
 			"ThemeColorMetaTag": func() string {
 				return conf.UI.ThemeColorMetaTag
 			},
@@ -245,6 +254,8 @@ func ActionIcon(opType int) string {
 		return "repo-forked"
 	case 20, 21, 22: // Mirror sync
 		return "repo-clone"
+	case 23: // Transfer issue
+		return "arrow-right"
 	default:
 		return "invalid type"
 	}
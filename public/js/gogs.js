@@ -120,12 +120,6 @@ function initCommentForm() {
 
   initCommentPreviewTab($(".comment.form"));
 
-  // Labels
-  var $list = $(".ui.labels.list");
-  var $noSelect = $list.find(".no-select");
-  var $labelMenu = $(".select-label .menu");
-  var hasLabelUpdateAction = $labelMenu.data("action") == "update";
-
   function updateIssueMeta(url, action, id) {
     $.post(url, {
       _csrf: csrf,
@@ -134,92 +128,104 @@ function initCommentForm() {
     });
   }
 
-  // Add &nbsp; to each unselected label to keep UI looks good.
-  // This should be added directly to HTML but somehow just get empty <span> on this page.
-  $labelMenu
-    .find(".item:not(.no-select) .octicon:not(.octicon-check)")
-    .each(function() {
-      $(this).html("&nbsp;");
-    });
-  $labelMenu.find(".item:not(.no-select)").click(function() {
-    if ($(this).hasClass("checked")) {
-      $(this).removeClass("checked");
-      $(this)
-        .find(".octicon")
-        .removeClass("octicon-check")
-        .html("&nbsp;");
-      if (hasLabelUpdateAction) {
-        updateIssueMeta(
-          $labelMenu.data("update-url"),
-          "detach",
-          $(this).data("id")
-        );
-      }
-    } else {
-      $(this).addClass("checked");
-      $(this)
-        .find(".octicon")
-        .addClass("octicon-check")
-        .html("");
-      if (hasLabelUpdateAction) {
-        updateIssueMeta(
-          $labelMenu.data("update-url"),
-          "attach",
-          $(this).data("id")
-        );
-      }
-    }
+  // Labels, assignees and reviewers
+  function selectMultipleItems(select_id, list_selector) {
+    var $list = $(list_selector);
+    var $noSelect = $list.find(".no-select");
+    var $menu = $(select_id + " .menu");
+    var hasUpdateAction = $menu.data("action") == "update";
 
-    var labelIds = "";
-    $(this)
-      .parent()
-      .find(".item")
+    // Add &nbsp; to each unselected item to keep UI looks good.
+    // This should be added directly to HTML but somehow just get empty <span> on this page.
+    $menu
+      .find(".item:not(.no-select) .octicon:not(.octicon-check)")
       .each(function() {
-        if ($(this).hasClass("checked")) {
-          labelIds += $(this).data("id") + ",";
-          $($(this).data("id-selector")).removeClass("hide");
-        } else {
-          $($(this).data("id-selector")).addClass("hide");
-        }
+        $(this).html("&nbsp;");
       });
-    if (labelIds.length == 0) {
-      $noSelect.removeClass("hide");
-    } else {
-      $noSelect.addClass("hide");
-    }
-    $(
-      $(this)
-        .parent()
-        .data("id")
-    ).val(labelIds);
-    return false;
-  });
-  $labelMenu.find(".no-select.item").click(function() {
-    if (hasLabelUpdateAction) {
-      updateIssueMeta($labelMenu.data("update-url"), "clear", "");
-    }
-
-    $(this)
-      .parent()
-      .find(".item")
-      .each(function() {
+    $menu.find(".item:not(.no-select)").click(function() {
+      if ($(this).hasClass("checked")) {
         $(this).removeClass("checked");
         $(this)
           .find(".octicon")
           .removeClass("octicon-check")
           .html("&nbsp;");
-      });
+        if (hasUpdateAction) {
+          updateIssueMeta(
+            $menu.data("update-url"),
+            "detach",
+            $(this).data("id")
+          );
+        }
+      } else {
+        $(this).addClass("checked");
+        $(this)
+          .find(".octicon")
+          .addClass("octicon-check")
+          .html("");
+        if (hasUpdateAction) {
+          updateIssueMeta(
+            $menu.data("update-url"),
+            "attach",
+            $(this).data("id")
+          );
+        }
+      }
 
-    $list.find(".item").each(function() {
-      $(this).addClass("hide");
+      var ids = "";
+      $(this)
+        .parent()
+        .find(".item")
+        .each(function() {
+          if ($(this).hasClass("checked")) {
+            ids += $(this).data("id") + ",";
+            $($(this).data("id-selector")).removeClass("hide");
+          } else {
+            $($(this).data("id-selector")).addClass("hide");
+          }
+        });
+      if (ids.length == 0) {
+        $noSelect.removeClass("hide");
+      } else {
+        $noSelect.addClass("hide");
+      }
+      $(
+        $(this)
+          .parent()
+          .data("id")
+      ).val(ids);
+      return false;
     });
-    $noSelect.removeClass("hide");
-    $(
+    $menu.find(".no-select.item").click(function() {
+      if (hasUpdateAction) {
+        updateIssueMeta($menu.data("update-url"), "clear", "");
+      }
+
       $(this)
         .parent()
-        .data("id")
-    ).val("");
-  });
+        .find(".item")
+        .each(function() {
+          $(this).removeClass("checked");
+          $(this)
+            .find(".octicon")
+            .removeClass("octicon-check")
+            .html("&nbsp;");
+        });
+
+      $list.find(".item").each(function() {
+        $(this).addClass("hide");
+      });
+      $noSelect.removeClass("hide");
+      $(
+        $(this)
+          .parent()
+          .data("id")
+      ).val("");
+    });
+  }
+
+  selectMultipleItems(".select-label", ".ui.labels.list");
+  selectMultipleItems(".select-assignee", ".ui.select-assignee.list");
+  selectMultipleItems(".select-reviewer", ".ui.select-reviewer.list");
 
   function selectItem(select_id, input_id) {
     var $menu = $(select_id + " .menu");
@@ -250,19 +256,6 @@ function initCommentForm() {
                 "</a>"
             );
           break;
-        case "#assignee_id":
-          $list
-            .find(".selected")
-            .html(
-              '<a class="item" href=' +
-                $(this).data("href") +
-                ">" +
-                '<img class="ui avatar image" src=' +
-                $(this).data("avatar") +
-                ">" +
-                $(this).text() +
-                "</a>"
-            );
       }
       $(".ui" + select_id + ".list .no-select").addClass("hide");
       $(input_id).val($(this).data("id"));
@@ -285,9 +278,45 @@ function initCommentForm() {
     });
   }
 
-  // Milestone and assignee
+  // Milestone
   selectItem(".select-milestone", "#milestone_id");
-  selectItem(".select-assignee", "#assignee_id");
+}
+
+function initLabelEdit() {
+  // Create label
+  var $newLabelPanel = $(".new-label.segment");
+  $(".new-label.button").click(function() {
+    $newLabelPanel.show();
+  });
+  $(".new-label.segment .cancel").click(function() {
+    $newLabelPanel.hide();
+  });
+
+  $(".color-picker").each(function() {
+    $(this).minicolors();
+  });
+  $(".precolors .color").click(function() {
+    var color_hex = $(this).data("color-hex");
+    $(".color-picker").val(color_hex);
+    $(".minicolors-swatch-color").css("background-color", color_hex);
+  });
+  $(".edit-label-button").click(function() {
+    $("#label-modal-id").val($(this).data("id"));
+    $(".edit-label .new-label-input").val($(this).data("title"));
+    $(".edit-label .color-picker").val($(this).data("color"));
+    $(".minicolors-swatch-color").css(
+      "background-color",
+      $(this).data("color")
+    );
+    $(".edit-label.modal")
+      .modal({
+        onApprove: function() {
+          $(".edit-label.form").submit();
+        }
+      })
+      .modal("show");
+    return false;
+  });
 }
 
 function initRepository() {
@@ -365,40 +394,7 @@ function initRepository() {
 
   // Labels
   if ($(".repository.labels").length > 0) {
-    // Create label
-    var $newLabelPanel = $(".new-label.segment");
-    $(".new-label.button").click(function() {
-      $newLabelPanel.show();
-    });
-    $(".new-label.segment .cancel").click(function() {
-      $newLabelPanel.hide();
-    });
-
-    $(".color-picker").each(function() {
-      $(this).minicolors();
-    });
-    $(".precolors .color").click(function() {
-      var color_hex = $(this).data("color-hex");
-      $(".color-picker").val(color_hex);
-      $(".minicolors-swatch-color").css("background-color", color_hex);
-    });
-    $(".edit-label-button").click(function() {
-      $("#label-modal-id").val($(this).data("id"));
-      $(".edit-label .new-label-input").val($(this).data("title"));
-      $(".edit-label .color-picker").val($(this).data("color"));
-      $(".minicolors-swatch-color").css(
-        "background-color",
-        $(this).data("color")
-      );
-      $(".edit-label.modal")
-        .modal({
-          onApprove: function() {
-            $(".edit-label.form").submit();
-          }
-        })
-        .modal("show");
-      return false;
-    });
+    initLabelEdit();
   }
 
   // Milestones
@@ -1000,6 +996,11 @@ function initOrganization() {
     return;
   }
 
+  // Labels
+  if ($(".organization.settings.labels").length > 0) {
+    initLabelEdit();
+  }
+
   // Options
   if ($(".organization.settings.options").length > 0) {
     $("#org_name").keyup(function() {
@@ -1335,6 +1336,32 @@ function initCodeView() {
   }
 }
 
+function initCSVView() {
+  // Sort rows by values of the column, numbers are compared numerically.
+  $(".csv-view thead th").click(function() {
+    var $th = $(this);
+    var $table = $th.closest("table");
+    var index = $th.index();
+    var ascending = !$th.hasClass("ascending");
+    $table.find("thead th").removeClass("ascending descending");
+    $th.addClass(ascending ? "ascending" : "descending");
+
+    var rows = $table.find("tbody > tr").get();
+    rows.sort(function(a, b) {
+      var x = $(a).children().eq(index).text();
+      var y = $(b).children().eq(index).text();
+      var result;
+      if (x.trim() !== "" && y.trim() !== "" && !isNaN(x) && !isNaN(y)) {
+        result = Number(x) - Number(y);
+      } else {
+        result = x.localeCompare(y);
+      }
+      return ascending ? result : -result;
+    });
+    $table.children("tbody").append(rows);
+  });
+}
+
 function initUserSettings() {
   console.log("initUserSettings");
 
@@ -1411,6 +1438,79 @@ function initWebhookSettings() {
   });
 }
 
+function initProjectBoard() {
+  var $columns = $(".project-columns");
+  var moveURL = $columns.data("move-url");
+  if (!moveURL) {
+    return;
+  }
+
+  var $dragging = null;
+  var dropped = false;
+  $columns.on("dragstart", ".project-card", function(e) {
+    $dragging = $(this);
+    dropped = false;
+    $dragging.addClass("dragging");
+    e.originalEvent.dataTransfer.effectAllowed = "move";
+    e.originalEvent.dataTransfer.setData("text/plain", $dragging.data("id"));
+  });
+  $columns.on("dragend", ".project-card", function() {
+    $(this).removeClass("dragging");
+    $dragging = null;
+    // The card has been moved around but not dropped into any column.
+    if (!dropped) {
+      window.location.reload();
+    }
+  });
+  $columns.on("dragover", ".cards", function(e) {
+    if (!$dragging) {
+      return;
+    }
+    e.preventDefault();
+
+    // Place the card before the first card below the pointer.
+    var $cards = $(this);
+    var $before = null;
+    $cards
+      .children(".project-card")
+      .not($dragging)
+      .each(function() {
+        var rect = this.getBoundingClientRect();
+        if (e.originalEvent.clientY < rect.top + rect.height / 2) {
+          $before = $(this);
+          return false;
+        }
+      });
+    if ($before) {
+      $dragging.insertBefore($before);
+    } else {
+      $cards.append($dragging);
+    }
+  });
+  $columns.on("drop", ".cards", function(e) {
+    if (!$dragging) {
+      return;
+    }
+    e.preventDefault();
+    dropped = true;
+
+    var $cards = $(this);
+    $.post(moveURL + "/" + $dragging.data("id") + "/move", {
+      _csrf: csrf,
+      column: $cards.data("column"),
+      position: $cards.children(".project-card").index($dragging)
+    }).fail(function() {
+      window.location.reload();
+    });
+
+    $columns.find(".project-column").each(function() {
+      $(this)
+        .find(".column-header .label")
+        .text($(this).find(".project-card").length);
+    });
+  });
+}
+
 $(document).ready(function() {
   csrf = $("meta[name=_csrf]").attr("content");
   suburl = $("meta[name=_suburl]").attr("content");
@@ -1676,6 +1776,7 @@ $(document).ready(function() {
   initOrganization();
   initAdmin();
   initCodeView();
+  initCSVView();
 
   // Repo clone url.
   if ($("#repo-clone-url").length > 0) {
@@ -1694,7 +1795,8 @@ $(document).ready(function() {
   var routes = {
     "div.user.settings": initUserSettings,
     "div.repository.settings.collaboration": initRepositoryCollaboration,
-    "div.webhook.settings": initWebhookSettings
+    "div.webhook.settings": initWebhookSettings,
+    "div.project.board": initProjectBoard
   };
 
   var selector;